@@ -9,9 +9,11 @@
 #   chugware/internal/version.BuildDate – UTC build date    (e.g. "2026-02-22")
 #   chugware/internal/version.GitCommit – short git hash    (e.g. "abc1234")
 #
-# Both executables are built:
+# All executables are built:
 #   ChugWare2.exe   – the main contest-management GUI application
 #   htmlgen.exe     – the standalone HTML contest browser generator
+#   migrate.exe     – converts contest folders between JSON and database storage
+#   export.exe      – exports a contest's results as an XLSX or ODS spreadsheet
 
 param(
     [string]$Version = "1.0.0"
@@ -65,6 +67,26 @@ if ($LASTEXITCODE -ne 0) {
 }
 Write-Host "  -> htmlgen.exe" -ForegroundColor Green
 
+# ── Build migrate ─────────────────────────────────────────────────────────────
+
+Write-Host "Building migrate.exe ..." -ForegroundColor Yellow
+go build -ldflags $LdFlags -o migrate.exe ./cmd/migrate/
+if ($LASTEXITCODE -ne 0) {
+    Write-Error "Build failed for migrate.exe"
+    exit 1
+}
+Write-Host "  -> migrate.exe" -ForegroundColor Green
+
+# ── Build export ──────────────────────────────────────────────────────────────
+
+Write-Host "Building export.exe ..." -ForegroundColor Yellow
+go build -ldflags $LdFlags -o export.exe ./cmd/export/
+if ($LASTEXITCODE -ne 0) {
+    Write-Error "Build failed for export.exe"
+    exit 1
+}
+Write-Host "  -> export.exe" -ForegroundColor Green
+
 # ── Done ──────────────────────────────────────────────────────────────────────
 
 Write-Host ""
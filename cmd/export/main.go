@@ -0,0 +1,74 @@
+// export – writes the results of a ChugWare contest as a spreadsheet.
+//
+// Usage:
+//
+//	export --contest <folder> [--root <ChugWare folder>] [--format xlsx|ods] [--out <file>]
+//
+// The contest folder (a name inside --root, or a path) is read from its
+// contest/ data in either storage backend. The spreadsheet has a summary
+// sheet and one sheet per discipline with rank, name, program, team, base,
+// penalty and total time, status and comment. Without --out it is written to
+// the contest's results/ folder.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"chugware/internal/config"
+	"chugware/internal/export"
+)
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ─── entry point ──────────────────────────────────────────────────────────────
+
+func main() {
+	root := flag.String("root", "ChugWare", "Path to ChugWare contests folder")
+	contest := flag.String("contest", "", "Contest folder to export (name inside --root, or a path)")
+	format := flag.String("format", export.FormatXLSX, "Spreadsheet format: xlsx or ods")
+	out := flag.String("out", "", "Output file (default: results/contest_results_<time>.<format> in the contest folder)")
+	flag.Parse()
+
+	if *contest == "" {
+		fmt.Fprintln(os.Stderr, "error: --contest is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	folder := *contest
+	if !filepath.IsAbs(folder) && !exists(folder) {
+		folder = filepath.Join(*root, *contest)
+	}
+	if !exists(filepath.Join(folder, config.ContestDirectory)) {
+		fmt.Fprintf(os.Stderr, "error: no contest data found in %s\n", folder)
+		os.Exit(1)
+	}
+
+	c, err := export.LoadContest(folder)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading contest: %v\n", err)
+		os.Exit(1)
+	}
+
+	filename := *out
+	if filename == "" {
+		filename = filepath.Join(folder, config.ResultsDirectory,
+			fmt.Sprintf("contest_results_%s.%s", time.Now().Format("20060102_150405"), strings.ToLower(*format)))
+	} else if filepath.Ext(filename) == "" {
+		filename += "." + strings.ToLower(*format)
+	}
+
+	if err := export.ContestWorkbook(c).WriteFile(filename); err != nil {
+		fmt.Fprintf(os.Stderr, "error exporting results: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Exported %s (%d result(s)) to: %s\n", c.Name, len(c.Results), filename)
+}
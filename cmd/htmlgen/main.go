@@ -5,56 +5,130 @@
 //	htmlgen [--root <ChugWare folder>] [--out <output.html>]
 //
 // Scans every sub-folder inside the ChugWare root directory, reads
-// contest/participants.json and contest/results.json, then writes a
+// contest/participants.json and contest/results.json (or contest/contest.db
+// for contests migrated to the database store), then writes a
 // single self-contained HTML file you can open in any browser.
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
-	"math"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/models"
 )
 
 // ─── data types ──────────────────────────────────────────────────────────────
 
-type Participant struct {
-	Name        string `json:"name"`
-	Program     string `json:"program"`
-	Team        string `json:"team"`
-	Bottle      string `json:"bottle"`
-	HalfTankard string `json:"half_tankard"`
-	FullTankard string `json:"full_tankard"`
-}
-
-type Result struct {
-	Name           string `json:"name"`
-	Discipline     string `json:"discipline"`
-	Time           string `json:"time"`
-	BaseTime       string `json:"base_time"`
-	AdditionalTime string `json:"additional_time"`
-	Status         string `json:"status"`
-	Comment        string `json:"comment"`
-}
-
 type RankedResult struct {
 	Rank           int
+	Place          string // Rank as shown: "T-2" for a shared placing
+	Tied           bool
 	Name           string
 	Program        string
 	Team           string
+	Roster         string // team results: members in running order
 	Discipline     string
 	Time           string
 	BaseTime       string
 	AdditionalTime string
+	Penalties      string // the itemized penalties behind AdditionalTime
+	Record         string // the records the result broke, e.g. "NEW RECORD: all-time record"
 	Status         string
 	Comment        string
+	Attempts       []RankedResult // the competitor's attempts that do not count
+}
+
+type RankedLeg struct {
+	Rank int
+	Name string
+	Team string
+	Leg  int
+	Time string
+}
+
+// PointsRow is a line of the championship standings.
+type PointsRow struct {
+	Rank      int
+	Place     string // "T-2" for a shared placing
+	Name      string
+	Program   string
+	Points    int
+	Breakdown string // points per discipline, e.g. "Bottle 8, Half Tankard 10"
+}
+
+// StandingsTab is a tab of the championship standings.
+type StandingsTab struct {
+	Name       string
+	Individual bool // rows are participants rather than factions
+	Rows       []PointsRow
+}
+
+// SeriesRow is a competitor's line in a season series table.
+type SeriesRow struct {
+	Rank      int
+	Place     string // "T-2" for a shared placing
+	Name      string
+	Points    int
+	Breakdown string // points per contest, those that do not count in brackets
+}
+
+// SeriesTab is a season series table: overall or one discipline.
+type SeriesTab struct {
+	Name string
+	Rows []SeriesRow
+}
+
+// Series is a season series defined in the series file of the root folder.
+type Series struct {
+	Name     string
+	Counting string // e.g. "best 3 of 5 contests count"
+	Contests []string
+	Tabs     []SeriesTab
+}
+
+// RecordRow is a record on the records page.
+type RecordRow struct {
+	Discipline string
+	Label      string // e.g. "program record (D)"
+	Name       string
+	Time       string
+	Contest    string
+	Previous   string // the time it broke; empty for the first record
+}
+
+// BracketMatch is a match of a knockout bracket, its teams by name.
+type BracketMatch struct {
+	ID      string
+	A, B    string
+	TimeA   string
+	TimeB   string
+	WinnerA bool
+	WinnerB bool
+	Note    string // "judges' decision" or "bye"
+}
+
+// BracketRound is a column of a bracket view.
+type BracketRound struct {
+	Name    string // e.g. "Semi-finals"
+	Matches []BracketMatch
+}
+
+// Bracket is the knockout bracket of a team discipline.
+type Bracket struct {
+	Discipline string
+	Format     string // "single" or "double" elimination
+	Champion   string // empty until the final is decided
+	Rounds     []BracketRound
 }
 
 type DisciplineTab struct {
@@ -62,13 +136,25 @@ type DisciplineTab struct {
 	Results []RankedResult
 }
 
+// CategoryBoard holds the leaderboards of a participant category, one per
+// individual discipline it has results in.
+type CategoryBoard struct {
+	Name        string
+	Diplomas    bool
+	Disciplines []DisciplineTab
+}
+
 type Contest struct {
 	FolderName   string
 	DisplayName  string
 	Date         string
 	Official     bool
-	Participants []Participant
+	Participants []models.Participant
 	Disciplines  []DisciplineTab
+	FastestLegs  []RankedLeg    // relay legs (Bier Staphette), fastest first
+	Standings    []StandingsTab // championship points: overall, then factions
+	Brackets     []Bracket      // knockout brackets of the team disciplines
+	Categories   []CategoryBoard
 	// summary
 	TotalResults      int
 	TotalParticipants int
@@ -95,45 +181,6 @@ func parseFolder(name string) (displayName, date string, official bool, ok bool)
 
 // ─── time helpers ─────────────────────────────────────────────────────────────
 
-// timeRe matches HH:MM:SS.mmmm
-var timeRe = regexp.MustCompile(`^(\d{1,2}):(\d{2}):(\d{2})(?:\.(\d+))?$`)
-
-func parseTimeMs(s string) int64 {
-	s = strings.TrimSpace(s)
-	if s == "" || strings.EqualFold(s, "nan") {
-		return math.MaxInt64 // sort DQ to the bottom
-	}
-	m := timeRe.FindStringSubmatch(s)
-	if m == nil {
-		return math.MaxInt64
-	}
-	h := mustAtoi(m[1])
-	mi := mustAtoi(m[2])
-	sec := mustAtoi(m[3])
-	frac := 0
-	if m[4] != "" {
-		fs := m[4]
-		for len(fs) < 4 {
-			fs += "0"
-		}
-		if len(fs) > 4 {
-			fs = fs[:4]
-		}
-		frac = mustAtoi(fs)
-	}
-	return int64(h*36000000 + mi*600000 + sec*10000 + frac)
-}
-
-func mustAtoi(s string) int {
-	n := 0
-	for _, c := range s {
-		if c >= '0' && c <= '9' {
-			n = n*10 + int(c-'0')
-		}
-	}
-	return n
-}
-
 func formatTime(s string) string {
 	if strings.EqualFold(s, "nan") || s == "" {
 		return "—"
@@ -148,63 +195,69 @@ func formatAdditional(s string) string {
 	return s
 }
 
-// ─── JSON loaders ─────────────────────────────────────────────────────────────
+// ─── loaders ──────────────────────────────────────────────────────────────────
 
-func loadParticipants(path string) ([]Participant, error) {
-	data, err := os.ReadFile(path)
+// loadContest reads the participants, teams and results of a contest/ folder
+// through the same stores as the GUI, so older file versions are upgraded in
+// memory and files from a newer ChugWare are refused with a
+// data.UnsupportedVersionError. Voided attempts stay on disk for the audit
+// trail only and are dropped here.
+func loadContest(base string) ([]models.Participant, []models.Team, []models.Result, error) {
+	store, err := data.OpenContestStore(base)
 	if err != nil {
-		return nil, err
-	}
-	var list []map[string]string
-	if err := json.Unmarshal(data, &list); err != nil {
-		return nil, err
-	}
-	out := make([]Participant, 0, len(list))
-	for _, m := range list {
-		out = append(out, Participant{
-			Name:        m["name"],
-			Program:     m["program"],
-			Team:        m["team"],
-			Bottle:      m["bottle"],
-			HalfTankard: m["half_tankard"],
-			FullTankard: m["full_tankard"],
-		})
+		return nil, nil, nil, err
 	}
-	return out, nil
-}
+	defer store.Close()
 
-func loadResults(path string) ([]Result, error) {
-	data, err := os.ReadFile(path)
+	participants, err := store.LoadParticipants()
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, fmt.Errorf("participants: %w", err)
 	}
-	var list []map[string]string
-	if err := json.Unmarshal(data, &list); err != nil {
-		return nil, err
+	teams, err := store.LoadTeams()
+	if err != nil {
+		return participants, nil, nil, fmt.Errorf("teams: %w", err)
 	}
-	out := make([]Result, 0, len(list))
-	for _, m := range list {
-		out = append(out, Result{
-			Name:           m["name"],
-			Discipline:     m["discipline"],
-			Time:           m["time"],
-			BaseTime:       m["base_time"],
-			AdditionalTime: m["additional_time"],
-			Status:         m["status"],
-			Comment:        m["comment"],
-		})
+	all, err := store.LoadResults()
+	if err != nil {
+		return participants, teams, nil, fmt.Errorf("results: %w", err)
+	}
+	results := make([]models.Result, 0, len(all))
+	for _, r := range all {
+		if !r.Voided {
+			results = append(results, r)
+		}
 	}
-	return out, nil
+	return participants, teams, results, nil
 }
 
-// ─── scanner ──────────────────────────────────────────────────────────────────
+// pointsRows turns points standings into table rows, listing the points per
+// discipline in the given order.
+func pointsRows(standings []data.PointsStanding, order []string) []PointsRow {
+	rows := make([]PointsRow, len(standings))
+	for i, ps := range standings {
+		var parts []string
+		for _, d := range order {
+			if points, ok := ps.Disciplines[d]; ok {
+				parts = append(parts, fmt.Sprintf("%s %d", d, points))
+			}
+		}
+		rows[i] = PointsRow{Rank: ps.Rank, Place: ps.Place, Name: ps.Name, Program: ps.Program, Points: ps.Points, Breakdown: strings.Join(parts, ", ")}
+	}
+	return rows
+}
 
-var disciplineOrder = []string{
-	"Bottle", "Half Tankard", "Full Tankard",
-	"Bier Staphette", "Mega Medley", "Team Clash",
+// rankLegs numbers relay legs that are already sorted fastest first.
+func rankLegs(legs []data.Leg) []RankedLeg {
+	ranked := make([]RankedLeg, len(legs))
+	for i, l := range legs {
+		ranked[i] = RankedLeg{Rank: i + 1, Name: l.Name, Team: l.Team, Leg: l.Leg, Time: formatTime(l.Time)}
+	}
+	return ranked
 }
 
-func scanContests(root string) ([]Contest, error) {
+// ─── scanner ──────────────────────────────────────────────────────────────────
+
+func scanContests(root string, records *data.RecordBook) ([]Contest, error) {
 	entries, err := os.ReadDir(root)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read root folder %q: %w", root, err)
@@ -226,115 +279,138 @@ func scanContests(root string) ([]Contest, error) {
 		}
 
 		base := filepath.Join(root, e.Name(), "contest")
-		pFile := filepath.Join(base, "participants.json")
-		rFile := filepath.Join(base, "results.json")
-
-		participants, err := loadParticipants(pFile)
+		participants, teams, results, err := loadContest(base)
+		var unsupported *data.UnsupportedVersionError
+		if errors.As(err, &unsupported) {
+			fmt.Fprintf(os.Stderr, "  skip (%v)\n", err)
+			continue
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "  warning: cannot load participants for %s: %v\n", e.Name(), err)
+			fmt.Fprintf(os.Stderr, "  warning: cannot load %s: %v\n", e.Name(), err)
 		}
-
-		results, err := loadResults(rFile)
+		disciplines, err := data.LoadDisciplines(filepath.Join(base, config.DisciplineFileName))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "  warning: cannot load results for %s: %v\n", e.Name(), err)
+			fmt.Fprintf(os.Stderr, "  warning: %v; using the built-in disciplines\n", err)
+			disciplines = data.DefaultDisciplineRegistry()
 		}
 
-		// participant lookup by name → program/team
-		pLookup := make(map[string]Participant, len(participants))
+		// participant lookup by ID → program/team
+		pLookup := make(map[string]models.Participant, len(participants))
 		for _, p := range participants {
-			pLookup[p.Name] = p
+			pLookup[p.ID] = p
+		}
+		// team lookup by ID → program/roster for the team disciplines
+		tLookup := make(map[string]models.Team, len(teams))
+		for _, t := range teams {
+			tLookup[t.ID] = t
 		}
 
 		// group results by discipline
-		byDisc := make(map[string][]Result, 6)
+		byDisc := make(map[string][]models.Result, 6)
 		for _, r := range results {
 			byDisc[r.Discipline] = append(byDisc[r.Discipline], r)
 		}
 
 		var totalPass, totalDQ int
-		var tabs []DisciplineTab
+		for _, r := range results {
+			if r.Status == "Pass" {
+				totalPass++
+			} else {
+				totalDQ++
+			}
+		}
 
-		for _, disc := range disciplineOrder {
-			rs, ok := byDisc[disc]
-			if !ok {
-				continue
+		// the contest's disciplines in order, then any it does not define
+		order := disciplines.Names()
+		var other []string
+		for disc := range byDisc {
+			if _, found := disciplines.Get(disc); !found {
+				other = append(other, disc)
 			}
-			// sort: pass first by time asc, DQ at the bottom
-			sort.SliceStable(rs, func(i, j int) bool {
-				if rs[i].Status != rs[j].Status {
-					if rs[i].Status == "Disqualified" {
-						return false
-					}
-					if rs[j].Status == "Disqualified" {
-						return true
-					}
-				}
-				return parseTimeMs(rs[i].Time) < parseTimeMs(rs[j].Time)
-			})
-
-			ranked := make([]RankedResult, 0, len(rs))
-			rank := 0
-			for _, r := range rs {
-				p := pLookup[r.Name]
-				if r.Status == "Pass" {
-					rank++
-				}
-				displayRank := rank
-				if r.Status == "Disqualified" {
-					displayRank = 0
+		}
+		sort.Strings(other)
+		order = append(order, other...)
+
+		rankedResult := func(r models.Result) RankedResult {
+			p, t := pLookup[r.ParticipantID], tLookup[r.TeamID]
+			program := p.Program
+			if r.IsTeamResult() {
+				program = t.Program
+			}
+			return RankedResult{
+				Name:           r.Name,
+				Program:        program,
+				Team:           p.Team,
+				Roster:         strings.Join(data.RosterNames(t, participants), ", "),
+				Discipline:     r.Discipline,
+				Time:           formatTime(r.Time),
+				BaseTime:       formatTime(r.BaseTime),
+				AdditionalTime: formatAdditional(r.AdditionalTime),
+				Penalties:      data.PenaltySummary(r.Penalties),
+				Record:         data.RecordSummary(records.BrokenBy(e.Name(), r.ID)),
+				Status:         r.Status,
+				Comment:        r.Comment,
+			}
+		}
+
+		// one row per participant or team: the attempt that counts, with
+		// the others listed below it
+		leaderboard := func(def models.DisciplineDef, rs []models.Result) []RankedResult {
+			var ranked []RankedResult
+			for _, st := range data.Standings(def, rs) {
+				row := rankedResult(st.Result)
+				row.Rank, row.Place, row.Tied = st.Rank, st.Place(), st.Tied
+				if st.Note != "" {
+					row.Comment = strings.TrimSpace(row.Comment + " (" + st.Note + ")")
 				}
-				if r.Status == "Pass" {
-					totalPass++
-				} else {
-					totalDQ++
+				for _, o := range st.Others {
+					row.Attempts = append(row.Attempts, rankedResult(o))
 				}
-				ranked = append(ranked, RankedResult{
-					Rank:           displayRank,
-					Name:           r.Name,
-					Program:        p.Program,
-					Team:           p.Team,
-					Discipline:     r.Discipline,
-					Time:           formatTime(r.Time),
-					BaseTime:       formatTime(r.BaseTime),
-					AdditionalTime: formatAdditional(r.AdditionalTime),
-					Status:         r.Status,
-					Comment:        r.Comment,
-				})
+				ranked = append(ranked, row)
 			}
-			tabs = append(tabs, DisciplineTab{Name: disc, Results: ranked})
+			return ranked
 		}
-		// handle disciplines not in predefined order
-		for disc, rs := range byDisc {
-			found := false
-			for _, d := range disciplineOrder {
-				if d == disc {
-					found = true
-					break
-				}
+		definition := func(disc string) models.DisciplineDef {
+			if def, found := disciplines.Get(disc); found {
+				return def
+			}
+			return models.DisciplineDef{Name: disc}
+		}
+
+		var tabs []DisciplineTab
+		for _, disc := range order {
+			if rs, ok := byDisc[disc]; ok {
+				tabs = append(tabs, DisciplineTab{Name: disc, Results: leaderboard(definition(disc), rs)})
 			}
-			if !found {
-				sort.SliceStable(rs, func(i, j int) bool {
-					return parseTimeMs(rs[i].Time) < parseTimeMs(rs[j].Time)
-				})
-				ranked := make([]RankedResult, len(rs))
-				for i, r := range rs {
-					p := pLookup[r.Name]
-					ranked[i] = RankedResult{
-						Rank:           i + 1,
-						Name:           r.Name,
-						Program:        p.Program,
-						Team:           p.Team,
-						Discipline:     r.Discipline,
-						Time:           formatTime(r.Time),
-						BaseTime:       formatTime(r.BaseTime),
-						AdditionalTime: formatAdditional(r.AdditionalTime),
-						Status:         r.Status,
-						Comment:        r.Comment,
-					}
+		}
+
+		// a leaderboard per category in the individual disciplines
+		configured, err := data.LoadCategories(filepath.Join(base, config.CategoryFileName))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  warning: %v\n", err)
+		}
+		var boards []CategoryBoard
+		for _, c := range data.ContestCategories(configured, participants) {
+			board := CategoryBoard{Name: c.Name, Diplomas: c.Diplomas}
+			for _, disc := range order {
+				rs := data.CategoryResults(byDisc[disc], participants, c.Name)
+				if def := definition(disc); !def.Team && len(rs) > 0 {
+					board.Disciplines = append(board.Disciplines, DisciplineTab{Name: disc, Results: leaderboard(def, rs)})
 				}
-				tabs = append(tabs, DisciplineTab{Name: disc, Results: ranked})
+			}
+			if len(board.Disciplines) > 0 {
+				boards = append(boards, board)
 			}
 		}
+		brackets, err := data.LoadBrackets(filepath.Join(base, config.BracketFileName))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  warning: %v\n", err)
+		}
+		championship := data.NewChampionship(disciplines, participants, teams, results)
+		standings := []StandingsTab{
+			{Name: "Overall", Individual: true, Rows: pointsRows(championship.Individuals, disciplines.Names())},
+			{Name: "Factions", Rows: pointsRows(championship.Factions, disciplines.Names())},
+		}
 
 		contests = append(contests, Contest{
 			FolderName:        e.Name(),
@@ -343,6 +419,10 @@ func scanContests(root string) ([]Contest, error) {
 			Official:          official,
 			Participants:      participants,
 			Disciplines:       tabs,
+			FastestLegs:       rankLegs(data.FastestLegs(results, disciplines.Relays()...)),
+			Standings:         standings,
+			Brackets:          bracketViews(brackets, tLookup),
+			Categories:        boards,
 			TotalResults:      len(results),
 			TotalParticipants: len(participants),
 			TotalPass:         totalPass,
@@ -358,11 +438,128 @@ func scanContests(root string) ([]Contest, error) {
 	return contests, nil
 }
 
+// bracketViews turns the brackets of a contest into their views, naming the
+// teams.
+func bracketViews(brackets []models.Bracket, teams map[string]models.Team) []Bracket {
+	name := func(id string) string {
+		switch id {
+		case "":
+			return "TBD"
+		case models.BracketBye:
+			return "bye"
+		}
+		if t, ok := teams[id]; ok {
+			return t.Name
+		}
+		return id
+	}
+	var views []Bracket
+	for _, b := range brackets {
+		view := Bracket{Discipline: b.Discipline, Format: b.Format}
+		if champion := data.Champion(b); champion != "" {
+			view.Champion = name(champion)
+		}
+		for _, round := range data.BracketRounds(b) {
+			r := BracketRound{Name: round.Name}
+			for _, m := range round.Matches {
+				bm := BracketMatch{
+					ID: m.ID, A: name(m.A), B: name(m.B), TimeA: m.TimeA, TimeB: m.TimeB,
+					WinnerA: m.Winner != "" && m.Winner == m.A, WinnerB: m.Winner != "" && m.Winner == m.B,
+				}
+				switch m.Decision {
+				case models.DecisionJudge:
+					bm.Note = "judges' decision"
+				case models.DecisionBye:
+					bm.Note = "bye"
+				}
+				r.Matches = append(r.Matches, bm)
+			}
+			view.Rounds = append(view.Rounds, r)
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// ─── season series ────────────────────────────────────────────────────────────
+
+// loadSeries computes the standings of every series in the series file of
+// root. A series that cannot be loaded is skipped with a warning.
+func loadSeries(root string) ([]Series, error) {
+	defs, err := data.LoadSeries(data.SeriesPathFor(root))
+	if err != nil {
+		return nil, err
+	}
+	var series []Series
+	for _, def := range defs {
+		standings, err := data.LoadSeriesStandings(root, def)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  warning: cannot load series %q: %v\n", def.Name, err)
+			continue
+		}
+		labels := make([]string, len(standings.Contests))
+		for i, folder := range standings.Contests {
+			labels[i] = folder
+			if displayName, date, _, ok := parseFolder(folder); ok {
+				labels[i] = displayName + " " + date
+			}
+		}
+		counting := "all contests count"
+		if def.BestOf > 0 {
+			counting = fmt.Sprintf("best %d of %d contests count", def.BestOf, len(standings.Contests))
+		}
+		s := Series{Name: def.Name, Counting: counting, Contests: labels}
+		for _, table := range append([]data.SeriesTable{standings.Overall}, standings.Disciplines...) {
+			s.Tabs = append(s.Tabs, SeriesTab{Name: table.Name, Rows: seriesRows(table, labels)})
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// seriesRows turns a season table into rows, naming the contests by label.
+func seriesRows(table data.SeriesTable, labels []string) []SeriesRow {
+	rows := make([]SeriesRow, len(table.Entries))
+	for i, e := range table.Entries {
+		var parts []string
+		for c, points := range e.PerContest {
+			switch {
+			case points == 0:
+			case e.Counted[c]:
+				parts = append(parts, fmt.Sprintf("%s %d", labels[c], points))
+			default:
+				parts = append(parts, fmt.Sprintf("%s (%d)", labels[c], points))
+			}
+		}
+		rows[i] = SeriesRow{Rank: e.Rank, Place: e.Place, Name: e.Name, Points: e.Points, Breakdown: strings.Join(parts, ", ")}
+	}
+	return rows
+}
+
+// ─── records ──────────────────────────────────────────────────────────────────
+
+// recordRows turns records into rows, naming the contests as the sidebar
+// does.
+func recordRows(records []data.Record) []RecordRow {
+	rows := make([]RecordRow, len(records))
+	for i, r := range records {
+		contest := r.Contest
+		if displayName, date, _, ok := parseFolder(r.Contest); ok {
+			contest = displayName + " " + date
+		}
+		rows[i] = RecordRow{Discipline: r.Discipline, Label: r.Label(), Name: r.Name, Time: formatTime(r.Time), Contest: contest, Previous: r.Previous}
+	}
+	return rows
+}
+
 // ─── template data helpers ────────────────────────────────────────────────────
 
 type TemplateData struct {
 	GeneratedAt string
 	Contests    []Contest
+	Series      []Series
+	Records     []RecordRow // current all-time, program and team records
+	History     []RecordRow // every record set, newest first
 }
 
 // ─── HTML template ────────────────────────────────────────────────────────────
@@ -485,7 +682,21 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
 .rank-1{color:var(--gold);}
 .rank-2{color:var(--silver);}
 .rank-3{color:var(--bronze);}
+.tie{font-size:.7rem;color:var(--muted);font-weight:600;}
+.record{display:inline-block;margin-top:3px;padding:1px 7px;border-radius:4px;font-size:.68rem;font-weight:800;
+  letter-spacing:.5px;background:rgba(255,215,0,.15);color:var(--gold);border:1px solid rgba(255,215,0,.35);}
 .rank-dq{color:var(--dq);font-style:italic;font-size:.8rem;}
+tr.attempt td{color:var(--muted);font-size:.78rem;padding-top:2px;padding-bottom:2px;}
+
+/* knockout brackets */
+.bracket{display:flex;gap:18px;padding:16px;overflow-x:auto;}
+.bracket-round{display:flex;flex-direction:column;justify-content:space-around;gap:12px;min-width:190px;}
+.bracket-round h4{font-size:.75rem;text-transform:uppercase;letter-spacing:.8px;color:var(--muted);text-align:center;}
+.match{background:var(--surface);border:1px solid var(--border);border-radius:8px;overflow:hidden;}
+.match .slot{display:flex;justify-content:space-between;gap:8px;padding:6px 10px;font-size:.85rem;}
+.match .slot + .slot{border-top:1px solid var(--border);}
+.match .slot.won{font-weight:700;color:var(--pass);}
+.match .meta{padding:2px 10px;font-size:.68rem;color:var(--muted);background:rgba(255,255,255,.03);}
 
 /* status pills */
 .pill{padding:3px 10px;border-radius:99px;font-size:.75rem;font-weight:700;}
@@ -507,6 +718,8 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
 }
 .part-card .pname{font-weight:700;font-size:.95rem;margin-bottom:4px;}
 .part-card .pinfo{font-size:.78rem;color:var(--muted);margin-bottom:8px;}
+.roster{font-size:.78rem;font-weight:400;color:var(--muted);}
+.penalties{font-size:.72rem;color:var(--muted);font-family:inherit;}
 .try-dots{display:flex;gap:6px;flex-wrap:wrap;}
 .try-dot{
   font-size:.7rem;padding:2px 8px;border-radius:4px;font-weight:700;
@@ -562,6 +775,22 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
     <span class="badge">{{.Date}}</span>
   </a>
   {{end}}
+  {{if .History}}
+  <div class="nav-section">Records</div>
+  <a class="nav-item" onclick="showSection('records-section',this)">
+    🏅 Records
+    <span class="badge">{{len .Records}}</span>
+  </a>
+  {{end}}
+  {{if .Series}}
+  <div class="nav-section">Season Series</div>
+  {{range $si, $s := .Series}}
+  <a class="nav-item" onclick="showSection('series-{{$si}}',this)">
+    📈 {{$s.Name}}
+    <span class="badge">{{len $s.Contests}}</span>
+  </a>
+  {{end}}
+  {{end}}
 </nav>
 
 <main class="main">
@@ -651,14 +880,14 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
         {{range $disc.Results}}
           <tr>
             <td class="rank{{if eq .Rank 1}} rank-1{{else if eq .Rank 2}} rank-2{{else if eq .Rank 3}} rank-3{{else if eq .Rank 0}} rank-dq{{end}}">
-              {{if eq .Rank 0}}DQ{{else if eq .Rank 1}}🥇{{else if eq .Rank 2}}🥈{{else if eq .Rank 3}}🥉{{else}}{{.Rank}}{{end}}
+              {{if eq .Rank 0}}{{if eq .Status "Pass"}}–{{else}}DQ{{end}}{{else if eq .Rank 1}}🥇{{else if eq .Rank 2}}🥈{{else if eq .Rank 3}}🥉{{else}}{{.Place}}{{end}}{{if and .Tied (le .Rank 3)}}<div class="tie">{{.Place}}</div>{{end}}
             </td>
-            <td style="font-weight:600;">{{.Name}}</td>
+            <td style="font-weight:600;">{{.Name}}{{if .Roster}}<div class="roster">{{.Roster}}</div>{{end}}</td>
             <td style="color:var(--muted);">{{.Program}}</td>
             <td style="color:var(--muted);">{{.Team}}</td>
-            <td class="time">{{.Time}}</td>
+            <td class="time">{{.Time}}{{if .Record}}<div class="record">{{.Record}}</div>{{end}}</td>
             <td class="time" style="color:var(--muted);font-size:.82rem;">{{.BaseTime}}</td>
-            <td class="time-penalty">{{.AdditionalTime}}</td>
+            <td class="time-penalty">{{.AdditionalTime}}{{if .Penalties}}<div class="penalties">{{.Penalties}}</div>{{end}}</td>
             <td>
               {{if eq .Status "Pass"}}
                 <span class="pill pill-pass">Pass</span>
@@ -668,6 +897,19 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
             </td>
             <td style="color:var(--muted);font-size:.82rem;">{{.Comment}}</td>
           </tr>
+          {{range .Attempts}}
+          <tr class="attempt">
+            <td></td>
+            <td>↳ other attempt</td>
+            <td></td>
+            <td></td>
+            <td class="time">{{.Time}}{{if .Record}}<div class="record">{{.Record}}</div>{{end}}</td>
+            <td class="time">{{.BaseTime}}</td>
+            <td class="time">{{.AdditionalTime}}{{if .Penalties}}<div class="penalties">{{.Penalties}}</div>{{end}}</td>
+            <td>{{.Status}}</td>
+            <td>{{.Comment}}</td>
+          </tr>
+          {{end}}
         {{end}}
         </tbody>
       </table>
@@ -681,6 +923,161 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
     <div class="card"><div class="empty">No results recorded yet for this contest.</div></div>
   {{end}}
 
+  {{range $ki, $cat := $c.Categories}}
+  <!-- category leaderboards -->
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">
+      {{$cat.Name}} Leaderboards
+      {{if $cat.Diplomas}}<span class="count">diplomas awarded</span>{{end}}
+    </div>
+    <div style="padding:16px 16px 0;">
+      <div class="tab-bar" id="cat-{{$ci}}-{{$ki}}">
+        {{range $di, $disc := $cat.Disciplines}}
+        <button class="tab-btn{{if eq $di 0}} active{{end}}"
+          onclick="switchTab('cat-{{$ci}}-{{$ki}}','catcontent-{{$ci}}-{{$ki}}',{{$di}},this)">
+          {{$disc.Name}}
+          <span style="opacity:.6;font-weight:400;margin-left:4px;">({{len $disc.Results}})</span>
+        </button>
+        {{end}}
+      </div>
+    </div>
+    {{range $di, $disc := $cat.Disciplines}}
+    <div class="tab-content{{if eq $di 0}} active{{end}}" id="catcontent-{{$ci}}-{{$ki}}-{{$di}}">
+      <table>
+        <thead>
+          <tr>
+            <th style="width:50px;">Rank</th>
+            <th>Athlete</th>
+            <th>Program</th>
+            <th>Team</th>
+            <th>Time</th>
+            <th>Status</th>
+          </tr>
+        </thead>
+        <tbody>
+        {{range $disc.Results}}
+          <tr>
+            <td class="rank{{if eq .Rank 1}} rank-1{{else if eq .Rank 2}} rank-2{{else if eq .Rank 3}} rank-3{{else if eq .Rank 0}} rank-dq{{end}}">
+              {{if eq .Rank 0}}{{if eq .Status "Pass"}}–{{else}}DQ{{end}}{{else if eq .Rank 1}}🥇{{else if eq .Rank 2}}🥈{{else if eq .Rank 3}}🥉{{else}}{{.Place}}{{end}}{{if and .Tied (le .Rank 3)}}<div class="tie">{{.Place}}</div>{{end}}
+            </td>
+            <td style="font-weight:600;">{{.Name}}</td>
+            <td style="color:var(--muted);">{{.Program}}</td>
+            <td style="color:var(--muted);">{{.Team}}</td>
+            <td class="time">{{.Time}}{{if .Record}}<div class="record">{{.Record}}</div>{{end}}</td>
+            <td>
+              {{if eq .Status "Pass"}}
+                <span class="pill pill-pass">Pass</span>
+              {{else}}
+                <span class="pill pill-dq">DQ</span>
+              {{end}}
+            </td>
+          </tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>
+    {{end}}
+  </div>
+  {{end}}
+
+  {{if (index $c.Standings 0).Rows}}
+  <!-- championship standings -->
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">Championship Standings</div>
+    <div style="padding:16px 16px 0;">
+      <div class="tab-bar" id="standings-{{$ci}}">
+        {{range $si, $tab := $c.Standings}}
+        <button class="tab-btn{{if eq $si 0}} active{{end}}"
+          onclick="switchTab('standings-{{$ci}}','standingcontent-{{$ci}}',{{$si}},this)">{{$tab.Name}}</button>
+        {{end}}
+      </div>
+    </div>
+    {{range $si, $tab := $c.Standings}}
+    <div class="tab-content{{if eq $si 0}} active{{end}}" id="standingcontent-{{$ci}}-{{$si}}">
+      <table>
+        <thead>
+          <tr>
+            <th style="width:50px;">Rank</th>
+            <th>{{if $tab.Individual}}Athlete{{else}}Faction{{end}}</th>
+            {{if $tab.Individual}}<th>Program</th>{{end}}
+            <th>Points</th>
+            <th>By Discipline</th>
+          </tr>
+        </thead>
+        <tbody>
+        {{range $tab.Rows}}
+          <tr>
+            <td class="rank{{if eq .Rank 1}} rank-1{{else if eq .Rank 2}} rank-2{{else if eq .Rank 3}} rank-3{{end}}">{{.Place}}</td>
+            <td style="font-weight:600;">{{.Name}}</td>
+            {{if $tab.Individual}}<td style="color:var(--muted);">{{.Program}}</td>{{end}}
+            <td class="time">{{.Points}}</td>
+            <td style="color:var(--muted);font-size:.82rem;">{{.Breakdown}}</td>
+          </tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>
+    {{end}}
+  </div>
+  {{end}}
+
+  {{if $c.FastestLegs}}
+  <!-- fastest relay legs -->
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">
+      Fastest Relay Legs
+      <span class="count">{{len $c.FastestLegs}} legs</span>
+    </div>
+    <table>
+      <thead>
+        <tr>
+          <th style="width:50px;">Rank</th>
+          <th>Athlete</th>
+          <th>Team</th>
+          <th>Leg</th>
+          <th>Time</th>
+        </tr>
+      </thead>
+      <tbody>
+      {{range $c.FastestLegs}}
+        <tr>
+          <td class="rank">{{.Rank}}</td>
+          <td style="font-weight:600;">{{.Name}}</td>
+          <td style="color:var(--muted);">{{.Team}}</td>
+          <td style="color:var(--muted);">{{.Leg}}</td>
+          <td class="time">{{.Time}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+  </div>
+  {{end}}
+
+  {{range $c.Brackets}}
+  <!-- knockout bracket -->
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">
+      {{.Discipline}} Bracket
+      <span class="count">{{.Format}} elimination</span>
+      {{if .Champion}}<span class="record">CHAMPION: {{.Champion}}</span>{{end}}
+    </div>
+    <div class="bracket">
+      {{range .Rounds}}
+      <div class="bracket-round">
+        <h4>{{.Name}}</h4>
+        {{range .Matches}}
+        <div class="match">
+          <div class="meta">{{.ID}}{{if .Note}} · {{.Note}}{{end}}</div>
+          <div class="slot{{if .WinnerA}} won{{end}}"><span>{{.A}}</span><span class="time">{{.TimeA}}</span></div>
+          <div class="slot{{if .WinnerB}} won{{end}}"><span>{{.B}}</span><span class="time">{{.TimeB}}</span></div>
+        </div>
+        {{end}}
+      </div>
+      {{end}}
+    </div>
+  </div>
+  {{end}}
+
   <!-- participants panel -->
   <div class="card">
     <div class="card-title">
@@ -693,6 +1090,7 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
       <div class="part-card">
         <div class="pname">{{.Name}}</div>
         <div class="pinfo">{{.Program}}{{if and .Program .Team}} · {{end}}{{.Team}}</div>
+        {{if .Categories}}<div class="pinfo">{{range $i, $cat := .Categories}}{{if $i}}, {{end}}{{$cat}}{{end}}</div>{{end}}
         <div class="try-dots">
           {{if .Bottle}}<span class="try-dot">🍶 {{.Bottle}}</span>{{end}}
           {{if .HalfTankard}}<span class="try-dot">🍺 {{.HalfTankard}}</span>{{end}}
@@ -709,6 +1107,130 @@ tbody tr:hover{background:rgba(255,255,255,.03);}
 </section>
 {{end}}
 
+<!-- ░░ RECORDS ░░ -->
+{{if .History}}
+<section id="records-section" class="contest-section">
+
+  <div style="margin-bottom:24px;">
+    <h2 style="font-size:1.5rem;margin-bottom:8px;">Records</h2>
+    <p style="color:var(--muted);font-size:.9rem;">The fastest passes of every discipline across all contests, of each program and of each team.</p>
+  </div>
+
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">Current Records <span class="count">{{len .Records}}</span></div>
+    <table>
+      <thead>
+        <tr>
+          <th>Discipline</th>
+          <th>Record</th>
+          <th>Athlete</th>
+          <th>Time</th>
+          <th>Contest</th>
+        </tr>
+      </thead>
+      <tbody>
+      {{range .Records}}
+        <tr>
+          <td>{{.Discipline}}</td>
+          <td style="color:var(--muted);">{{.Label}}</td>
+          <td style="font-weight:600;">{{.Name}}</td>
+          <td class="time">{{.Time}}</td>
+          <td style="color:var(--muted);font-size:.82rem;">{{.Contest}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+  </div>
+
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">Records History <span class="count">newest first</span></div>
+    <table>
+      <thead>
+        <tr>
+          <th>Contest</th>
+          <th>Discipline</th>
+          <th>Record</th>
+          <th>Athlete</th>
+          <th>Time</th>
+          <th>Broke</th>
+        </tr>
+      </thead>
+      <tbody>
+      {{range .History}}
+        <tr>
+          <td style="color:var(--muted);font-size:.82rem;">{{.Contest}}</td>
+          <td>{{.Discipline}}</td>
+          <td style="color:var(--muted);">{{.Label}}</td>
+          <td style="font-weight:600;">{{.Name}}</td>
+          <td class="time">{{.Time}}</td>
+          <td class="time" style="color:var(--muted);">{{if .Previous}}{{.Previous}}{{else}}first record{{end}}</td>
+        </tr>
+      {{end}}
+      </tbody>
+    </table>
+  </div>
+
+</section>
+{{end}}
+
+<!-- ░░ SEASON SERIES ░░ -->
+{{range $si, $s := .Series}}
+<section id="series-{{$si}}" class="contest-section">
+
+  <div class="contest-hero">
+    <div class="contest-hero-info">
+      <h2>{{$s.Name}}</h2>
+      <div class="date">📈 Season series · {{$s.Counting}}</div>
+      <div class="roster">{{range $i, $c := $s.Contests}}{{if $i}} · {{end}}{{$c}}{{end}}</div>
+    </div>
+    <div class="stat-grid">
+      <div class="stat-card"><div class="val">{{len $s.Contests}}</div><div class="lbl">Contests</div></div>
+    </div>
+  </div>
+
+  {{if (index $s.Tabs 0).Rows}}
+  <div class="card" style="margin-bottom:24px;">
+    <div class="card-title">Season Standings <span class="count">points in brackets do not count</span></div>
+    <div style="padding:16px 16px 0;">
+      <div class="tab-bar" id="seriestabs-{{$si}}">
+        {{range $ti, $tab := $s.Tabs}}
+        <button class="tab-btn{{if eq $ti 0}} active{{end}}"
+          onclick="switchTab('seriestabs-{{$si}}','seriescontent-{{$si}}',{{$ti}},this)">{{$tab.Name}}</button>
+        {{end}}
+      </div>
+    </div>
+    {{range $ti, $tab := $s.Tabs}}
+    <div class="tab-content{{if eq $ti 0}} active{{end}}" id="seriescontent-{{$si}}-{{$ti}}">
+      <table>
+        <thead>
+          <tr>
+            <th style="width:50px;">Rank</th>
+            <th>Athlete</th>
+            <th>Points</th>
+            <th>By Contest</th>
+          </tr>
+        </thead>
+        <tbody>
+        {{range $tab.Rows}}
+          <tr>
+            <td class="rank{{if eq .Rank 1}} rank-1{{else if eq .Rank 2}} rank-2{{else if eq .Rank 3}} rank-3{{end}}">{{.Place}}</td>
+            <td style="font-weight:600;">{{.Name}}</td>
+            <td class="time">{{.Points}}</td>
+            <td style="color:var(--muted);font-size:.82rem;">{{.Breakdown}}</td>
+          </tr>
+        {{end}}
+        </tbody>
+      </table>
+    </div>
+    {{end}}
+  </div>
+  {{else}}
+    <div class="card"><div class="empty">No points scored yet in this series.</div></div>
+  {{end}}
+
+</section>
+{{end}}
+
 </main>
 
 <script>
@@ -768,13 +1290,31 @@ func main() {
 	}
 
 	fmt.Printf("Scanning contests in: %s\n", absRoot)
-	contests, err := scanContests(absRoot)
+	records, err := data.LoadRecordBook(absRoot, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  warning: some contests are left out of the records: %v\n", err)
+	}
+	contests, err := scanContests(absRoot, records)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error scanning contests: %v\n", err)
 		os.Exit(1)
 	}
 	fmt.Printf("Found %d contest(s)\n", len(contests))
 
+	series, err := loadSeries(absRoot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  warning: %v\n", err)
+	}
+
+	var current []data.Record
+	for _, scope := range []string{data.RecordAllTime, data.RecordProgram, data.RecordTeam} {
+		current = append(current, records.Records(scope)...)
+	}
+	history := make([]data.Record, len(records.History))
+	for i, r := range records.History {
+		history[len(records.History)-1-i] = r
+	}
+
 	absOut, err := filepath.Abs(*out)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error resolving output path: %v\n", err)
@@ -797,6 +1337,9 @@ func main() {
 	data := TemplateData{
 		GeneratedAt: time.Now().Format("2006-01-02 15:04:05"),
 		Contests:    contests,
+		Series:      series,
+		Records:     recordRows(current),
+		History:     recordRows(history),
 	}
 
 	if err := tmpl.Execute(f, data); err != nil {
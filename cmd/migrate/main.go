@@ -0,0 +1,149 @@
+// migrate – converts ChugWare contest folders between storage backends and
+// upgrades old data files to the current file version.
+//
+// Usage:
+//
+//	migrate [--root <ChugWare folder>] [--contest <folder>] [--to db|json] [--force]
+//	migrate --upgrade [--dry-run] [--root <ChugWare folder>] [--contest <folder>]
+//
+// Every contest folder inside the root (or just the one given by --contest)
+// has its contest/participants.json and contest/results.json copied into a
+// single contest/contest.db database, or back again with --to json. The
+// source files are never modified.
+//
+// With --upgrade the JSON files are instead rewritten in place at the current
+// file version; --dry-run only prints which files would change and how.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+)
+
+// contestDirs returns the contest/ folders to migrate.
+func contestDirs(root, contest string) ([]string, error) {
+	if contest != "" {
+		dir := contest
+		if !filepath.IsAbs(dir) && !exists(dir) {
+			dir = filepath.Join(root, contest)
+		}
+		return []string{filepath.Join(dir, config.ContestDirectory)}, nil
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read root folder %q: %w", root, err)
+	}
+	var dirs []string
+	for _, e := range entries {
+		dir := filepath.Join(root, e.Name(), config.ContestDirectory)
+		if e.IsDir() && exists(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// upgrade runs the schema upgrade over dirs and returns the number of failures.
+func upgrade(dirs []string, dryRun bool) int {
+	failed, pending := 0, 0
+	for _, dir := range dirs {
+		name := filepath.Base(filepath.Dir(dir))
+		reports, err := data.UpgradeContest(dir, dryRun)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", name, err)
+			failed++
+			continue
+		}
+		for _, r := range reports {
+			if !r.NeedsUpgrade() {
+				fmt.Printf("  %s/%s: up to date (version %d)\n", name, filepath.Base(r.Path), r.FromVersion)
+				continue
+			}
+			pending++
+			verb := "upgraded"
+			if dryRun {
+				verb = "would upgrade"
+			}
+			fmt.Printf("  %s/%s: %s version %d -> %d (%d record(s))\n",
+				name, filepath.Base(r.Path), verb, r.FromVersion, r.ToVersion, r.Records)
+			for _, step := range r.Steps {
+				fmt.Printf("      %s\n", step)
+			}
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("\nDry run: %d file(s) would be upgraded. Nothing was written.\n", pending)
+	} else {
+		fmt.Printf("\nUpgraded %d file(s).\n", pending)
+	}
+	return failed
+}
+
+// ─── entry point ──────────────────────────────────────────────────────────────
+
+func main() {
+	root := flag.String("root", "ChugWare", "Path to ChugWare contests folder")
+	contest := flag.String("contest", "", "Migrate only this contest folder (name inside --root, or a path)")
+	to := flag.String("to", data.BackendDatabase, "Target storage: db (single database file) or json")
+	force := flag.Bool("force", false, "Overwrite data already present in the target")
+	upgradeFiles := flag.Bool("upgrade", false, "Upgrade JSON data files to the current file version instead of migrating")
+	dryRun := flag.Bool("dry-run", false, "With --upgrade, only report what would change")
+	flag.Parse()
+
+	absRoot, err := filepath.Abs(*root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error resolving root path: %v\n", err)
+		os.Exit(1)
+	}
+
+	dirs, err := contestDirs(absRoot, *contest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error finding contests: %v\n", err)
+		os.Exit(1)
+	}
+	if len(dirs) == 0 {
+		fmt.Printf("No contest folders found in: %s\n", absRoot)
+		return
+	}
+
+	if *upgradeFiles {
+		if upgrade(dirs, *dryRun) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	failed := 0
+	for _, dir := range dirs {
+		report, err := data.MigrateContest(dir, *to, *force)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", filepath.Base(filepath.Dir(dir)), err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s: %d participant(s), %d team(s), %d result(s) %s -> %s\n",
+			filepath.Base(filepath.Dir(dir)), report.Participants, report.Teams, report.Results, report.From, report.To)
+	}
+
+	participantFile, resultFile := data.ContestStorePaths(config.ContestDirectory, *to)
+	fmt.Printf("\nMigrated %d of %d contest(s).\n", len(dirs)-failed, len(dirs))
+	fmt.Println("To use the migrated data, set in Configuration (inside the contest folder):")
+	fmt.Printf("  Participant File: %s\n", participantFile)
+	fmt.Printf("  Result File:      %s\n", resultFile)
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
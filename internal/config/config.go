@@ -18,12 +18,14 @@ const (
 	TemplateDirectory = "template"
 	ContestDirectory  = "contest"
 	ResultsDirectory  = "results"
+	BackupsDirectory  = "backups"
 
 	// Constraints
 	MaxStringLength = 255
 	MaxParticipants = 200
 	MaxEntries      = 999
 	MaxValue        = 9999
+	MaxBackups      = 10 // rolling backups kept per data file
 
 	// Chugging Attempts
 	BottleTries      = 3
@@ -44,6 +46,49 @@ const (
 
 	// Config file
 	ConfigFileName = "chugware_config.json"
+
+	// Append-only result journal, stored next to the result file
+	JournalFileName = "results_journal.jsonl"
+
+	// Embedded database holding a whole contest; used instead of
+	// participants.json/results.json when a file setting points at it
+	DatabaseFileName = "contest.db"
+
+	// Team rosters, stored next to the participant file
+	TeamFileName = "teams.json"
+
+	// Overall standings written by Finish Contest to the results directory
+	// when the Leaderboard File and Faction File settings are left empty
+	LeaderBoardFileName = "leaderboard.json"
+	FactionFileName     = "factions.json"
+
+	// Season series definitions, stored in the ChugWare root folder
+	SeriesFileName = "series.json"
+
+	// Discipline definitions of a contest, stored next to the participant
+	// file; without it the built-in disciplines are used
+	DisciplineFileName = "disciplines.json"
+
+	// Knockout brackets of the head-to-head disciplines, stored next to the
+	// participant file
+	BracketFileName = "brackets.json"
+
+	// Participant categories of a contest with their diploma eligibility,
+	// stored next to the participant file
+	CategoryFileName = "categories.json"
+
+	// Drawn running orders and skipped lists of the disciplines, stored next
+	// to the participant file
+	RunningOrderFileName = "running_order.json"
+
+	// Copies of the discipline and category files as they were when an
+	// official contest recorded its first result, stored in this folder next
+	// to them
+	SealedDirectory = "sealed"
+
+	// Advisory lock telling other ChugWare instances that a contest is open,
+	// stored next to the result file
+	LockFileName = "chugware.lock"
 )
 
 var (
@@ -157,3 +202,30 @@ func GetResultsPath() string {
 	}
 	return filepath.Join(Settings.FolderPathContestNameAndDate, ResultsDirectory)
 }
+
+// GetLeaderBoardPath returns the file the overall individual standings are
+// written to: the Leaderboard File setting, relative to the results directory
+// unless absolute, or LeaderBoardFileName there.
+func GetLeaderBoardPath() string {
+	return resultsFile(Settings.LeaderBoardFile, LeaderBoardFileName)
+}
+
+// GetFactionPath returns the file the faction standings are written to, as
+// GetLeaderBoardPath does for the Faction File setting.
+func GetFactionPath() string {
+	return resultsFile(Settings.FactionFile, FactionFileName)
+}
+
+func resultsFile(setting, defaultName string) string {
+	if setting == "" {
+		setting = defaultName
+	}
+	if filepath.IsAbs(setting) {
+		return setting
+	}
+	results := GetResultsPath()
+	if results == "" {
+		return ""
+	}
+	return filepath.Join(results, setting)
+}
@@ -178,3 +178,28 @@ func TestGetResultsPath_NoBase(t *testing.T) {
 	Settings.FolderPathContestNameAndDate = ""
 	assert.Equal(t, "", GetResultsPath())
 }
+
+func TestGetLeaderBoardPath(t *testing.T) {
+	origSettings, origFile := saveGlobals()
+	defer restoreGlobals(origSettings, origFile)
+
+	Settings.FolderPathContestNameAndDate = "/base/contest"
+	Settings.LeaderBoardFile = ""
+	assert.Equal(t, filepath.Join("/base/contest", ResultsDirectory, LeaderBoardFileName), GetLeaderBoardPath())
+
+	Settings.LeaderBoardFile = "overall.json"
+	assert.Equal(t, filepath.Join("/base/contest", ResultsDirectory, "overall.json"), GetLeaderBoardPath())
+
+	abs := filepath.Join(t.TempDir(), "overall.json")
+	Settings.LeaderBoardFile = abs
+	assert.Equal(t, abs, GetLeaderBoardPath())
+}
+
+func TestGetFactionPath_NoBase(t *testing.T) {
+	origSettings, origFile := saveGlobals()
+	defer restoreGlobals(origSettings, origFile)
+
+	Settings.FolderPathContestNameAndDate = ""
+	Settings.FactionFile = ""
+	assert.Equal(t, "", GetFactionPath())
+}
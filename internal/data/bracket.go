@@ -0,0 +1,407 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// BracketPathFor returns the bracket file of a contest, stored next to its
+// participant file.
+func BracketPathFor(participantFile string) string {
+	return filepath.Join(filepath.Dir(participantFile), config.BracketFileName)
+}
+
+// LoadBrackets reads a bracket file: a JSON array with a bracket per
+// discipline. A missing file means no brackets.
+func LoadBrackets(path string) ([]models.Bracket, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading brackets %s: %w", path, err)
+	}
+	var brackets []models.Bracket
+	if err := json.Unmarshal(content, &brackets); err != nil {
+		return nil, fmt.Errorf("error parsing brackets %s: %w", path, err)
+	}
+	return brackets, nil
+}
+
+// SaveBrackets writes the brackets of a contest to path.
+func SaveBrackets(path string, brackets []models.Bracket) error {
+	if err := utils.SaveJSONFile(path, brackets); err != nil {
+		return fmt.Errorf("error saving brackets %s: %w", path, err)
+	}
+	return nil
+}
+
+// FindBracket returns the position of the bracket of discipline, or -1.
+func FindBracket(brackets []models.Bracket, discipline string) int {
+	for i, b := range brackets {
+		if b.Discipline == discipline {
+			return i
+		}
+	}
+	return -1
+}
+
+// SeedTeams returns the team IDs in seed order: the teams ranked in def by
+// results first, fastest first, then the rest in the order given. Without
+// results the given order is kept.
+func SeedTeams(teams []models.Team, def models.DisciplineDef, results []models.Result) []string {
+	var seeds []string
+	seeded := make(map[string]bool)
+	for _, st := range Standings(def, results) {
+		id := st.Result.TeamID
+		if st.Rank == 0 || seeded[id] {
+			continue
+		}
+		for _, t := range teams {
+			if t.ID == id {
+				seeds = append(seeds, id)
+				seeded[id] = true
+			}
+		}
+	}
+	for _, t := range teams {
+		if !seeded[t.ID] {
+			seeds = append(seeds, t.ID)
+		}
+	}
+	return seeds
+}
+
+// NewBracket draws a single- or double-elimination bracket for discipline
+// from the team IDs in seed order. The bracket is filled up with byes to a
+// power of two, placed so that the best seeds meet the latest, and the teams
+// drawn against a bye go through at once.
+//
+// In double elimination the loser of each winners-bracket match drops into
+// the losers bracket; the winners of both brackets meet in a single grand
+// final.
+func NewBracket(discipline, format string, seeds []string) (models.Bracket, error) {
+	if format == "" {
+		format = models.BracketSingle
+	}
+	if format != models.BracketSingle && format != models.BracketDouble {
+		return models.Bracket{}, fmt.Errorf("unknown bracket format %q", format)
+	}
+	if len(seeds) < 2 {
+		return models.Bracket{}, fmt.Errorf("a bracket needs at least two teams")
+	}
+
+	size, rounds := 1, 0
+	for size < len(seeds) {
+		size *= 2
+		rounds++
+	}
+	seedAt := func(seed int) string {
+		if seed > len(seeds) {
+			return models.BracketBye
+		}
+		return seeds[seed-1]
+	}
+
+	b := models.Bracket{Discipline: discipline, Format: format, Seeds: seeds}
+	order := seedOrder(size)
+	for r := 1; r <= rounds; r++ {
+		for m := 1; m <= size>>r; m++ {
+			match := models.Match{ID: matchID("W", r, m), Section: models.BracketWinners, Round: r}
+			if r == 1 {
+				match.A, match.B = seedAt(order[2*m-2]), seedAt(order[2*m-1])
+			}
+			if r < rounds {
+				match.WinnerTo, match.WinnerSlot = matchID("W", r+1, (m+1)/2), (m-1)%2
+			}
+			b.Matches = append(b.Matches, match)
+		}
+	}
+	if format == models.BracketDouble {
+		addLosersBracket(&b, size, rounds)
+	}
+	resolveByes(&b)
+	return b, nil
+}
+
+// addLosersBracket adds the losers bracket and the grand final to the
+// winners bracket of b. The losers of the first round meet each other; in
+// every later round of the winners bracket the losers drop in against the
+// survivors of the losers bracket, in reverse order to put off rematches.
+func addLosersBracket(b *models.Bracket, size, rounds int) {
+	winnersFinal := matchIndex(b, matchID("W", rounds, 1))
+	b.Matches[winnersFinal].WinnerTo, b.Matches[winnersFinal].WinnerSlot = "F", 0
+
+	lRounds := 2*rounds - 2
+	for lr := 1; lr <= lRounds; lr++ {
+		n := size >> ((lr+1)/2 + 1)
+		for m := 1; m <= n; m++ {
+			match := models.Match{ID: matchID("L", lr, m), Section: models.BracketLosers, Round: lr}
+			switch {
+			case lr == lRounds:
+				match.WinnerTo, match.WinnerSlot = "F", 1
+			case lr%2 == 1:
+				match.WinnerTo, match.WinnerSlot = matchID("L", lr+1, m), 0
+			default:
+				match.WinnerTo, match.WinnerSlot = matchID("L", lr+1, (m+1)/2), (m-1)%2
+			}
+			b.Matches = append(b.Matches, match)
+		}
+	}
+
+	for r := 1; r <= rounds; r++ {
+		n := size >> r
+		for m := 1; m <= n; m++ {
+			match := &b.Matches[matchIndex(b, matchID("W", r, m))]
+			switch {
+			case rounds == 1:
+				match.LoserTo, match.LoserSlot = "F", 1
+			case r == 1:
+				match.LoserTo, match.LoserSlot = matchID("L", 1, (m+1)/2), (m-1)%2
+			default:
+				match.LoserTo, match.LoserSlot = matchID("L", 2*r-2, n+1-m), 1
+			}
+		}
+	}
+
+	b.Matches = append(b.Matches, models.Match{ID: "F", Section: models.BracketFinal, Round: 1})
+}
+
+// seedOrder returns the seeds of a bracket of size teams in the order they
+// are drawn, pairwise: 1 meets size, 2 meets size-1 and so on, with the top
+// two seeds in different halves.
+func seedOrder(size int) []int {
+	order := []int{1}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, s := range order {
+			next = append(next, s, n+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+func matchID(section string, round, match int) string {
+	return fmt.Sprintf("%s%d.%d", section, round, match)
+}
+
+// matchIndex returns the position of the match with the given ID, or -1.
+func matchIndex(b *models.Bracket, id string) int {
+	for i := range b.Matches {
+		if b.Matches[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// place puts team into a slot of the match id; an empty id is ignored.
+func place(b *models.Bracket, id string, slot int, team string) {
+	i := matchIndex(b, id)
+	if i < 0 {
+		return
+	}
+	if slot == 0 {
+		b.Matches[i].A = team
+	} else {
+		b.Matches[i].B = team
+	}
+}
+
+// resolveByes decides every match that has a bye in it: the other team goes
+// through, and two byes send a bye on.
+func resolveByes(b *models.Bracket) {
+	for changed := true; changed; {
+		changed = false
+		for i := range b.Matches {
+			m := b.Matches[i]
+			if m.Winner != "" || m.A == "" || m.B == "" || (m.A != models.BracketBye && m.B != models.BracketBye) {
+				continue
+			}
+			winner, loser := m.A, m.B
+			if m.A == models.BracketBye {
+				winner, loser = m.B, m.A
+			}
+			b.Matches[i].Winner, b.Matches[i].Decision = winner, models.DecisionBye
+			place(b, m.WinnerTo, m.WinnerSlot, winner)
+			place(b, m.LoserTo, m.LoserSlot, loser)
+			changed = true
+		}
+	}
+}
+
+// played reports whether the match id, or a match its teams went on to
+// through byes, has been decided by time or the judges.
+func played(b *models.Bracket, id string) bool {
+	i := matchIndex(b, id)
+	if i < 0 || b.Matches[i].Winner == "" {
+		return false
+	}
+	m := b.Matches[i]
+	if m.Decision != models.DecisionBye {
+		return true
+	}
+	return played(b, m.WinnerTo) || played(b, m.LoserTo)
+}
+
+// retract empties a slot of the match id. A match it decided through a bye
+// is taken back as well.
+func retract(b *models.Bracket, id string, slot int) {
+	i := matchIndex(b, id)
+	if i < 0 {
+		return
+	}
+	place(b, id, slot, "")
+	m := b.Matches[i]
+	if m.Winner != "" && m.Decision == models.DecisionBye {
+		b.Matches[i].Winner, b.Matches[i].Decision = "", ""
+		retract(b, m.WinnerTo, m.WinnerSlot)
+		retract(b, m.LoserTo, m.LoserSlot)
+	}
+}
+
+// RecordMatch decides the match id: winner is the team in slot A or B. The
+// winner and, in double elimination, the loser go on to their next matches.
+// A decided match can be recorded again until a match it feeds is played.
+func RecordMatch(b *models.Bracket, id, winner, decision, timeA, timeB string) error {
+	i := matchIndex(b, id)
+	if i < 0 {
+		return fmt.Errorf("no match %s in the %s bracket", id, b.Discipline)
+	}
+	m := b.Matches[i]
+	decided := m.Winner != "" && m.Decision != models.DecisionBye
+	if !MatchReady(m) && !decided {
+		return fmt.Errorf("match %s is not ready: its teams are not decided yet", id)
+	}
+	if winner == "" || (winner != m.A && winner != m.B) {
+		return fmt.Errorf("the winner of match %s must be one of its teams", id)
+	}
+	if m.Winner != "" {
+		if played(b, m.WinnerTo) || played(b, m.LoserTo) {
+			return fmt.Errorf("match %s cannot be changed: the next match has been played", id)
+		}
+		retract(b, m.WinnerTo, m.WinnerSlot)
+		retract(b, m.LoserTo, m.LoserSlot)
+	}
+
+	loser := m.A
+	if winner == m.A {
+		loser = m.B
+	}
+	b.Matches[i].Winner, b.Matches[i].Decision = winner, decision
+	b.Matches[i].TimeA, b.Matches[i].TimeB = timeA, timeB
+	place(b, m.WinnerTo, m.WinnerSlot, winner)
+	place(b, m.LoserTo, m.LoserSlot, loser)
+	resolveByes(b)
+	return nil
+}
+
+// RecordMatchByTime decides the match id by the times of its teams: the
+// faster team wins. Equal times are left to the judges.
+func RecordMatchByTime(b *models.Bracket, id, timeA, timeB string) error {
+	i := matchIndex(b, id)
+	if i < 0 {
+		return fmt.Errorf("no match %s in the %s bracket", id, b.Discipline)
+	}
+	a, bt := utils.ParseAndPadTimeString(timeA), utils.ParseAndPadTimeString(timeB)
+	if a == config.NoKey || bt == config.NoKey {
+		return fmt.Errorf("invalid time for match %s", id)
+	}
+	ta, tb := utils.ParseTimeForComparison(a), utils.ParseTimeForComparison(bt)
+	if ta < 0 || tb < 0 {
+		return fmt.Errorf("invalid time for match %s", id)
+	}
+	if ta == tb {
+		return fmt.Errorf("the times of match %s are equal: let the judges decide", id)
+	}
+	winner := b.Matches[i].A
+	if tb < ta {
+		winner = b.Matches[i].B
+	}
+	return RecordMatch(b, id, winner, models.DecisionTime, a, bt)
+}
+
+// MatchReady reports whether a match can be run: both teams are known and
+// it has not been decided.
+func MatchReady(m models.Match) bool {
+	return m.Winner == "" && m.A != "" && m.B != "" && m.A != models.BracketBye && m.B != models.BracketBye
+}
+
+// Champion returns the winner of the bracket's last match, or "" while it
+// is undecided.
+func Champion(b models.Bracket) string {
+	for _, m := range b.Matches {
+		if m.WinnerTo == "" && m.Section != models.BracketLosers && m.Winner != models.BracketBye {
+			return m.Winner
+		}
+	}
+	return ""
+}
+
+// BracketRound is one round of a bracket for display.
+type BracketRound struct {
+	Section string
+	Name    string // e.g. "Semi-finals" or "Losers round 2"
+	Matches []models.Match
+}
+
+// BracketRounds groups the matches of b by section and round, winners
+// bracket first.
+func BracketRounds(b models.Bracket) []BracketRound {
+	var rounds []BracketRound
+	for _, m := range b.Matches {
+		n := len(rounds)
+		if n == 0 || rounds[n-1].Section != m.Section || rounds[n-1].Matches[0].Round != m.Round {
+			rounds = append(rounds, BracketRound{Section: m.Section})
+			n++
+		}
+		rounds[n-1].Matches = append(rounds[n-1].Matches, m)
+	}
+
+	last := map[string]int{}
+	for _, r := range rounds {
+		last[r.Section] = r.Matches[0].Round
+	}
+	for i, r := range rounds {
+		round := r.Matches[0].Round
+		switch r.Section {
+		case models.BracketFinal:
+			rounds[i].Name = "Grand final"
+		case models.BracketLosers:
+			rounds[i].Name = fmt.Sprintf("Losers round %d", round)
+			if round == last[r.Section] {
+				rounds[i].Name = "Losers final"
+			}
+		default:
+			rounds[i].Name = winnersRoundName(last[r.Section]-round, b.Format)
+			if rounds[i].Name == "" {
+				rounds[i].Name = fmt.Sprintf("Round %d", round)
+			}
+		}
+	}
+	return rounds
+}
+
+// winnersRoundName names a winners-bracket round by the rounds left after
+// it; "" for the early rounds.
+func winnersRoundName(left int, format string) string {
+	switch left {
+	case 0:
+		if format == models.BracketDouble {
+			return "Winners final"
+		}
+		return "Final"
+	case 1:
+		return "Semi-finals"
+	case 2:
+		return "Quarter-finals"
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func match(t *testing.T, b models.Bracket, id string) models.Match {
+	t.Helper()
+	i := matchIndex(&b, id)
+	require.GreaterOrEqual(t, i, 0, "match %s", id)
+	return b.Matches[i]
+}
+
+// playAll decides every ready match by letting the team in slot A win until
+// the bracket is done.
+func playAll(t *testing.T, b *models.Bracket) {
+	t.Helper()
+	for progress := true; progress; {
+		progress = false
+		for _, m := range b.Matches {
+			if MatchReady(m) {
+				require.NoError(t, RecordMatch(b, m.ID, m.A, models.DecisionJudge, "", ""))
+				progress = true
+			}
+		}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Drawing brackets
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestNewBracket_SingleWithByes(t *testing.T) {
+	b, err := NewBracket(models.DisciplineTeamClash, "", []string{"t1", "t2", "t3", "t4", "t5"})
+	require.NoError(t, err)
+	assert.Equal(t, models.BracketSingle, b.Format)
+	assert.Len(t, b.Matches, 7, "five teams fill a bracket of eight")
+
+	assert.Equal(t, []string{"t1", models.BracketBye}, []string{match(t, b, "W1.1").A, match(t, b, "W1.1").B})
+	assert.Equal(t, []string{"t4", "t5"}, []string{match(t, b, "W1.2").A, match(t, b, "W1.2").B})
+	assert.Equal(t, models.DecisionBye, match(t, b, "W1.1").Decision)
+	assert.Equal(t, "t1", match(t, b, "W2.1").A, "the top seed goes through its bye")
+	assert.Equal(t, []string{"t2", "t3"}, []string{match(t, b, "W2.2").A, match(t, b, "W2.2").B})
+
+	var ready []string
+	for _, m := range b.Matches {
+		if MatchReady(m) {
+			ready = append(ready, m.ID)
+		}
+	}
+	assert.Equal(t, []string{"W1.2", "W2.2"}, ready)
+}
+
+func TestNewBracket_Errors(t *testing.T) {
+	_, err := NewBracket(models.DisciplineTeamClash, models.BracketSingle, []string{"t1"})
+	assert.Error(t, err)
+	_, err = NewBracket(models.DisciplineTeamClash, "triple", []string{"t1", "t2"})
+	assert.Error(t, err)
+}
+
+func TestNewBracket_Double(t *testing.T) {
+	b, err := NewBracket(models.DisciplineTeamClash, models.BracketDouble, []string{"t1", "t2", "t3", "t4"})
+	require.NoError(t, err)
+	assert.Len(t, b.Matches, 6, "three winners, two losers and the grand final")
+
+	require.NoError(t, RecordMatch(&b, "W1.1", "t4", models.DecisionJudge, "", ""))
+	require.NoError(t, RecordMatch(&b, "W1.2", "t2", models.DecisionJudge, "", ""))
+	assert.Equal(t, []string{"t1", "t3"}, []string{match(t, b, "L1.1").A, match(t, b, "L1.1").B})
+
+	require.NoError(t, RecordMatch(&b, "W2.1", "t4", models.DecisionJudge, "", ""))
+	assert.Equal(t, "t2", match(t, b, "L2.1").B, "the loser of the winners final drops into the losers final")
+	require.NoError(t, RecordMatch(&b, "L1.1", "t1", models.DecisionJudge, "", ""))
+	require.NoError(t, RecordMatch(&b, "L2.1", "t1", models.DecisionJudge, "", ""))
+	assert.Equal(t, []string{"t4", "t1"}, []string{match(t, b, "F").A, match(t, b, "F").B})
+	assert.Empty(t, Champion(b))
+
+	require.NoError(t, RecordMatch(&b, "F", "t1", models.DecisionJudge, "", ""))
+	assert.Equal(t, "t1", Champion(b))
+}
+
+func TestNewBracket_DoubleCompletes(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		var seeds []string
+		for i := 1; i <= n; i++ {
+			seeds = append(seeds, string(rune('a'+i-1)))
+		}
+		b, err := NewBracket(models.DisciplineTeamClash, models.BracketDouble, seeds)
+		require.NoError(t, err)
+		playAll(t, &b)
+		assert.Equal(t, "a", Champion(b), "%d teams", n)
+		for _, m := range b.Matches {
+			assert.NotEmpty(t, m.Winner, "%d teams: match %s", n, m.ID)
+		}
+	}
+}
+
+func TestSeedOrder(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, seedOrder(2))
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, seedOrder(8))
+}
+
+func TestSeedTeams(t *testing.T) {
+	teams := []models.Team{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	def := builtin(models.DisciplineTeamClash)
+	assert.Equal(t, []string{"a", "b", "c"}, SeedTeams(teams, def, nil))
+
+	results := []models.Result{
+		{TeamID: "b", Discipline: def.Name, Time: "00:00:20.0000", Status: models.StatusPass},
+		{TeamID: "c", Discipline: def.Name, Time: "00:00:10.0000", Status: models.StatusPass},
+		{TeamID: "x", Discipline: def.Name, Time: "00:00:05.0000", Status: models.StatusPass},
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, SeedTeams(teams, def, results), "unranked teams are seeded last")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Recording matches
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestRecordMatchByTime(t *testing.T) {
+	b, err := NewBracket(models.DisciplineTeamClash, models.BracketSingle, []string{"t1", "t2", "t3", "t4"})
+	require.NoError(t, err)
+
+	require.NoError(t, RecordMatchByTime(&b, "W1.1", "12.5", "11.0"))
+	m := match(t, b, "W1.1")
+	assert.Equal(t, "t4", m.Winner, "the faster team wins")
+	assert.Equal(t, models.DecisionTime, m.Decision)
+	assert.Equal(t, "00:00:12.5000", m.TimeA)
+	assert.Equal(t, "t4", match(t, b, "W2.1").A)
+
+	assert.Error(t, RecordMatchByTime(&b, "W1.2", "10", "10"), "equal times are for the judges")
+	assert.Error(t, RecordMatchByTime(&b, "W1.2", "abc", "10"))
+	assert.Error(t, RecordMatchByTime(&b, "W9.9", "9", "10"))
+	assert.Error(t, RecordMatch(&b, "W2.1", "t4", models.DecisionJudge, "", ""), "the other semi-final is undecided")
+	assert.Error(t, RecordMatch(&b, "W1.2", "t1", models.DecisionJudge, "", ""), "t1 is not in the match")
+}
+
+func TestRecordMatch_Amend(t *testing.T) {
+	b, err := NewBracket(models.DisciplineTeamClash, models.BracketSingle, []string{"t1", "t2", "t3", "t4"})
+	require.NoError(t, err)
+	require.NoError(t, RecordMatch(&b, "W1.1", "t1", models.DecisionJudge, "", ""))
+	require.NoError(t, RecordMatch(&b, "W1.1", "t4", models.DecisionJudge, "", ""))
+	assert.Equal(t, "t4", match(t, b, "W2.1").A, "the amended winner replaces the old one")
+
+	require.NoError(t, RecordMatch(&b, "W1.2", "t2", models.DecisionJudge, "", ""))
+	require.NoError(t, RecordMatch(&b, "W2.1", "t2", models.DecisionJudge, "", ""))
+	assert.Error(t, RecordMatch(&b, "W1.1", "t1", models.DecisionJudge, "", ""), "the final has been played")
+}
+
+func TestRecordMatch_AmendThroughBye(t *testing.T) {
+	b, err := NewBracket(models.DisciplineTeamClash, models.BracketDouble, []string{"t1", "t2", "t3"})
+	require.NoError(t, err)
+	// t4 is a bye: t1 goes through, and the bye drops into the losers bracket
+	// where the loser of W1.2 goes through it in turn.
+	require.NoError(t, RecordMatch(&b, "W1.2", "t2", models.DecisionJudge, "", ""))
+	assert.Equal(t, "t3", match(t, b, "L2.1").A)
+
+	require.NoError(t, RecordMatch(&b, "W1.2", "t3", models.DecisionJudge, "", ""))
+	assert.Equal(t, "t2", match(t, b, "L2.1").A, "the bye is decided again for the new loser")
+	assert.Equal(t, "t3", match(t, b, "W2.1").B)
+}
+
+func TestBracketRounds(t *testing.T) {
+	b, err := NewBracket(models.DisciplineTeamClash, models.BracketDouble, []string{"a", "b", "c", "d", "e", "f", "g", "h"})
+	require.NoError(t, err)
+	var names []string
+	for _, r := range BracketRounds(b) {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"Quarter-finals", "Semi-finals", "Winners final",
+		"Losers round 1", "Losers round 2", "Losers round 3", "Losers final", "Grand final"}, names)
+}
+
+func TestLoadSaveBrackets(t *testing.T) {
+	path := BracketPathFor(filepath.Join(t.TempDir(), "participants.json"))
+	brackets, err := LoadBrackets(path)
+	require.NoError(t, err)
+	assert.Empty(t, brackets, "a missing file means no brackets")
+
+	b, err := NewBracket(models.DisciplineTeamClash, models.BracketDouble, []string{"t1", "t2", "t3"})
+	require.NoError(t, err)
+	require.NoError(t, SaveBrackets(path, []models.Bracket{b}))
+	brackets, err = LoadBrackets(path)
+	require.NoError(t, err)
+	require.Len(t, brackets, 1)
+	assert.Equal(t, b, brackets[0])
+	assert.Equal(t, 0, FindBracket(brackets, models.DisciplineTeamClash))
+	assert.Equal(t, -1, FindBracket(brackets, models.DisciplineBottle))
+}
@@ -0,0 +1,137 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// CategoryPathFor returns the category file of a contest, stored next to
+// its participant file.
+func CategoryPathFor(participantFile string) string {
+	return filepath.Join(filepath.Dir(participantFile), config.CategoryFileName)
+}
+
+// LoadCategories reads a category file: a JSON array of categories in the
+// order their leaderboards are shown. A missing file means no categories
+// are configured. Once the file has been sealed (see SealSetup) the sealed
+// copy is read instead.
+func LoadCategories(path string) ([]models.Category, error) {
+	if sealed := SealedPathFor(path); utils.DoesFileExist(sealed) {
+		path = sealed
+	}
+	return readCategories(path)
+}
+
+// readCategories reads the category file at path itself, sealed or not.
+func readCategories(path string) ([]models.Category, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading categories %s: %w", path, err)
+	}
+	var categories []models.Category
+	if err := json.Unmarshal(content, &categories); err != nil {
+		return nil, fmt.Errorf("error parsing categories %s: %w", path, err)
+	}
+	seen := make(map[string]bool, len(categories))
+	for i, c := range categories {
+		key := strings.ToLower(strings.TrimSpace(c.Name))
+		switch {
+		case key == "":
+			return nil, fmt.Errorf("category %d in %s has no name", i+1, path)
+		case seen[key]:
+			return nil, fmt.Errorf("category %q is listed twice in %s", c.Name, path)
+		}
+		seen[key] = true
+	}
+	return categories, nil
+}
+
+// SaveCategories writes the categories of a contest to path.
+func SaveCategories(path string, categories []models.Category) error {
+	if err := utils.SaveJSONFile(path, categories); err != nil {
+		return fmt.Errorf("error saving categories %s: %w", path, err)
+	}
+	return nil
+}
+
+// ContestCategories returns the categories a contest shows leaderboards
+// for: the configured ones in their order, then any other category a
+// participant is in, by name and without diplomas.
+func ContestCategories(configured []models.Category, participants []models.Participant) []models.Category {
+	categories := append([]models.Category(nil), configured...)
+	known := make(map[string]bool, len(configured))
+	for _, c := range configured {
+		known[strings.ToLower(c.Name)] = true
+	}
+	var other []string
+	for _, p := range participants {
+		for _, name := range p.Categories {
+			if key := strings.ToLower(name); name != "" && !known[key] {
+				known[key] = true
+				other = append(other, name)
+			}
+		}
+	}
+	sort.Strings(other)
+	for _, name := range other {
+		categories = append(categories, models.Category{Name: name})
+	}
+	return categories
+}
+
+// InCategory reports whether p is in category; case is ignored.
+func InCategory(p models.Participant, category string) bool {
+	for _, c := range p.Categories {
+		if strings.EqualFold(c, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryResults returns the results of the participants in category.
+// Team results are left out: teams are not split by category.
+func CategoryResults(results []models.Result, participants []models.Participant, category string) []models.Result {
+	var members []models.Participant
+	for _, p := range participants {
+		if InCategory(p, category) {
+			members = append(members, p)
+		}
+	}
+	var kept []models.Result
+	for _, r := range results {
+		for _, p := range members {
+			if r.BelongsTo(p) {
+				kept = append(kept, r)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// ParseCategories splits a comma-separated list of category names, as
+// typed in a form or an import column, leaving out blanks and repeats.
+func ParseCategories(s string) []string {
+	var categories []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if key := strings.ToLower(name); name != "" && !seen[key] {
+			seen[key] = true
+			categories = append(categories, name)
+		}
+	}
+	return categories
+}
@@ -0,0 +1,93 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Category file
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestLoadSaveCategories(t *testing.T) {
+	path := CategoryPathFor(filepath.Join(t.TempDir(), "participants.json"))
+	categories, err := LoadCategories(path)
+	require.NoError(t, err)
+	assert.Empty(t, categories, "a missing file means no categories")
+
+	want := []models.Category{{Name: "Women", Diplomas: true}, {Name: "Alumni"}}
+	require.NoError(t, SaveCategories(path, want))
+	categories, err = LoadCategories(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, categories)
+}
+
+func TestLoadCategories_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "categories.json")
+	for _, content := range []string{
+		`[{"name": ""}]`,
+		`[{"name": "Women"}, {"name": "women"}]`,
+		`{"name": "Women"}`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		_, err := LoadCategories(path)
+		assert.Error(t, err, content)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Membership
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestContestCategories(t *testing.T) {
+	configured := []models.Category{{Name: "Women", Diplomas: true}}
+	participants := []models.Participant{
+		{ID: "p1", Categories: []string{"women", "Guests"}},
+		{ID: "p2", Categories: []string{"Alumni", "Guests"}},
+	}
+	assert.Equal(t, []models.Category{{Name: "Women", Diplomas: true}, {Name: "Alumni"}, {Name: "Guests"}},
+		ContestCategories(configured, participants), "categories only used by participants follow by name")
+}
+
+func TestCategoryResults(t *testing.T) {
+	participants := []models.Participant{
+		{ID: "p1", Name: "Alice", Categories: []string{"Women", "Alumni"}},
+		{ID: "p2", Name: "Bob", Categories: []string{"Alumni"}},
+		{ID: "p3", Name: "Carol"},
+	}
+	results := []models.Result{
+		{ID: "r1", ParticipantID: "p1", Name: "Alice"},
+		{ID: "r2", ParticipantID: "p2", Name: "Bob"},
+		{ID: "r3", ParticipantID: "p3", Name: "Carol"},
+		{ID: "r4", TeamID: "t1", Name: "Red"},
+	}
+	ids := func(rs []models.Result) []string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"r1"}, ids(CategoryResults(results, participants, "women")))
+	assert.Equal(t, []string{"r1", "r2"}, ids(CategoryResults(results, participants, "Alumni")))
+	assert.Empty(t, CategoryResults(results, participants, "Guests"))
+
+	// A category leaderboard ranks its own members from first place
+	def := builtin(models.DisciplineBottle)
+	results[0].Time, results[0].Status = "00:00:09.0000", models.StatusPass
+	results[1].Time, results[1].Status = "00:00:05.0000", models.StatusPass
+	standings := Standings(def, CategoryResults(results, participants, "Women"))
+	require.Len(t, standings, 1)
+	assert.Equal(t, 1, standings[0].Rank)
+}
+
+func TestParseCategories(t *testing.T) {
+	assert.Equal(t, []string{"Women", "Alumni"}, ParseCategories(" Women, ,Alumni, women "))
+	assert.Empty(t, ParseCategories(""))
+}
@@ -0,0 +1,263 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// ContestRules are the rules a contest runs under, set by its mode. Official
+// contests run by the strict rule set; unofficial ones, such as training
+// nights and parties, relax it and stay out of the club's statistics.
+type ContestRules struct {
+	Official bool
+	// LimitTries holds participants to the tries they are entitled to;
+	// otherwise they may run a discipline they entered as often as they like
+	LimitTries bool
+	// Counted puts the contest in the records and season standings
+	Counted bool
+	// LockConfiguration freezes the setup of the contest once its first
+	// result has been recorded
+	LockConfiguration bool
+}
+
+// OfficialRules returns the strict rule set of an official contest.
+func OfficialRules() ContestRules {
+	return ContestRules{Official: true, LimitTries: true, Counted: true, LockConfiguration: true}
+}
+
+// UnofficialRules returns the relaxed rule set of an unofficial contest.
+func UnofficialRules() ContestRules {
+	return ContestRules{}
+}
+
+// ContestFolderName returns the folder name of a contest,
+// <Name>_<YYYY-MM-DD>_<Official|Unofficial>, with the spaces of the name
+// replaced by underscores.
+func ContestFolderName(name, date string, official bool) string {
+	mode := config.UnOfficialKey
+	if official {
+		mode = config.OfficialKey
+	}
+	return fmt.Sprintf("%s_%s_%s", strings.ReplaceAll(name, " ", "_"), date, mode)
+}
+
+// ContestFolderOf returns the contest folder a data file belongs to: the
+// folder holding its contest/ directory.
+func ContestFolderOf(dataFile string) string {
+	return filepath.Dir(filepath.Dir(absPath(dataFile)))
+}
+
+// IsOfficialFolder reports whether the contest in folder, a folder name or
+// path, is official. Only folders marked Unofficial are not: contests from
+// before the mode could be chosen were all official.
+func IsOfficialFolder(folder string) bool {
+	return !strings.HasSuffix(filepath.Base(folder), "_"+config.UnOfficialKey)
+}
+
+// RulesFor returns the rules of the contest in folder, by its mode.
+func RulesFor(folder string) ContestRules {
+	if IsOfficialFolder(folder) {
+		return OfficialRules()
+	}
+	return UnofficialRules()
+}
+
+// MayRun reports whether p may make another attempt at the discipline def:
+// they must have entered it, and under LimitTries have a try left.
+func (cr ContestRules) MayRun(p models.Participant, def models.DisciplineDef, results []models.Result) bool {
+	if !cr.LimitTries {
+		entitled, ok := TriesEntitled(p, def)
+		return ok && entitled > 0
+	}
+	return RemainingTries(p, def, results) > 0
+}
+
+// Locked reports whether the setup of a contest with results is frozen:
+// under LockConfiguration it is from the first result that stands. A
+// result voided since does not lock it.
+func (cr ContestRules) Locked(results []models.Result) bool {
+	if !cr.LockConfiguration {
+		return false
+	}
+	for _, r := range results {
+		if !r.Voided {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupLockedError is returned when a change to the setup of an official
+// contest is refused because results have been recorded.
+type SetupLockedError struct {
+	What string // what could not be changed, e.g. "the participant list"
+}
+
+func (e *SetupLockedError) Error() string {
+	return fmt.Sprintf("%s cannot be changed: the contest is official and results have been recorded", e.What)
+}
+
+// SameTries reports whether a and b are entitled to the same tries in every
+// discipline.
+func SameTries(a, b models.Participant) bool {
+	if a.Bottle != b.Bottle || a.HalfTankard != b.HalfTankard || a.FullTankard != b.FullTankard ||
+		len(a.Tries) != len(b.Tries) {
+		return false
+	}
+	for discipline, n := range a.Tries {
+		if m, ok := b.Tries[discipline]; !ok || m != n {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupLocked reports whether the setup of the contest with the given files
+// is locked (see ContestRules.Locked). The results are taken from the open
+// session of the files if there is one and read from disk otherwise,
+// without writing anything.
+func SetupLocked(participantFile, resultFile string) (bool, error) {
+	if resultFile == "" {
+		return false, nil
+	}
+	rules := RulesFor(ContestFolderOf(resultFile))
+	if !rules.LockConfiguration {
+		return false, nil
+	}
+
+	sessionsMu.Lock()
+	s, open := sessions[absPath(participantFile)+"\x00"+absPath(resultFile)]
+	sessionsMu.Unlock()
+	if open {
+		return rules.Locked(s.Results().GetResults()), nil
+	}
+
+	entries, err := OpenJournal(JournalPathFor(resultFile)).Entries()
+	if err != nil {
+		return false, err
+	}
+	var results []models.Result
+	if len(entries) > 0 {
+		if results, err = ReplayResults(entries); err != nil {
+			return false, err
+		}
+	} else if utils.DoesFileExist(resultFile) {
+		store, err := resultStoreFor(resultFile)
+		if err != nil {
+			return false, err
+		}
+		if results, err = store.LoadResults(); err != nil {
+			return false, err
+		}
+	}
+	return rules.Locked(results), nil
+}
+
+// CheckContestFiles returns an error if switching the participant and
+// result files from the current ones to the new ones would swap the files of
+// a locked contest for others in the same contest folder. Files in another
+// folder open another contest, which is always allowed.
+func CheckContestFiles(participantFile, resultFile, newParticipantFile, newResultFile string) error {
+	swapped := func(old, updated string) bool {
+		return old != "" && absPath(old) != absPath(updated) && ContestFolderOf(old) == ContestFolderOf(updated)
+	}
+	if !swapped(participantFile, newParticipantFile) && !swapped(resultFile, newResultFile) {
+		return nil
+	}
+	locked, err := SetupLocked(participantFile, resultFile)
+	if err != nil {
+		return fmt.Errorf("cannot tell whether the contest files may be changed: %w", err)
+	}
+	if locked {
+		return &SetupLockedError{What: "the participant and result files of the contest"}
+	}
+	return nil
+}
+
+// SealedPathFor returns where the sealed copy of a discipline or category
+// file is kept: in the sealed folder next to it.
+func SealedPathFor(path string) string {
+	return filepath.Join(filepath.Dir(path), config.SealedDirectory, filepath.Base(path))
+}
+
+// SealSetup freezes the discipline and category definitions of the contest
+// next to participantFile when its setup locks. A copy of each, as it is read
+// now, is kept at SealedPathFor and LoadDisciplines and LoadCategories read it
+// instead of the file from then on, so a file edited by hand cannot change the
+// rules and standings of results already recorded. Copies made earlier are
+// kept.
+func SealSetup(participantFile string) error {
+	categoryFile := CategoryPathFor(participantFile)
+	if sealed := SealedPathFor(categoryFile); !utils.DoesFileExist(sealed) {
+		categories, err := readCategories(categoryFile)
+		if err != nil {
+			return err
+		}
+		if err := writeSealed(sealed, categories); err != nil {
+			return err
+		}
+	}
+	disciplineFile := DisciplinePathFor(participantFile)
+	if sealed := SealedPathFor(disciplineFile); !utils.DoesFileExist(sealed) {
+		disciplines, err := readDisciplines(disciplineFile)
+		if err != nil {
+			return err
+		}
+		if err := writeSealed(sealed, disciplines.defs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSealed writes a sealed copy. Unlike the data files it is written once,
+// so it gets no rolling backups.
+func writeSealed(path string, v any) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error sealing %s: %w", filepath.Base(path), err)
+	}
+	if err := utils.WriteFileAtomic(path, content, 0644); err != nil {
+		return fmt.Errorf("error sealing %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// UnsealSetup drops the sealed copies of the contest next to participantFile,
+// e.g. once every result has been voided and the setup is open again.
+func UnsealSetup(participantFile string) error {
+	return os.RemoveAll(filepath.Join(filepath.Dir(participantFile), config.SealedDirectory))
+}
+
+// IgnoredSetupEdits returns the names of the sealed setup files of the contest
+// next to participantFile that have been edited since they were sealed. The
+// edits have no effect while the seal holds.
+func IgnoredSetupEdits(participantFile string) []string {
+	var edited []string
+	disciplineFile := DisciplinePathFor(participantFile)
+	if sealedFile := SealedPathFor(disciplineFile); utils.DoesFileExist(sealedFile) {
+		sealed, err := readDisciplines(sealedFile)
+		current, currentErr := readDisciplines(disciplineFile)
+		if err == nil && (currentErr != nil || !reflect.DeepEqual(current.defs, sealed.defs)) {
+			edited = append(edited, config.DisciplineFileName)
+		}
+	}
+	categoryFile := CategoryPathFor(participantFile)
+	if sealedFile := SealedPathFor(categoryFile); utils.DoesFileExist(sealedFile) {
+		sealed, err := readCategories(sealedFile)
+		current, currentErr := readCategories(categoryFile)
+		same := len(current) == 0 && len(sealed) == 0 || reflect.DeepEqual(current, sealed)
+		if err == nil && (currentErr != nil || !same) {
+			edited = append(edited, config.CategoryFileName)
+		}
+	}
+	return edited
+}
@@ -0,0 +1,255 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Contest modes
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestContestFolderName(t *testing.T) {
+	assert.Equal(t, "Spring_Cup_2026-03-01_Official", ContestFolderName("Spring Cup", "2026-03-01", true))
+	assert.Equal(t, "Party_2026-04-01_Unofficial", ContestFolderName("Party", "2026-04-01", false))
+}
+
+func TestRulesFor(t *testing.T) {
+	assert.Equal(t, OfficialRules(), RulesFor(filepath.Join("ChugWare", "Spring_2026-03-01_Official")))
+	assert.Equal(t, UnofficialRules(), RulesFor("Party_2026-04-01_Unofficial"))
+	assert.Equal(t, OfficialRules(), RulesFor("Legacy"), "folders without a mode are official")
+	assert.True(t, OfficialRules().Counted)
+	assert.False(t, UnofficialRules().Counted)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Rules
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestContestRules_MayRun(t *testing.T) {
+	def := builtin(models.DisciplineFullTankard)
+	p := models.Participant{ID: "p1", Name: "Alice", Bottle: "3", HalfTankard: "2", FullTankard: "1"}
+	results := []models.Result{{ParticipantID: "p1", Discipline: models.DisciplineFullTankard, Status: models.StatusPass}}
+
+	assert.False(t, OfficialRules().MayRun(p, def, results), "the only try has been used")
+	assert.True(t, UnofficialRules().MayRun(p, def, results), "tries are unlimited")
+
+	p.FullTankard = "0"
+	assert.False(t, UnofficialRules().MayRun(p, def, nil), "only those who entered the discipline run it")
+}
+
+func TestContestRules_Locked(t *testing.T) {
+	assert.False(t, OfficialRules().Locked(nil))
+	assert.False(t, OfficialRules().Locked([]models.Result{{ID: "r1", Voided: true}}), "a voided result does not lock the setup")
+
+	results := []models.Result{{ID: "r1", Voided: true}, {ID: "r2"}}
+	assert.True(t, OfficialRules().Locked(results))
+	assert.False(t, UnofficialRules().Locked(results))
+}
+
+func TestSameTries(t *testing.T) {
+	old := models.Participant{Name: "Alice", Bottle: "3", HalfTankard: "2", FullTankard: "2", Tries: map[string]int{"Yard": 1}}
+	renamed := old
+	renamed.Name = "Alice B"
+	more := old
+	more.Bottle = "4"
+	custom := old
+	custom.Tries = map[string]int{"Yard": 2}
+
+	assert.True(t, SameTries(old, renamed))
+	assert.False(t, SameTries(old, more))
+	assert.False(t, SameTries(old, custom))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Locked setup
+// ─────────────────────────────────────────────────────────────────────────────
+
+// openModeSession opens a session on an empty contest in a folder named
+// folder, with Alice registered and, if recorded, a result for her.
+func openModeSession(t *testing.T, folder string, recorded bool) (*Session, models.Participant) {
+	t.Helper()
+	pFile, rFile := ContestStorePaths(filepath.Join(t.TempDir(), folder, config.ContestDirectory), BackendJSON)
+	s, err := OpenSession(pFile, rFile, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Release() })
+
+	alice := models.Participant{Name: "Alice", Program: "F", Team: "T1", Bottle: "3", HalfTankard: "2", FullTankard: "2"}
+	require.NoError(t, s.Participants().AddParticipant(alice))
+	alice = s.Participants().GetParticipants()[0]
+	if recorded {
+		require.NoError(t, s.Results().AddResult(models.Result{ParticipantID: alice.ID, Name: "Alice",
+			Discipline: models.DisciplineBottle, BaseTime: "5", Status: models.StatusPass}))
+	}
+	return s, alice
+}
+
+func TestParticipantManager_LockedSetup(t *testing.T) {
+	s, alice := openModeSession(t, "Cup_2026-03-01_Official", true)
+	pm := s.Participants()
+	var locked *SetupLockedError
+
+	assert.ErrorAs(t, pm.AddParticipant(models.Participant{Name: "Bob", Program: "F", Team: "T1", Bottle: "3"}), &locked)
+	assert.ErrorAs(t, pm.RemoveParticipant(alice.ID), &locked)
+	_, err := pm.ImportParticipants(ImportReport{Rows: []ImportRow{{Participant: models.Participant{Name: "Cleo", Program: "F", Team: "T1"}}}})
+	assert.ErrorAs(t, err, &locked)
+
+	more := alice
+	more.Bottle = "4"
+	assert.ErrorAs(t, pm.UpdateParticipant(more), &locked)
+	renamed := alice
+	renamed.Name = "Alice B"
+	assert.NoError(t, pm.UpdateParticipant(renamed), "a name can still be corrected")
+	assert.Len(t, pm.GetParticipants(), 1)
+
+	// Voiding the only result opens the setup again
+	require.NoError(t, s.Results().VoidResult(s.Results().GetResults()[0].ID, "false start"))
+	assert.NoError(t, pm.AddParticipant(models.Participant{Name: "Bob", Program: "F", Team: "T1", Bottle: "3"}))
+}
+
+func TestParticipantManager_OpenSetup(t *testing.T) {
+	for _, tc := range []struct {
+		folder   string
+		recorded bool
+	}{
+		{"Cup_2026-03-01_Official", false},
+		{"Party_2026-04-01_Unofficial", true},
+	} {
+		s, alice := openModeSession(t, tc.folder, tc.recorded)
+		pm := s.Participants()
+		assert.NoError(t, pm.AddParticipant(models.Participant{Name: "Bob", Program: "F", Team: "T1", Bottle: "3"}), tc.folder)
+		more := alice
+		more.Bottle = "4"
+		assert.NoError(t, pm.UpdateParticipant(more), tc.folder)
+	}
+}
+
+func TestTeamManager_LockedSetup(t *testing.T) {
+	s, alice := openModeSession(t, "Cup_2026-03-01_Official", false)
+	tm := s.Teams()
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red", Members: []string{alice.ID}}))
+	red := tm.GetTeams()[0]
+	require.NoError(t, s.Results().AddResult(models.Result{TeamID: red.ID, Name: "Red",
+		Discipline: models.DisciplineBierStaphette, BaseTime: "30", Status: models.StatusPass}))
+	var locked *SetupLockedError
+
+	assert.ErrorAs(t, tm.AddTeam(models.Team{Name: "Blue"}), &locked)
+	assert.ErrorAs(t, tm.RemoveTeam(red.ID), &locked)
+	roster := red
+	roster.Members = nil
+	assert.ErrorAs(t, tm.UpdateTeam(roster), &locked)
+	renamed := red
+	renamed.Name = "Red Devils"
+	assert.NoError(t, tm.UpdateTeam(renamed), "a name can still be corrected")
+
+	// Voiding the only result opens the setup again
+	require.NoError(t, s.Results().VoidResult(s.Results().GetResults()[0].ID, "false start"))
+	assert.NoError(t, tm.AddTeam(models.Team{Name: "Blue"}))
+}
+
+func TestSession_SealsSetupFiles(t *testing.T) {
+	s, alice := openModeSession(t, "Cup_2026-03-01_Official", false)
+	pFile := s.participantFile
+	disciplineFile, categoryFile := DisciplinePathFor(pFile), CategoryPathFor(pFile)
+	require.NoError(t, SaveCategories(categoryFile, []models.Category{{Name: "Women", Diplomas: true}}))
+
+	// Nothing is sealed before the first result
+	rm := s.Results()
+	require.NoError(t, rm.SaveResults())
+	assert.NoFileExists(t, SealedPathFor(disciplineFile))
+
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: alice.ID, Name: "Alice",
+		Discipline: models.DisciplineBottle, BaseTime: "5", Status: models.StatusPass}))
+	require.NoError(t, rm.SaveResults())
+	assert.FileExists(t, SealedPathFor(disciplineFile))
+	assert.FileExists(t, SealedPathFor(categoryFile))
+	assert.Empty(t, IgnoredSetupEdits(pFile))
+
+	// Edits made by hand from now on are ignored
+	edited := DefaultDisciplines()[:1]
+	edited[0].TimeLimit = "10"
+	require.NoError(t, SaveDisciplines(disciplineFile, &DisciplineRegistry{defs: edited}))
+	require.NoError(t, os.WriteFile(categoryFile, []byte(`[{"name":"Alumni"}]`), 0644))
+	disciplines, err := LoadDisciplines(disciplineFile)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDisciplineRegistry().Names(), disciplines.Names())
+	categories, err := LoadCategories(categoryFile)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Category{{Name: "Women", Diplomas: true}}, categories)
+	assert.Equal(t, []string{config.DisciplineFileName, config.CategoryFileName}, IgnoredSetupEdits(pFile))
+
+	// Voiding the only result opens the setup again, edits and all
+	require.NoError(t, rm.VoidResult(rm.GetResults()[0].ID, "false start"))
+	require.NoError(t, rm.SaveResults())
+	assert.NoFileExists(t, SealedPathFor(disciplineFile))
+	disciplines, err = LoadDisciplines(disciplineFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{edited[0].Name}, disciplines.Names())
+}
+
+func TestSession_UnofficialSetupIsNotSealed(t *testing.T) {
+	s, alice := openModeSession(t, "Party_2026-04-01_Unofficial", true)
+	require.NoError(t, s.Results().AddResult(models.Result{ParticipantID: alice.ID, Name: "Alice",
+		Discipline: models.DisciplineBottle, BaseTime: "6", Status: models.StatusPass}))
+	require.NoError(t, s.Results().SaveResults())
+	assert.NoFileExists(t, SealedPathFor(DisciplinePathFor(s.participantFile)))
+	assert.NoError(t, s.Teams().AddTeam(models.Team{Name: "Blue"}))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Disqualified results
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestDisqualifiedResults_EditableInEveryMode(t *testing.T) {
+	for _, folder := range []string{"Cup_2026-03-01_Official", "Party_2026-03-01_Unofficial"} {
+		t.Run(folder, func(t *testing.T) {
+			s, alice := openModeSession(t, folder, false)
+			rm := s.Results()
+			dq := models.Result{ParticipantID: alice.ID, Name: "Alice", Discipline: models.DisciplineBottle, Status: models.StatusDisqualified}
+			require.NoError(t, rm.AddResult(dq))
+			id := rm.GetResults()[0].ID
+
+			pass := models.Result{ParticipantID: alice.ID, Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "6", Status: models.StatusPass}
+			require.NoError(t, rm.UpdateLastResult(pass), "the last result is overwritten")
+			r, _ := rm.GetResultByID(id)
+			assert.Equal(t, models.StatusPass, r.Status)
+
+			require.NoError(t, rm.AmendResult(id, models.Result{Status: models.StatusDisqualified}, "spill on video", builtin(models.DisciplineBottle)))
+			require.NoError(t, rm.AmendResult(id, models.Result{BaseTime: "5", Status: models.StatusPass}, "appeal upheld", builtin(models.DisciplineBottle)))
+			r, _ = rm.GetResultByID(id)
+			assert.Equal(t, models.StatusPass, r.Status, "a disqualification is amended")
+
+			require.NoError(t, rm.AmendResult(id, models.Result{Status: models.StatusDisqualified}, "second look", builtin(models.DisciplineBottle)))
+			require.NoError(t, rm.VoidResult(id, "wrong participant"))
+			r, _ = rm.GetResultByID(id)
+			assert.True(t, r.Voided, "a disqualification is voided")
+		})
+	}
+}
+
+func TestCheckContestFiles(t *testing.T) {
+	s, _ := openModeSession(t, "Cup_2026-03-01_Official", true)
+	require.NoError(t, s.Results().SaveResults())
+	pFile, rFile := s.participantFile, s.resultFile
+	other := filepath.Join(filepath.Dir(pFile), "other.json")
+	var locked *SetupLockedError
+
+	assert.NoError(t, CheckContestFiles(pFile, rFile, pFile, rFile))
+	assert.ErrorAs(t, CheckContestFiles(pFile, rFile, other, rFile), &locked, "the files of the contest stay")
+
+	nextPFile, nextRFile := ContestStorePaths(filepath.Join(t.TempDir(), "Next_2026-05-01_Official", config.ContestDirectory), BackendJSON)
+	assert.NoError(t, CheckContestFiles(pFile, rFile, nextPFile, nextRFile), "another contest can be opened")
+
+	// Read from disk once the session is closed
+	require.NoError(t, s.Release())
+	isLocked, err := SetupLocked(pFile, rFile)
+	require.NoError(t, err)
+	assert.True(t, isLocked)
+}
@@ -0,0 +1,374 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// DisciplineRegistry is the list of disciplines a contest runs, in the order
+// they are shown. It is read from the discipline file of the contest (see
+// DisciplinePathFor); a contest without one runs the built-in disciplines.
+type DisciplineRegistry struct {
+	defs []models.DisciplineDef
+}
+
+// DefaultDisciplines returns the built-in discipline definitions: the three
+// individual disciplines of the "322" code and the three team disciplines.
+func DefaultDisciplines() []models.DisciplineDef {
+	return []models.DisciplineDef{
+		{
+			Name:         models.DisciplineBottle,
+			DefaultTries: config.BottleTries,
+			CodeDigit:    1,
+			Penalty:      models.PenaltyRule{Prompt: true, DQReason: "Overflow"},
+		},
+		{Name: models.DisciplineHalfTankard, DefaultTries: config.HalfTankardTries, CodeDigit: 2},
+		{Name: models.DisciplineFullTankard, DefaultTries: config.FullTankardTries, CodeDigit: 3},
+		{Name: models.DisciplineBierStaphette, Team: true, Relay: true},
+		{Name: models.DisciplineMegaMedley, Team: true},
+		{Name: models.DisciplineTeamClash, Team: true},
+	}
+}
+
+// DefaultDisciplineRegistry returns the registry of the built-in disciplines.
+func DefaultDisciplineRegistry() *DisciplineRegistry {
+	return &DisciplineRegistry{defs: DefaultDisciplines()}
+}
+
+// NewDisciplineRegistry validates defs and returns a registry over them.
+func NewDisciplineRegistry(defs []models.DisciplineDef) (*DisciplineRegistry, error) {
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no disciplines defined")
+	}
+	seen := make(map[string]bool, len(defs))
+	for i, d := range defs {
+		name := strings.TrimSpace(d.Name)
+		if name == "" {
+			return nil, fmt.Errorf("discipline %d has no name", i+1)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("discipline %q is defined twice", name)
+		}
+		seen[name] = true
+		defs[i].Name = name
+
+		if d.Team && (d.CodeDigit != 0 || d.DefaultTries != 0) {
+			return nil, fmt.Errorf("discipline %q: team disciplines have no tries", name)
+		}
+		if d.Relay && !d.Team {
+			return nil, fmt.Errorf("discipline %q: a relay must be a team discipline", name)
+		}
+		if d.CodeDigit < 0 || d.CodeDigit > 3 {
+			return nil, fmt.Errorf("discipline %q: code digit must be 1, 2 or 3", name)
+		}
+		if d.DefaultTries < 0 || d.DefaultTries > 9 {
+			return nil, fmt.Errorf("discipline %q: default tries must be between 0 and 9", name)
+		}
+		switch d.Ranking {
+		case "", models.RankByTotal, models.RankByBase:
+		default:
+			return nil, fmt.Errorf("discipline %q: unknown ranking %q", name, d.Ranking)
+		}
+		switch d.Attempts {
+		case "", models.CountBest, models.CountLast:
+		case models.CountAverage:
+			if d.AverageOf < 1 || d.AverageOf > 9 {
+				return nil, fmt.Errorf("discipline %q: average_of must be between 1 and 9", name)
+			}
+		default:
+			return nil, fmt.Errorf("discipline %q: unknown attempt policy %q", name, d.Attempts)
+		}
+		for j, rule := range d.TieBreak {
+			switch rule {
+			case models.TieBreakPenalty, models.TieBreakSecond, models.TieBreakEarlier:
+			case models.TieBreakShared:
+				if j != len(d.TieBreak)-1 {
+					return nil, fmt.Errorf("discipline %q: tie break %q must come last", name, rule)
+				}
+			default:
+				return nil, fmt.Errorf("discipline %q: unknown tie break %q", name, rule)
+			}
+		}
+		for _, p := range d.Points {
+			if p < 0 {
+				return nil, fmt.Errorf("discipline %q: points cannot be negative", name)
+			}
+		}
+		if d.TimeLimit != "" && limitTime(d.TimeLimit) < 0 {
+			return nil, fmt.Errorf("discipline %q: invalid time limit %q", name, d.TimeLimit)
+		}
+		if d.Penalty.Max != "" && limitTime(d.Penalty.Max) < 0 {
+			return nil, fmt.Errorf("discipline %q: invalid maximum penalty %q", name, d.Penalty.Max)
+		}
+		penalties := make(map[string]bool, len(d.Penalty.Catalog))
+		for _, item := range d.Penalty.Catalog {
+			switch {
+			case strings.TrimSpace(item.Name) == "":
+				return nil, fmt.Errorf("discipline %q: a penalty has no name", name)
+			case penalties[item.Name]:
+				return nil, fmt.Errorf("discipline %q: penalty %q is listed twice", name, item.Name)
+			case item.Time != "" && limitTime(item.Time) < 0:
+				return nil, fmt.Errorf("discipline %q: penalty %q has an invalid time %q", name, item.Name, item.Time)
+			case item.DQAfter < 0:
+				return nil, fmt.Errorf("discipline %q: penalty %q: dq_after cannot be negative", name, item.Name)
+			case item.Time == "" && item.DQAfter == 0:
+				return nil, fmt.Errorf("discipline %q: penalty %q needs a time or dq_after", name, item.Name)
+			}
+			penalties[item.Name] = true
+		}
+	}
+	return &DisciplineRegistry{defs: defs}, nil
+}
+
+// DisciplinePathFor returns the discipline file that belongs with a
+// participant file setting. It is a JSON file next to the participant file,
+// for a contest database too.
+func DisciplinePathFor(participantFile string) string {
+	return filepath.Join(filepath.Dir(participantFile), config.DisciplineFileName)
+}
+
+// LoadDisciplines reads a discipline file: a JSON array of discipline
+// definitions. A missing file means the built-in disciplines. Once the file
+// has been sealed (see SealSetup) the sealed copy is read instead.
+func LoadDisciplines(path string) (*DisciplineRegistry, error) {
+	if sealed := SealedPathFor(path); utils.DoesFileExist(sealed) {
+		path = sealed
+	}
+	return readDisciplines(path)
+}
+
+// readDisciplines reads the discipline file at path itself, sealed or not.
+func readDisciplines(path string) (*DisciplineRegistry, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultDisciplineRegistry(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading disciplines %s: %w", path, err)
+	}
+	var defs []models.DisciplineDef
+	if err := json.Unmarshal(content, &defs); err != nil {
+		return nil, fmt.Errorf("error parsing disciplines %s: %w", path, err)
+	}
+	r, err := NewDisciplineRegistry(defs)
+	if err != nil {
+		return nil, fmt.Errorf("error in disciplines %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// SaveDisciplines writes the definitions of r to path.
+func SaveDisciplines(path string, r *DisciplineRegistry) error {
+	return utils.SaveJSONFile(path, r.defs)
+}
+
+// Defs returns a copy of the definitions in order.
+func (r *DisciplineRegistry) Defs() []models.DisciplineDef {
+	return append([]models.DisciplineDef(nil), r.defs...)
+}
+
+// Names returns the discipline names in order.
+func (r *DisciplineRegistry) Names() []string {
+	names := make([]string, len(r.defs))
+	for i, d := range r.defs {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// Get returns the definition of the named discipline.
+func (r *DisciplineRegistry) Get(name string) (models.DisciplineDef, bool) {
+	for _, d := range r.defs {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return models.DisciplineDef{}, false
+}
+
+// IsTeam reports whether the named discipline is run by teams rather than by
+// individual participants.
+func (r *DisciplineRegistry) IsTeam(name string) bool {
+	d, ok := r.Get(name)
+	return ok && d.Team
+}
+
+// Relays returns the names of the relay disciplines in order.
+func (r *DisciplineRegistry) Relays() []string {
+	var names []string
+	for _, d := range r.defs {
+		if d.Relay {
+			names = append(names, d.Name)
+		}
+	}
+	return names
+}
+
+// limitTime parses a time limit or maximum penalty; -1 if it is invalid.
+func limitTime(s string) int64 {
+	padded := utils.ParseAndPadTimeString(strings.TrimSpace(s))
+	if padded == config.NoKey {
+		return -1
+	}
+	return utils.ParseTimeForComparison(padded)
+}
+
+// RankingTime returns the time a result is ranked by under the ranking
+// policy of def, in tenths of milliseconds as utils.ParseTimeForComparison;
+// -1 if the result has no valid time.
+func RankingTime(def models.DisciplineDef, r models.Result) int64 {
+	if def.Ranking == models.RankByBase && r.BaseTime != "" {
+		if t := utils.ParseTimeForComparison(r.BaseTime); t >= 0 {
+			return t
+		}
+	}
+	return utils.ParseTimeForComparison(r.Time)
+}
+
+// ApplyDisciplineRules checks a passed result against the limits of def: a
+// catalog penalty given its DQAfter times or more penalty time than the
+// penalty maximum disqualifies it, and a time over the time limit fails it.
+// The reason is added to the comment.
+func ApplyDisciplineRules(def models.DisciplineDef, r *models.Result) {
+	if r.Status != models.StatusPass {
+		return
+	}
+	if item, ok := EscalatedPenalty(def, r.Penalties); ok {
+		r.Status = models.StatusDisqualified
+		r.Comment = joinComment(r.Comment, item.Name)
+		return
+	}
+	if def.Penalty.Max != "" && r.AdditionalTime != "" {
+		if penalty := penaltyTime(*r); penalty > limitTime(def.Penalty.Max) {
+			r.Status = models.StatusDisqualified
+			r.Comment = joinComment(r.Comment, "penalty over "+def.Penalty.Max)
+			return
+		}
+	}
+	if def.TimeLimit != "" {
+		if t := utils.ParseTimeForComparison(r.Time); t > limitTime(def.TimeLimit) {
+			r.Status = models.StatusFail
+			r.Comment = joinComment(r.Comment, "over time limit "+def.TimeLimit)
+		}
+	}
+}
+
+// GivePenalty adds the catalog penalty item to the penalties of a result and
+// its time to the additional time as entered, e.g. "00:00:01.0000" plus a
+// two-second spill gives "00:00:03.0000". An empty or invalid additional time
+// counts as none.
+func GivePenalty(item models.PenaltyItem, penalties []models.Penalty, additional string) ([]models.Penalty, string) {
+	given := models.Penalty{Name: item.Name}
+	total := limitTime(additional)
+	if total < 0 {
+		total = 0
+	}
+	if item.Time != "" {
+		t := limitTime(item.Time)
+		given.Time = utils.FormatComparisonTime(t)
+		total += t
+	}
+	return append(penalties, given), utils.FormatComparisonTime(total)
+}
+
+// AdditionalTimeWith returns the additional time of a result with the given
+// penalties and extra time given besides them: the sum of their times, e.g. a
+// two-second spill and an extra "1" give "00:00:03.0000". An empty or invalid
+// extra time counts as none.
+func AdditionalTimeWith(penalties []models.Penalty, extra string) string {
+	total := limitTime(extra)
+	if total < 0 {
+		total = 0
+	}
+	return utils.FormatComparisonTime(total + penaltiesTime(penalties))
+}
+
+// ExtraTime returns the part of the additional time of r not given by its
+// itemized penalties, the reverse of AdditionalTimeWith: "" if there is none.
+// Without penalties it is the additional time as entered.
+func ExtraTime(r models.Result) string {
+	if len(r.Penalties) == 0 {
+		return r.AdditionalTime
+	}
+	extra := limitTime(r.AdditionalTime) - penaltiesTime(r.Penalties)
+	if extra <= 0 {
+		return ""
+	}
+	return utils.FormatComparisonTime(extra)
+}
+
+// penaltiesTime returns the time the penalties add up to.
+func penaltiesTime(penalties []models.Penalty) int64 {
+	var total int64
+	for _, p := range penalties {
+		if t := limitTime(p.Time); p.Time != "" && t > 0 {
+			total += t
+		}
+	}
+	return total
+}
+
+// EscalatedPenalty returns the catalog item of def that disqualifies a result
+// with the given penalties: one given at least its DQAfter times.
+func EscalatedPenalty(def models.DisciplineDef, penalties []models.Penalty) (models.PenaltyItem, bool) {
+	for _, item := range def.Penalty.Catalog {
+		if item.DQAfter == 0 {
+			continue
+		}
+		count := 0
+		for _, p := range penalties {
+			if p.Name == item.Name {
+				count++
+			}
+		}
+		if count >= item.DQAfter {
+			return item, true
+		}
+	}
+	return models.PenaltyItem{}, false
+}
+
+// PenaltySummary lists the penalties of a result for reports, e.g.
+// "Spill +00:00:02.0000, Early start".
+func PenaltySummary(penalties []models.Penalty) string {
+	parts := make([]string, len(penalties))
+	for i, p := range penalties {
+		parts[i] = p.Name
+		if p.Time != "" {
+			parts[i] += " +" + p.Time
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// penaltyTime returns the penalty of a result: the difference between its
+// total and base time where both are valid, otherwise its additional time as
+// entered.
+func penaltyTime(r models.Result) int64 {
+	total, base := utils.ParseTimeForComparison(r.Time), utils.ParseTimeForComparison(r.BaseTime)
+	if r.BaseTime != "" && total > 0 && base > 0 {
+		return total - base
+	}
+	return limitTime(r.AdditionalTime)
+}
+
+// joinComment adds reason to comment unless it already gives it, as a comment
+// carried over from an earlier check does.
+func joinComment(comment, reason string) string {
+	if comment == "" {
+		return reason
+	}
+	for _, part := range strings.Split(comment, "; ") {
+		if part == reason {
+			return comment
+		}
+	}
+	return comment + "; " + reason
+}
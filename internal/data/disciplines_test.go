@@ -0,0 +1,205 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// DisciplineRegistry
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestDefaultDisciplineRegistry(t *testing.T) {
+	r := DefaultDisciplineRegistry()
+	assert.Equal(t, []string{
+		models.DisciplineBottle, models.DisciplineHalfTankard, models.DisciplineFullTankard,
+		models.DisciplineBierStaphette, models.DisciplineMegaMedley, models.DisciplineTeamClash,
+	}, r.Names())
+	assert.True(t, r.IsTeam(models.DisciplineMegaMedley))
+	assert.False(t, r.IsTeam(models.DisciplineBottle))
+	assert.False(t, r.IsTeam("Shot"), "unknown disciplines are not team disciplines")
+	assert.Equal(t, []string{models.DisciplineBierStaphette}, r.Relays())
+
+	bottle, ok := r.Get(models.DisciplineBottle)
+	require.True(t, ok)
+	assert.True(t, bottle.Penalty.Prompt)
+	assert.Equal(t, "Overflow", bottle.Penalty.DQReason)
+}
+
+func TestLoadDisciplines_MissingFileMeansDefaults(t *testing.T) {
+	r, err := LoadDisciplines(filepath.Join(t.TempDir(), "disciplines.json"))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDisciplineRegistry().Names(), r.Names())
+}
+
+func TestLoadDisciplines_RoundTrip(t *testing.T) {
+	path := DisciplinePathFor(filepath.Join(t.TempDir(), "contest", "participants.json"))
+	want, err := NewDisciplineRegistry([]models.DisciplineDef{
+		{Name: "Kvartslitern", DefaultTries: 2, TimeLimit: "00:00:30", Ranking: models.RankByBase},
+		{Name: "Shot", DefaultTries: 1, Penalty: models.PenaltyRule{Prompt: true, DQReason: "Spill", Max: "5"}},
+		{Name: "Stafett", Team: true, Relay: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, SaveDisciplines(path, want))
+
+	got, err := LoadDisciplines(path)
+	require.NoError(t, err)
+	assert.Equal(t, want.Defs(), got.Defs())
+}
+
+func TestLoadDisciplines_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "disciplines.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "Shot"}`), 0644))
+	_, err := LoadDisciplines(path)
+	assert.Error(t, err, "the file is an array of definitions")
+}
+
+func TestNewDisciplineRegistry_Validation(t *testing.T) {
+	cases := map[string][]models.DisciplineDef{
+		"empty":           {},
+		"no name":         {{Name: " "}},
+		"duplicate":       {{Name: "Shot"}, {Name: "Shot"}},
+		"team with tries": {{Name: "Stafett", Team: true, DefaultTries: 1}},
+		"lone relay":      {{Name: "Stafett", Relay: true}},
+		"code digit":      {{Name: "Shot", CodeDigit: 4}},
+		"tries":           {{Name: "Shot", DefaultTries: 10}},
+		"ranking":         {{Name: "Shot", Ranking: "fastest"}},
+		"time limit":      {{Name: "Shot", TimeLimit: "soon"}},
+		"penalty max":     {{Name: "Shot", Penalty: models.PenaltyRule{Max: "a lot"}}},
+		"attempts":        {{Name: "Shot", Attempts: "first"}},
+		"average of":      {{Name: "Shot", Attempts: models.CountAverage}},
+		"tie break":       {{Name: "Shot", TieBreak: []string{"coin toss"}}},
+		"shared not last": {{Name: "Shot", TieBreak: []string{models.TieBreakShared, models.TieBreakPenalty}}},
+		"penalty name":    {{Name: "Shot", Penalty: models.PenaltyRule{Catalog: []models.PenaltyItem{{Time: "2"}}}}},
+		"penalty twice":   {{Name: "Shot", Penalty: models.PenaltyRule{Catalog: []models.PenaltyItem{{Name: "Spill", Time: "2"}, {Name: "Spill", Time: "3"}}}}},
+		"penalty time":    {{Name: "Shot", Penalty: models.PenaltyRule{Catalog: []models.PenaltyItem{{Name: "Spill", Time: "two"}}}}},
+		"penalty no-op":   {{Name: "Shot", Penalty: models.PenaltyRule{Catalog: []models.PenaltyItem{{Name: "Spill"}}}}},
+	}
+	for name, defs := range cases {
+		_, err := NewDisciplineRegistry(defs)
+		assert.Error(t, err, name)
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Tries, ranking and limits
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestTriesEntitled_CustomDiscipline(t *testing.T) {
+	shot := models.DisciplineDef{Name: "Shot", DefaultTries: 2}
+	alice := newParticipant("Alice")
+
+	tries, ok := TriesEntitled(alice, shot)
+	assert.True(t, ok)
+	assert.Equal(t, 2, tries, "the default applies without a count of their own")
+
+	alice.Tries = map[string]int{"Shot": 4}
+	tries, _ = TriesEntitled(alice, shot)
+	assert.Equal(t, 4, tries)
+
+	alice.Bottle = ""
+	tries, _ = TriesEntitled(alice, builtin(models.DisciplineBottle))
+	assert.Equal(t, 3, tries, "an empty code digit falls back to the default")
+}
+
+func TestRankingTime(t *testing.T) {
+	r := models.Result{Time: "00:00:09.0000", BaseTime: "00:00:07.0000", AdditionalTime: "2"}
+	assert.Equal(t, int64(90000), RankingTime(models.DisciplineDef{}, r))
+	assert.Equal(t, int64(70000), RankingTime(models.DisciplineDef{Ranking: models.RankByBase}, r))
+
+	r.BaseTime = ""
+	assert.Equal(t, int64(90000), RankingTime(models.DisciplineDef{Ranking: models.RankByBase}, r), "without a base time the total counts")
+}
+
+func TestApplyDisciplineRules(t *testing.T) {
+	def := models.DisciplineDef{Name: "Shot", TimeLimit: "10", Penalty: models.PenaltyRule{Max: "2"}}
+
+	ok := models.Result{Time: "00:00:09.0000", BaseTime: "00:00:08.0000", AdditionalTime: "1", Status: models.StatusPass}
+	ApplyDisciplineRules(def, &ok)
+	assert.Equal(t, models.StatusPass, ok.Status)
+
+	slow := models.Result{Time: "00:00:12.0000", Status: models.StatusPass, Comment: "wobbly"}
+	ApplyDisciplineRules(def, &slow)
+	assert.Equal(t, models.StatusFail, slow.Status)
+	assert.Equal(t, "wobbly; over time limit 10", slow.Comment)
+
+	spilled := models.Result{Time: "00:00:09.0000", BaseTime: "00:00:06.0000", AdditionalTime: "3", Status: models.StatusPass}
+	ApplyDisciplineRules(def, &spilled)
+	assert.Equal(t, models.StatusDisqualified, spilled.Status)
+
+	dq := models.Result{Time: "00:00:12.0000", Status: models.StatusDisqualified}
+	ApplyDisciplineRules(def, &dq)
+	assert.Equal(t, models.StatusDisqualified, dq.Status, "only passed results are checked")
+	assert.Empty(t, dq.Comment)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Penalty catalog
+// ─────────────────────────────────────────────────────────────────────────────
+
+func catalogDiscipline() models.DisciplineDef {
+	return models.DisciplineDef{Name: "Shot", Penalty: models.PenaltyRule{Catalog: []models.PenaltyItem{
+		{Name: "Spill", Time: "2", DQAfter: 3},
+		{Name: "Foam left", Time: "0.5"},
+		{Name: "Early start", DQAfter: 1},
+	}}}
+}
+
+func TestGivePenalty(t *testing.T) {
+	catalog := catalogDiscipline().Penalty.Catalog
+
+	penalties, additional := GivePenalty(catalog[0], nil, "")
+	assert.Equal(t, "00:00:02.0000", additional)
+	penalties, additional = GivePenalty(catalog[1], penalties, additional)
+	assert.Equal(t, "00:00:02.5000", additional, "penalties add up")
+	penalties, additional = GivePenalty(catalog[2], penalties, additional)
+	assert.Equal(t, "00:00:02.5000", additional, "a DQ penalty adds no time")
+
+	assert.Equal(t, []models.Penalty{
+		{Name: "Spill", Time: "00:00:02.0000"}, {Name: "Foam left", Time: "00:00:00.5000"}, {Name: "Early start"},
+	}, penalties)
+	assert.Equal(t, "Spill +00:00:02.0000, Foam left +00:00:00.5000, Early start", PenaltySummary(penalties))
+
+	_, additional = GivePenalty(catalog[1], nil, "1")
+	assert.Equal(t, "00:00:01.5000", additional, "time entered by hand is kept")
+}
+
+func TestAdditionalTimeWith(t *testing.T) {
+	spill := models.Penalty{Name: "Spill", Time: "00:00:02.0000"}
+	early := models.Penalty{Name: "Early start"}
+
+	assert.Equal(t, "00:00:03.0000", AdditionalTimeWith([]models.Penalty{spill, early}, "1"))
+	assert.Equal(t, "00:00:02.0000", AdditionalTimeWith([]models.Penalty{spill}, ""), "no extra time")
+
+	r := models.Result{AdditionalTime: "00:00:03.0000", Penalties: []models.Penalty{spill, early}}
+	assert.Equal(t, "00:00:01.0000", ExtraTime(r), "the time given besides the penalties")
+	r.AdditionalTime = "00:00:02.0000"
+	assert.Equal(t, "", ExtraTime(r))
+	assert.Equal(t, "0:03.5", ExtraTime(models.Result{AdditionalTime: "0:03.5"}), "without penalties it is kept as entered")
+}
+
+func TestEscalatedPenalty(t *testing.T) {
+	def := catalogDiscipline()
+	spill := models.Penalty{Name: "Spill", Time: "00:00:02.0000"}
+
+	_, ok := EscalatedPenalty(def, []models.Penalty{spill, spill})
+	assert.False(t, ok)
+	item, ok := EscalatedPenalty(def, []models.Penalty{spill, spill, spill})
+	assert.True(t, ok, "the third spill disqualifies")
+	assert.Equal(t, "Spill", item.Name)
+
+	r := models.Result{Time: "00:00:09.0000", Status: models.StatusPass, Penalties: []models.Penalty{{Name: "Early start"}}}
+	ApplyDisciplineRules(def, &r)
+	assert.Equal(t, models.StatusDisqualified, r.Status)
+	assert.Equal(t, "Early start", r.Comment)
+
+	r.Status = models.StatusPass
+	ApplyDisciplineRules(def, &r)
+	assert.Equal(t, "Early start", r.Comment, "a reason already in the comment is not added again")
+}
@@ -0,0 +1,243 @@
+package data
+
+import (
+	"fmt"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// DefaultHistoryLimit is the number of steps a History keeps for undo.
+const DefaultHistoryLimit = 50
+
+// Command is one reversible step, e.g. recording a result. Do applies it and
+// Undo takes it back; redoing a step calls Do again.
+type Command interface {
+	Do() error
+	Undo() error
+	Label() string
+}
+
+// History is a multi-level undo/redo stack of commands. It belongs to a single
+// window and is not safe for concurrent use.
+type History struct {
+	limit  int
+	done   []Command
+	undone []Command
+}
+
+// NewHistory creates an empty history keeping at most limit steps for undo
+// (DefaultHistoryLimit if limit is not positive).
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &History{limit: limit}
+}
+
+// Do runs c and records it for undo. A new step discards everything that
+// could have been redone. Nothing is recorded if c fails.
+func (h *History) Do(c Command) error {
+	if err := c.Do(); err != nil {
+		return err
+	}
+	h.done = append(h.done, c)
+	if len(h.done) > h.limit {
+		h.done = h.done[len(h.done)-h.limit:]
+	}
+	h.undone = nil
+	return nil
+}
+
+// Undo takes back the most recent step and returns it. If the step cannot be
+// undone it stays on the stack.
+func (h *History) Undo() (Command, error) {
+	if len(h.done) == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	c := h.done[len(h.done)-1]
+	if err := c.Undo(); err != nil {
+		return c, fmt.Errorf("error undoing %s: %w", c.Label(), err)
+	}
+	h.done = h.done[:len(h.done)-1]
+	h.undone = append(h.undone, c)
+	return c, nil
+}
+
+// Redo runs the most recently undone step again and returns it.
+func (h *History) Redo() (Command, error) {
+	if len(h.undone) == 0 {
+		return nil, fmt.Errorf("nothing to redo")
+	}
+	c := h.undone[len(h.undone)-1]
+	if err := c.Do(); err != nil {
+		return c, fmt.Errorf("error redoing %s: %w", c.Label(), err)
+	}
+	h.undone = h.undone[:len(h.undone)-1]
+	h.done = append(h.done, c)
+	return c, nil
+}
+
+// CanUndo reports whether there is a step to undo.
+func (h *History) CanUndo() bool {
+	return len(h.done) > 0
+}
+
+// CanRedo reports whether there is a step to redo.
+func (h *History) CanRedo() bool {
+	return len(h.undone) > 0
+}
+
+// Clear forgets every step, e.g. after the contest data was reloaded.
+func (h *History) Clear() {
+	h.done = nil
+	h.undone = nil
+}
+
+// RecordResult adds a result and saves the results. Undoing it retracts the
+// result again; redoing it records the same result under the same ID. If the
+// results cannot be saved, the result is taken back so nothing is recorded.
+type RecordResult struct {
+	Results *ResultManager
+	Result  models.Result
+}
+
+// Do records the result.
+func (c *RecordResult) Do() error {
+	if c.Result.ID == "" {
+		c.Result.ID = utils.NewID()
+	}
+	if err := c.Results.AddResult(c.Result); err != nil {
+		return fmt.Errorf("error adding result: %w", err)
+	}
+	if err := c.Results.SaveResults(); err != nil {
+		_ = c.Results.RetractResult(c.Result.ID)
+		return fmt.Errorf("error saving results: %w", err)
+	}
+	return nil
+}
+
+// Undo retracts the result.
+func (c *RecordResult) Undo() error {
+	if err := c.Results.RetractResult(c.Result.ID); err != nil {
+		return err
+	}
+	if err := c.Results.SaveResults(); err != nil {
+		return fmt.Errorf("error saving results: %w", err)
+	}
+	return nil
+}
+
+// Label describes the step for the operator.
+func (c *RecordResult) Label() string {
+	return fmt.Sprintf("%s's %s result (%s)", c.Result.Name, c.Result.Discipline, c.Result.Status)
+}
+
+// RecordResults adds the results of a heat run on several lanes and saves
+// the results once. Undoing it retracts all of them; redoing it records them
+// again under the same IDs. If the results cannot be saved, the heat is taken
+// back so nothing is recorded.
+type RecordResults struct {
+	Results *ResultManager
+	Heat    []models.Result
+}
+
+// Do records the results.
+func (c *RecordResults) Do() error {
+	if err := c.Results.AddResults(c.Heat); err != nil {
+		return fmt.Errorf("error adding results: %w", err)
+	}
+	if err := c.Results.SaveResults(); err != nil {
+		for i := len(c.Heat) - 1; i >= 0; i-- {
+			_ = c.Results.RetractResult(c.Heat[i].ID)
+		}
+		return fmt.Errorf("error saving results: %w", err)
+	}
+	return nil
+}
+
+// Undo retracts the results, last first.
+func (c *RecordResults) Undo() error {
+	for i := len(c.Heat) - 1; i >= 0; i-- {
+		if err := c.Results.RetractResult(c.Heat[i].ID); err != nil {
+			return err
+		}
+	}
+	if err := c.Results.SaveResults(); err != nil {
+		return fmt.Errorf("error saving results: %w", err)
+	}
+	return nil
+}
+
+// Label describes the step for the operator.
+func (c *RecordResults) Label() string {
+	if len(c.Heat) == 0 {
+		return "an empty heat"
+	}
+	return fmt.Sprintf("the %s heat of %d lanes", c.Heat[0].Discipline, len(c.Heat))
+}
+
+// FuncCommand turns a pair of functions into a Command, e.g. for window state
+// that changes together with the data.
+type FuncCommand struct {
+	Name     string
+	DoFunc   func() error
+	UndoFunc func() error
+}
+
+// Do calls DoFunc.
+func (c *FuncCommand) Do() error {
+	return c.DoFunc()
+}
+
+// Undo calls UndoFunc.
+func (c *FuncCommand) Undo() error {
+	return c.UndoFunc()
+}
+
+// Label returns Name.
+func (c *FuncCommand) Label() string {
+	return c.Name
+}
+
+// Steps runs several commands as a single step: in order on Do and in reverse
+// order on Undo. If one of them fails, the ones already run are taken back so
+// the step happens completely or not at all.
+type Steps struct {
+	Name     string
+	Commands []Command
+}
+
+// Do runs the commands in order.
+func (s *Steps) Do() error {
+	for i, c := range s.Commands {
+		if err := c.Do(); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				_ = s.Commands[j].Undo()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Undo takes the commands back in reverse order.
+func (s *Steps) Undo() error {
+	for i := len(s.Commands) - 1; i >= 0; i-- {
+		if err := s.Commands[i].Undo(); err != nil {
+			for j := i + 1; j < len(s.Commands); j++ {
+				_ = s.Commands[j].Do()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Label returns Name, or the label of the first command if Name is empty.
+func (s *Steps) Label() string {
+	if s.Name == "" && len(s.Commands) > 0 {
+		return s.Commands[0].Label()
+	}
+	return s.Name
+}
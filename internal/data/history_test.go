@@ -0,0 +1,253 @@
+package data
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// counterCommand adds n to *value and subtracts it again on undo.
+func counterCommand(value *int, n int) *FuncCommand {
+	return &FuncCommand{
+		Name:     "add",
+		DoFunc:   func() error { *value += n; return nil },
+		UndoFunc: func() error { *value -= n; return nil },
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// History
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestHistory_UndoRedoSeveralSteps(t *testing.T) {
+	value := 0
+	h := NewHistory(0)
+	require.NoError(t, h.Do(counterCommand(&value, 1)))
+	require.NoError(t, h.Do(counterCommand(&value, 10)))
+	require.NoError(t, h.Do(counterCommand(&value, 100)))
+	assert.Equal(t, 111, value)
+
+	_, err := h.Undo()
+	require.NoError(t, err)
+	_, err = h.Undo()
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+	assert.True(t, h.CanRedo())
+
+	_, err = h.Redo()
+	require.NoError(t, err)
+	assert.Equal(t, 11, value)
+
+	_, err = h.Undo()
+	require.NoError(t, err)
+	_, err = h.Undo()
+	require.NoError(t, err)
+	assert.Equal(t, 0, value)
+	assert.False(t, h.CanUndo())
+
+	_, err = h.Undo()
+	assert.Error(t, err, "nothing left to undo")
+}
+
+func TestHistory_NewStepDiscardsRedo(t *testing.T) {
+	value := 0
+	h := NewHistory(0)
+	require.NoError(t, h.Do(counterCommand(&value, 1)))
+	_, err := h.Undo()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Do(counterCommand(&value, 5)))
+	assert.False(t, h.CanRedo())
+	_, err = h.Redo()
+	assert.Error(t, err)
+	assert.Equal(t, 5, value)
+}
+
+func TestHistory_KeepsAtMostLimitSteps(t *testing.T) {
+	value := 0
+	h := NewHistory(2)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, h.Do(counterCommand(&value, 1)))
+	}
+
+	for h.CanUndo() {
+		_, err := h.Undo()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, value, "the oldest step can no longer be undone")
+}
+
+func TestHistory_FailedStepsAreNotRecorded(t *testing.T) {
+	h := NewHistory(0)
+	fail := &FuncCommand{
+		Name:     "fail",
+		DoFunc:   func() error { return errors.New("boom") },
+		UndoFunc: func() error { return nil },
+	}
+	assert.Error(t, h.Do(fail))
+	assert.False(t, h.CanUndo())
+
+	stuck := &FuncCommand{
+		Name:     "stuck",
+		DoFunc:   func() error { return nil },
+		UndoFunc: func() error { return errors.New("boom") },
+	}
+	require.NoError(t, h.Do(stuck))
+	_, err := h.Undo()
+	assert.Error(t, err)
+	assert.True(t, h.CanUndo(), "a step that could not be undone stays on the stack")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Steps
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestSteps_UndoInReverseOrder(t *testing.T) {
+	var order []string
+	step := func(name string) *FuncCommand {
+		return &FuncCommand{
+			Name:     name,
+			DoFunc:   func() error { order = append(order, "do "+name); return nil },
+			UndoFunc: func() error { order = append(order, "undo "+name); return nil },
+		}
+	}
+	s := &Steps{Commands: []Command{step("a"), step("b")}}
+	assert.Equal(t, "a", s.Label())
+
+	require.NoError(t, s.Do())
+	require.NoError(t, s.Undo())
+	assert.Equal(t, []string{"do a", "do b", "undo b", "undo a"}, order)
+}
+
+func TestSteps_RollsBackWhenAStepFails(t *testing.T) {
+	value := 0
+	s := &Steps{Name: "both", Commands: []Command{
+		counterCommand(&value, 1),
+		&FuncCommand{DoFunc: func() error { return errors.New("boom") }, UndoFunc: func() error { return nil }},
+	}}
+	assert.Error(t, s.Do())
+	assert.Equal(t, 0, value)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RecordResult
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestRecordResult_UndoRestoresResultAndTries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	alice := newParticipant("Alice")
+	alice.ID = "p1"
+
+	h := NewHistory(0)
+	record := &RecordResult{Results: rm, Result: models.Result{ParticipantID: "p1", Name: "Alice",
+		Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass}}
+	require.NoError(t, h.Do(record))
+	require.Len(t, rm.GetResults(), 1)
+	id := rm.GetResults()[0].ID
+	assert.Equal(t, 2, rm.RemainingTries(alice, builtin(models.DisciplineBottle)))
+
+	_, err := h.Undo()
+	require.NoError(t, err)
+	assert.Empty(t, rm.GetResults())
+	assert.Equal(t, 3, rm.RemainingTries(alice, builtin(models.DisciplineBottle)))
+
+	_, err = h.Redo()
+	require.NoError(t, err)
+	require.Len(t, rm.GetResults(), 1)
+	assert.Equal(t, id, rm.GetResults()[0].ID, "a redo records the same result")
+
+	// The journal replays to the same state
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	assert.Equal(t, rm.GetResults(), rm2.GetResults())
+
+	entries, err := rm.JournalEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, JournalRetract, entries[1].Action)
+}
+
+func TestRecordResult_FailedSaveRecordsNothing(t *testing.T) {
+	// Without a file path the result is added but cannot be saved
+	rm := NewResultManager()
+	h := NewHistory(0)
+	record := &RecordResult{Results: rm, Result: models.Result{ParticipantID: "p1", Name: "Alice",
+		Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass}}
+
+	assert.Error(t, h.Do(record))
+	assert.Empty(t, rm.GetResults(), "the result is taken back")
+	assert.False(t, h.CanUndo(), "the step is not recorded")
+}
+
+func TestResultManager_RetractResult_NotFound(t *testing.T) {
+	rm := NewResultManager()
+	assert.Error(t, rm.RetractResult("missing"))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RecordResults
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestRecordResults_HeatIsOneStep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+
+	h := NewHistory(0)
+	heat := &RecordResults{Results: rm, Heat: []models.Result{
+		{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass},
+		{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineBottle, Status: models.StatusDisqualified},
+	}}
+	require.NoError(t, h.Do(heat))
+	require.Len(t, rm.GetResults(), 2)
+	assert.Equal(t, "00:00:05.0000", rm.GetResults()[0].Time)
+	assert.Equal(t, "NaN", rm.GetResults()[1].Time)
+	ids := []string{rm.GetResults()[0].ID, rm.GetResults()[1].ID}
+
+	_, err := h.Undo()
+	require.NoError(t, err)
+	assert.Empty(t, rm.GetResults(), "one undo takes back the whole heat")
+
+	_, err = h.Redo()
+	require.NoError(t, err)
+	assert.Equal(t, ids, []string{rm.GetResults()[0].ID, rm.GetResults()[1].ID})
+
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	assert.Equal(t, rm.GetResults(), rm2.GetResults())
+}
+
+func TestRecordResults_FailedSaveRecordsNothing(t *testing.T) {
+	rm := NewResultManager()
+	h := NewHistory(0)
+	heat := &RecordResults{Results: rm, Heat: []models.Result{
+		{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass},
+		{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineBottle, BaseTime: "00:00:06.0000", Status: models.StatusPass},
+	}}
+
+	assert.Error(t, h.Do(heat))
+	assert.Empty(t, rm.GetResults(), "the whole heat is taken back")
+	assert.False(t, h.CanUndo())
+}
+
+func TestResultManager_AddResults_AllOrNothing(t *testing.T) {
+	rm := NewResultManager()
+	rm.SetFilePath(filepath.Join(t.TempDir(), "results.json"))
+	err := rm.AddResults([]models.Result{
+		{Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "5", Status: models.StatusPass},
+		{Name: "", Discipline: models.DisciplineBottle, BaseTime: "6", Status: models.StatusPass},
+	})
+	assert.Error(t, err)
+	assert.Empty(t, rm.GetResults())
+}
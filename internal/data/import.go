@@ -0,0 +1,346 @@
+package data
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// Participant fields a spreadsheet column can be mapped to when importing.
+// A file gives the tries either as one "322" code column or as one column per
+// discipline; without either, the tries from the configuration are used.
+const (
+	ImportName        = "name"
+	ImportProgram     = "program"
+	ImportTeam        = "team"
+	ImportCategories  = "categories"
+	ImportCode        = "code"
+	ImportBottle      = "bottle"
+	ImportHalfTankard = "half_tankard"
+	ImportFullTankard = "full_tankard"
+)
+
+// ImportFields lists the mappable fields in the order they are offered.
+var ImportFields = []string{ImportName, ImportProgram, ImportTeam, ImportCategories, ImportCode, ImportBottle, ImportHalfTankard, ImportFullTankard}
+
+// importHeaders are the column headings recognised for each field, lower
+// case, in English and Swedish.
+var importHeaders = map[string][]string{
+	ImportName:        {"name", "namn", "full name", "fullständigt namn", "participant", "deltagare"},
+	ImportProgram:     {"program", "programme", "sektion", "section", "utbildning"},
+	ImportTeam:        {"team", "lag"},
+	ImportCategories:  {"categories", "category", "kategorier", "kategori"},
+	ImportCode:        {"code", "kod", "disciplines", "discipliner", "tries", "försök"},
+	ImportBottle:      {"bottle", "flaska"},
+	ImportHalfTankard: {"half tankard", "half_tankard", "halv sejdel", "halvsejdel"},
+	ImportFullTankard: {"full tankard", "full_tankard", "hel sejdel", "helsejdel"},
+}
+
+// ImportTable is a CSV/TSV file read for import: its first row as the header
+// and the rows below it, each with its line number in the file.
+type ImportTable struct {
+	Delimiter rune
+	Encoding  string
+	Header    []string
+	Rows      [][]string
+	Lines     []int
+}
+
+// ReadImportFile reads a CSV or TSV file for import.
+func ReadImportFile(path string) (ImportTable, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ImportTable{}, fmt.Errorf("error reading file %s: %w", path, err)
+	}
+	table, err := ParseImportTable(raw)
+	if err != nil {
+		return table, fmt.Errorf("error reading file %s: %w", path, err)
+	}
+	return table, nil
+}
+
+// ParseImportTable decodes raw spreadsheet text. The encoding is detected
+// from a byte order mark, otherwise UTF-8 if the text is valid UTF-8 and
+// Windows-1252 (what Excel writes for Swedish text) if not. The delimiter is
+// whichever of tab, semicolon and comma occurs most often in the header.
+func ParseImportTable(raw []byte) (ImportTable, error) {
+	text, encoding, err := decodeImportText(raw)
+	if err != nil {
+		return ImportTable{}, err
+	}
+	table := ImportTable{Encoding: encoding, Delimiter: detectDelimiter(text)}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = table.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return table, err
+		}
+		line, _ := reader.FieldPos(0)
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if table.Header == nil {
+			table.Header = record
+			continue
+		}
+		table.Rows = append(table.Rows, record)
+		table.Lines = append(table.Lines, line)
+	}
+	if table.Header == nil {
+		return table, fmt.Errorf("the file is empty")
+	}
+	return table, nil
+}
+
+// decodeImportText converts raw to a string and names the encoding found.
+func decodeImportText(raw []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(raw, []byte{0xEF, 0xBB, 0xBF}):
+		return string(raw[3:]), "UTF-8", nil
+	case bytes.HasPrefix(raw, []byte{0xFF, 0xFE}), bytes.HasPrefix(raw, []byte{0xFE, 0xFF}):
+		decoded, err := unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM).NewDecoder().Bytes(raw)
+		if err != nil {
+			return "", "", fmt.Errorf("error decoding UTF-16: %w", err)
+		}
+		return string(decoded), "UTF-16", nil
+	case utf8.Valid(raw):
+		return string(raw), "UTF-8", nil
+	}
+	decoded, err := charmap.Windows1252.NewDecoder().Bytes(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("error decoding Windows-1252: %w", err)
+	}
+	return string(decoded), "Windows-1252", nil
+}
+
+// detectDelimiter picks the delimiter that occurs most often in the first
+// record of text. Delimiters inside quoted fields, such as the comma in
+// "Name, full", are not counted.
+func detectDelimiter(text string) rune {
+	counts := make(map[rune]int)
+	quoted := false
+	for _, r := range text {
+		if r == '"' {
+			// An escaped quote ("") toggles twice and leaves the state as it was
+			quoted = !quoted
+			continue
+		}
+		if quoted {
+			continue
+		}
+		if r == '\n' {
+			break
+		}
+		counts[r]++
+	}
+	delimiter, best := ',', 0
+	for _, d := range []rune{'\t', ';', ','} {
+		if n := counts[d]; n > best {
+			delimiter, best = d, n
+		}
+	}
+	return delimiter
+}
+
+// ColumnMapping maps import fields to column indexes in an ImportTable.
+// Fields that are not mapped are missing from the map.
+type ColumnMapping map[string]int
+
+// GuessMapping maps the columns whose heading names a field.
+func GuessMapping(header []string) ColumnMapping {
+	mapping := make(ColumnMapping)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		for _, field := range ImportFields {
+			if _, taken := mapping[field]; taken {
+				continue
+			}
+			for _, known := range importHeaders[field] {
+				if h == known {
+					mapping[field] = i
+				}
+			}
+		}
+	}
+	return mapping
+}
+
+// ImportRow is the outcome of validating one row of the file. Rows without
+// problems are imported.
+type ImportRow struct {
+	Line        int
+	Participant models.Participant
+	Problems    []string
+}
+
+// OK reports whether the row can be imported.
+func (r ImportRow) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// ImportReport lists every non-empty row of an import with its problems.
+type ImportReport struct {
+	Rows []ImportRow
+}
+
+// Accepted returns the participants of the rows without problems.
+func (r ImportReport) Accepted() []models.Participant {
+	var accepted []models.Participant
+	for _, row := range r.Rows {
+		if row.OK() {
+			accepted = append(accepted, row.Participant)
+		}
+	}
+	return accepted
+}
+
+// Rejected returns the number of rows with problems.
+func (r ImportReport) Rejected() int {
+	return len(r.Rows) - len(r.Accepted())
+}
+
+// PlanImport validates every row of table under mapping without changing
+// anything. A row is rejected if its name is missing, already registered (in
+// existing or further up the file) or any field is longer than
+// config.MaxStringLength, or if its tries are not a valid code.
+func PlanImport(table ImportTable, mapping ColumnMapping, existing []models.Participant) (ImportReport, error) {
+	if _, ok := mapping[ImportName]; !ok {
+		return ImportReport{}, fmt.Errorf("map a column to the participant name")
+	}
+
+	seen := make(map[string]string, len(existing))
+	for _, p := range existing {
+		seen[nameKey(p.Name)] = "an existing participant"
+	}
+
+	var report ImportReport
+	for i, record := range table.Rows {
+		cell := func(field string) string {
+			if col, ok := mapping[field]; ok && col >= 0 && col < len(record) {
+				return record[col]
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		row := ImportRow{Line: table.Lines[i]}
+		p := models.Participant{
+			Name:       cell(ImportName),
+			Program:    cell(ImportProgram),
+			Team:       cell(ImportTeam),
+			Categories: ParseCategories(cell(ImportCategories)),
+		}
+		if p.Program == "" {
+			p.Program = "N/A"
+		}
+		if p.Team == "" {
+			p.Team = "N/A"
+		}
+
+		switch {
+		case p.Name == "":
+			row.Problems = append(row.Problems, "no name")
+		case seen[nameKey(p.Name)] != "":
+			row.Problems = append(row.Problems, "duplicate of "+seen[nameKey(p.Name)])
+		default:
+			seen[nameKey(p.Name)] = fmt.Sprintf("line %d", row.Line)
+		}
+		for _, f := range []struct{ label, value string }{{"name", p.Name}, {"program", p.Program}, {"team", p.Team}} {
+			if utf8.RuneCountInString(f.value) > config.MaxStringLength {
+				row.Problems = append(row.Problems, fmt.Sprintf("%s longer than %d characters", f.label, config.MaxStringLength))
+			}
+		}
+
+		code, err := importCode(mapping, cell)
+		if err != nil {
+			row.Problems = append(row.Problems, err.Error())
+		} else {
+			p.Bottle, p.HalfTankard, p.FullTankard = code[0:1], code[1:2], code[2:3]
+		}
+
+		row.Participant = p
+		report.Rows = append(report.Rows, row)
+	}
+	return report, nil
+}
+
+// importCode returns the "322" code of a row: the code column if mapped,
+// otherwise the per-discipline columns (empty meaning 0), otherwise the tries
+// from the configuration.
+func importCode(mapping ColumnMapping, cell func(string) string) (string, error) {
+	if _, ok := mapping[ImportCode]; ok {
+		code := cell(ImportCode)
+		if len(code) != 3 || strings.Trim(code, "0123456789") != "" {
+			return "", fmt.Errorf("invalid code %q: must be 3 digits like 322", code)
+		}
+		return code, nil
+	}
+
+	tries := []int{config.BottleTries, config.HalfTankardTries, config.FullTankardTries}
+	for i, field := range []string{ImportBottle, ImportHalfTankard, ImportFullTankard} {
+		if _, ok := mapping[field]; !ok {
+			continue
+		}
+		value := cell(field)
+		if value == "" {
+			tries[i] = 0
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > 9 {
+			return "", fmt.Errorf("invalid %s tries %q: must be a number from 0 to 9", strings.ReplaceAll(field, "_", " "), value)
+		}
+		tries[i] = n
+	}
+	return fmt.Sprintf("%d%d%d", tries[0], tries[1], tries[2]), nil
+}
+
+// nameKey is how participant names are compared for duplicates.
+func nameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// ImportParticipants adds the accepted rows of report to the list, keeping
+// the participants already in it, and returns how many were added. Call
+// SaveParticipants to persist. Nothing is imported while the setup is locked.
+func (pm *ParticipantManager) ImportParticipants(report ImportReport) (int, error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if err := pm.checkSetup(); err != nil {
+		return 0, err
+	}
+
+	accepted := report.Accepted()
+	for _, p := range accepted {
+		if err := validateParticipant(p); err != nil {
+			return 0, fmt.Errorf("error importing %s: %w", p.Name, err)
+		}
+	}
+	for _, p := range accepted {
+		p.ID = utils.NewID()
+		pm.participants = append(pm.participants, p)
+	}
+	return len(accepted), nil
+}
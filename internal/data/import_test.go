@@ -0,0 +1,204 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ParseImportTable
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestParseImportTable_DetectsDelimiter(t *testing.T) {
+	for name, tc := range map[string]struct {
+		raw       string
+		delimiter rune
+	}{
+		"comma":     {"Name,Team\nAlice,Red\n", ','},
+		"semicolon": {"Name;Team\n\"Smith, Alice\";Red\n", ';'},
+		"tab":       {"Name\tTeam\nAlice; Bob\tRed\n", '\t'},
+	} {
+		t.Run(name, func(t *testing.T) {
+			table, err := ParseImportTable([]byte(tc.raw))
+			require.NoError(t, err)
+			assert.Equal(t, tc.delimiter, table.Delimiter)
+			assert.Equal(t, []string{"Name", "Team"}, table.Header)
+			require.Len(t, table.Rows, 1)
+			assert.Len(t, table.Rows[0], 2)
+		})
+	}
+}
+
+func TestParseImportTable_IgnoresDelimitersInQuotes(t *testing.T) {
+	table, err := ParseImportTable([]byte("\"Name, full\";Program;Team\n\"Smith, Alice\";D;Red\n"))
+	require.NoError(t, err)
+	assert.Equal(t, ';', table.Delimiter)
+	assert.Equal(t, []string{"Name, full", "Program", "Team"}, table.Header)
+	require.Len(t, table.Rows, 1)
+	assert.Equal(t, []string{"Smith, Alice", "D", "Red"}, table.Rows[0])
+}
+
+func TestParseImportTable_DetectsEncoding(t *testing.T) {
+	// "Åsa Öberg" in Windows-1252, as Excel saves a CSV on a Swedish system
+	latin := []byte("Namn;Lag\n\xc5sa \xd6berg;R\xf6d\n")
+	table, err := ParseImportTable(latin)
+	require.NoError(t, err)
+	assert.Equal(t, "Windows-1252", table.Encoding)
+	assert.Equal(t, []string{"Åsa Öberg", "Röd"}, table.Rows[0])
+
+	bom := append([]byte{0xEF, 0xBB, 0xBF}, []byte("Namn;Lag\nÅsa Öberg;Röd\n")...)
+	table, err = ParseImportTable(bom)
+	require.NoError(t, err)
+	assert.Equal(t, "UTF-8", table.Encoding)
+	assert.Equal(t, "Namn", table.Header[0], "the byte order mark is not part of the header")
+	assert.Equal(t, []string{"Åsa Öberg", "Röd"}, table.Rows[0])
+
+	// UTF-16 little endian with BOM, as "Unicode text" from Excel
+	utf16 := []byte{0xFF, 0xFE}
+	for _, r := range "Namn\tLag\nÅsa\tRöd\n" {
+		utf16 = append(utf16, byte(r), byte(r>>8))
+	}
+	table, err = ParseImportTable(utf16)
+	require.NoError(t, err)
+	assert.Equal(t, "UTF-16", table.Encoding)
+	assert.Equal(t, []string{"Åsa", "Röd"}, table.Rows[0])
+}
+
+func TestParseImportTable_Empty(t *testing.T) {
+	_, err := ParseImportTable(nil)
+	assert.Error(t, err)
+}
+
+func TestGuessMapping(t *testing.T) {
+	mapping := GuessMapping([]string{"Lag", "Namn", "Comment", "Kod"})
+	assert.Equal(t, ColumnMapping{ImportTeam: 0, ImportName: 1, ImportCode: 3}, mapping)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// PlanImport
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestPlanImport_ValidationReport(t *testing.T) {
+	raw := strings.Join([]string{
+		"Name,Program,Team,Code",
+		"Alice,D,Red,322",
+		"bob ,,,211",
+		",,,",
+		"ALICE,D,Blue,322",
+		"Carol,E,Red,3x2",
+		"Existing,E,Red,322",
+		strings.Repeat("x", config.MaxStringLength+1) + ",E,Red,322",
+		",E,Red,322",
+	}, "\n")
+	table, err := ParseImportTable([]byte(raw))
+	require.NoError(t, err)
+
+	report, err := PlanImport(table, GuessMapping(table.Header), []models.Participant{newParticipant("existing")})
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 7, "the blank row is skipped")
+
+	alice := report.Rows[0]
+	assert.True(t, alice.OK())
+	assert.Equal(t, 2, alice.Line)
+	assert.Equal(t, models.Participant{Name: "Alice", Program: "D", Team: "Red", Bottle: "3", HalfTankard: "2", FullTankard: "2"}, alice.Participant)
+
+	bob := report.Rows[1]
+	assert.True(t, bob.OK())
+	assert.Equal(t, "N/A", bob.Participant.Program)
+	assert.Equal(t, "1", bob.Participant.FullTankard)
+
+	assert.Equal(t, []string{"duplicate of line 2"}, report.Rows[2].Problems)
+	assert.Equal(t, 5, report.Rows[2].Line)
+	assert.Contains(t, report.Rows[3].Problems[0], "invalid code")
+	assert.Equal(t, []string{"duplicate of an existing participant"}, report.Rows[4].Problems)
+	assert.Contains(t, report.Rows[5].Problems[0], "name longer than")
+	assert.Equal(t, []string{"no name"}, report.Rows[6].Problems)
+
+	assert.Len(t, report.Accepted(), 2)
+	assert.Equal(t, 5, report.Rejected())
+}
+
+func TestPlanImport_LengthIsCountedInCharacters(t *testing.T) {
+	name := strings.Repeat("ö", config.MaxStringLength)
+	table, err := ParseImportTable([]byte("Name,Program,Team\n" + name + ",E,Röd\n"))
+	require.NoError(t, err)
+
+	report, err := PlanImport(table, GuessMapping(table.Header), nil)
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 1)
+	assert.True(t, report.Rows[0].OK(), "a name of the maximum length in characters is accepted: %v", report.Rows[0].Problems)
+
+	pm := NewParticipantManager()
+	assert.NoError(t, pm.AddParticipant(report.Rows[0].Participant), "and can be added")
+}
+
+func TestPlanImport_TriesColumns(t *testing.T) {
+	table, err := ParseImportTable([]byte("Name\tBottle\tFull tankard\nAlice\t1\t\nBob\tmany\t1\n"))
+	require.NoError(t, err)
+
+	report, err := PlanImport(table, GuessMapping(table.Header), nil)
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 2)
+
+	// Half tankard is not mapped and keeps the configured tries; an empty
+	// cell means no tries
+	alice := report.Rows[0].Participant
+	assert.Equal(t, "1", alice.Bottle)
+	assert.Equal(t, "2", alice.HalfTankard)
+	assert.Equal(t, "0", alice.FullTankard)
+
+	assert.Contains(t, report.Rows[1].Problems[0], "invalid bottle tries")
+}
+
+func TestPlanImport_Categories(t *testing.T) {
+	table, err := ParseImportTable([]byte("Namn;Kategori\nAlice;Women, Alumni\nBob;\n"))
+	require.NoError(t, err)
+
+	report, err := PlanImport(table, GuessMapping(table.Header), nil)
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, []string{"Women", "Alumni"}, report.Rows[0].Participant.Categories)
+	assert.Empty(t, report.Rows[1].Participant.Categories)
+}
+
+func TestPlanImport_RequiresName(t *testing.T) {
+	table, err := ParseImportTable([]byte("Team\nRed\n"))
+	require.NoError(t, err)
+	_, err = PlanImport(table, GuessMapping(table.Header), nil)
+	assert.Error(t, err)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ImportParticipants
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestParticipantManager_ImportParticipants_Merges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "participants.csv")
+	require.NoError(t, os.WriteFile(path, []byte("Namn;Kod\nAlice;322\nBob;32\n"), 0644))
+
+	pm := NewParticipantManager()
+	require.NoError(t, pm.AddParticipant(newParticipant("Zed")))
+
+	table, err := ReadImportFile(path)
+	require.NoError(t, err)
+	report, err := PlanImport(table, GuessMapping(table.Header), pm.GetParticipants())
+	require.NoError(t, err)
+
+	added, err := pm.ImportParticipants(report)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	participants := pm.GetParticipants()
+	require.Len(t, participants, 2, "the existing participant is kept")
+	assert.Equal(t, "Zed", participants[0].Name)
+	assert.Equal(t, "Alice", participants[1].Name)
+	assert.NotEmpty(t, participants[1].ID)
+}
@@ -0,0 +1,353 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+)
+
+// Journal actions. Every result mutation is written to the journal before it
+// is applied in memory, so results.json can always be rebuilt by replaying it.
+const (
+	JournalImport         = "import"          // row carried over from a pre-journal results.json
+	JournalAdd            = "add"             // new Pass (or other non-DQ) result
+	JournalDisqualify     = "dq"              // new Disqualified result
+	JournalAmend          = "amend"           // existing result replaced
+	JournalVoid           = "void"            // existing result withdrawn (kept, flagged as voided)
+	JournalDecrementTries = "decrement_tries" // participant try count reduced (before version 6 only)
+	JournalRestoreTries   = "restore_tries"   // try handed back after a void (before version 6 only)
+	JournalRename         = "rename"          // participant or team renamed; their results follow
+	JournalRetract        = "retract"         // result taken back by an undo (removed from the list)
+)
+
+// JournalEntry is a single line in the append-only journal file.
+type JournalEntry struct {
+	Seq           int            `json:"seq"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Station       string         `json:"station"`
+	Operator      string         `json:"operator"`
+	Action        string         `json:"action"`
+	Index         int            `json:"index"`
+	ResultID      string         `json:"result_id,omitempty"`
+	ParticipantID string         `json:"participant_id,omitempty"`
+	TeamID        string         `json:"team_id,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	Discipline    string         `json:"discipline,omitempty"`
+	Result        *models.Result `json:"result,omitempty"`
+	Remaining     string         `json:"remaining,omitempty"`
+	Reason        string         `json:"reason,omitempty"`
+}
+
+// Journal appends entries to a JSON-lines file in the contest folder.
+// Entries are never rewritten or removed.
+type Journal struct {
+	mu      sync.Mutex
+	path    string
+	lastSeq int
+	scanned bool
+}
+
+var (
+	journalsMu sync.Mutex
+	journals   = make(map[string]*Journal)
+)
+
+// JournalPathFor returns the journal file that belongs next to dataFile.
+func JournalPathFor(dataFile string) string {
+	return filepath.Join(filepath.Dir(dataFile), config.JournalFileName)
+}
+
+// OpenJournal returns the journal for path. Managers in different windows that
+// point at the same contest folder share one instance so sequence numbers stay
+// unique.
+func OpenJournal(path string) *Journal {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+
+	journalsMu.Lock()
+	defer journalsMu.Unlock()
+
+	if j, ok := journals[abs]; ok {
+		return j
+	}
+	j := &Journal{path: abs}
+	journals[abs] = j
+	return j
+}
+
+// Path returns the journal file path.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// Append stamps entry with the next sequence number, the current time and the
+// station/operator identity, writes it to disk and returns the stamped entry.
+func (j *Journal) Append(entry JournalEntry) (JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if !j.scanned {
+		entries, err := readJournal(j.path)
+		if err != nil {
+			return entry, err
+		}
+		if len(entries) > 0 {
+			j.lastSeq = entries[len(entries)-1].Seq
+		}
+		j.scanned = true
+	}
+
+	entry.Seq = j.lastSeq + 1
+	entry.Timestamp = time.Now()
+	entry.Station = stationID()
+	entry.Operator = operatorName()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, fmt.Errorf("error marshalling journal entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return entry, fmt.Errorf("error creating journal directory: %w", err)
+	}
+
+	f, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return entry, fmt.Errorf("error opening journal %s: %w", j.path, err)
+	}
+	defer f.Close()
+
+	if err := trimTornTail(f); err != nil {
+		return entry, fmt.Errorf("error repairing journal %s: %w", j.path, err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return entry, fmt.Errorf("error writing journal %s: %w", j.path, err)
+	}
+	if err := f.Sync(); err != nil {
+		return entry, fmt.Errorf("error syncing journal %s: %w", j.path, err)
+	}
+
+	j.lastSeq = entry.Seq
+	return entry, nil
+}
+
+// forget drops the cached sequence number so that the next Append rescans the
+// file, e.g. after another program has appended to it.
+func (j *Journal) forget() {
+	j.mu.Lock()
+	j.scanned = false
+	j.mu.Unlock()
+}
+
+// Entries returns every entry in the journal in the order it was written.
+func (j *Journal) Entries() ([]JournalEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return readJournal(j.path)
+}
+
+// readJournal parses the journal file. A missing file is an empty journal.
+func readJournal(path string) ([]JournalEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening journal %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	var torn error
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		// A bad line is only tolerated at the very end of the file
+		if torn != nil {
+			return entries, torn
+		}
+		var e JournalEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			torn = fmt.Errorf("error parsing journal %s line %d: %w", path, lineNo, err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("error reading journal %s: %w", path, err)
+	}
+	// A broken last line is an append that was cut off by a crash; the
+	// mutation it describes never reached results.json, so it is dropped.
+	return entries, nil
+}
+
+// trimTornTail removes a partial last line left behind by a crash mid-append,
+// so the next entry starts on a line of its own.
+func trimTornTail(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	data := make([]byte, info.Size())
+	if _, err := f.ReadAt(data, 0); err != nil {
+		return err
+	}
+	return f.Truncate(int64(bytes.LastIndexByte(data, '\n') + 1))
+}
+
+// ReplayResults rebuilds the result list by applying entries in order.
+func ReplayResults(entries []JournalEntry) ([]models.Result, error) {
+	results := make([]models.Result, 0)
+	var err error
+	for _, e := range entries {
+		results, err = applyJournalEntry(results, e)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// EntriesUntil returns the prefix of entries written at or before t.
+func EntriesUntil(entries []JournalEntry, t time.Time) []JournalEntry {
+	for i, e := range entries {
+		if e.Timestamp.After(t) {
+			return entries[:i]
+		}
+	}
+	return entries
+}
+
+// applyJournalEntry applies a single entry to results and returns the new slice.
+func applyJournalEntry(results []models.Result, e JournalEntry) ([]models.Result, error) {
+	switch e.Action {
+	case JournalImport, JournalAdd, JournalDisqualify:
+		if e.Result == nil {
+			return results, fmt.Errorf("journal entry %d (%s) has no result", e.Seq, e.Action)
+		}
+		return append(results, linkedResult(*e.Result)), nil
+	case JournalAmend:
+		if e.Result == nil {
+			return results, fmt.Errorf("journal entry %d (%s) has no result", e.Seq, e.Action)
+		}
+		i := journalTarget(results, e)
+		if i < 0 {
+			return results, fmt.Errorf("journal entry %d amends unknown result %s", e.Seq, e.target())
+		}
+		results[i] = linkedResult(*e.Result)
+		return results, nil
+	case JournalRename:
+		for i := range results {
+			r := &results[i]
+			if (e.TeamID != "" && r.TeamID == e.TeamID) || (e.TeamID == "" && !r.IsTeamResult() && r.ParticipantID == e.ParticipantID) {
+				r.Name = e.Name
+			}
+		}
+		return results, nil
+	case JournalRetract:
+		i := journalTarget(results, e)
+		if i < 0 {
+			return results, fmt.Errorf("journal entry %d retracts unknown result %s", e.Seq, e.target())
+		}
+		return append(results[:i], results[i+1:]...), nil
+	case JournalVoid:
+		i := journalTarget(results, e)
+		if i < 0 {
+			return results, fmt.Errorf("journal entry %d voids unknown result %s", e.Seq, e.target())
+		}
+		results[i].KeepOriginal()
+		results[i].Voided = true
+		results[i].EditReason = e.Reason
+		return results, nil
+	case JournalDecrementTries, JournalRestoreTries:
+		// Try counters of older contests – results are unaffected
+		return results, nil
+	default:
+		return results, fmt.Errorf("journal entry %d has unknown action %q", e.Seq, e.Action)
+	}
+}
+
+// linkedResult fills in the participant reference of a result journalled
+// before participant IDs existed. Team results refer to their team instead.
+func linkedResult(r models.Result) models.Result {
+	if r.ParticipantID == "" && !r.IsTeamResult() {
+		r.ParticipantID = LegacyParticipantID(r.Name)
+	}
+	return r
+}
+
+// journalTarget returns the index of the result an amend/void entry refers to.
+// Entries carry the stable result ID; Index is only used when it is missing.
+func journalTarget(results []models.Result, e JournalEntry) int {
+	if e.ResultID != "" {
+		for i := range results {
+			if results[i].ID == e.ResultID {
+				return i
+			}
+		}
+		return -1
+	}
+	if e.Index < 0 || e.Index >= len(results) {
+		return -1
+	}
+	return e.Index
+}
+
+// target describes the result an entry refers to, for error messages.
+func (e JournalEntry) target() string {
+	if e.ResultID != "" {
+		return e.ResultID
+	}
+	return fmt.Sprintf("#%d", e.Index)
+}
+
+// stationID identifies the machine writing journal entries.
+func stationID() string {
+	if config.Settings.StationID != "" {
+		return config.Settings.StationID
+	}
+	if host, err := os.Hostname(); err == nil {
+		return host
+	}
+	return "unknown"
+}
+
+// operatorName identifies the person at the station.
+func operatorName() string {
+	if config.Settings.OperatorName != "" {
+		return config.Settings.OperatorName
+	}
+	for _, key := range []string{"USERNAME", "USER"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return "unknown"
+}
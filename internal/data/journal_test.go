@@ -0,0 +1,224 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// withStation sets the station/operator identity for the duration of a test.
+func withStation(t *testing.T, station, operator string) {
+	t.Helper()
+	saved := config.Settings
+	config.Settings.StationID = station
+	config.Settings.OperatorName = operator
+	t.Cleanup(func() { config.Settings = saved })
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Journal – append / read
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestJournal_AppendStampsEntries(t *testing.T) {
+	withStation(t, "Station-1", "Judge Dredd")
+	j := OpenJournal(filepath.Join(t.TempDir(), config.JournalFileName))
+
+	first, err := j.Append(JournalEntry{Action: JournalAdd, Result: &models.Result{Name: "Alice"}})
+	require.NoError(t, err)
+	second, err := j.Append(JournalEntry{Action: JournalAdd, Result: &models.Result{Name: "Bob"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, first.Seq)
+	assert.Equal(t, 2, second.Seq)
+	assert.Equal(t, "Station-1", first.Station)
+	assert.Equal(t, "Judge Dredd", first.Operator)
+	assert.False(t, first.Timestamp.IsZero())
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Bob", entries[1].Result.Name)
+}
+
+func TestJournal_SequenceContinuesAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.JournalFileName)
+	_, err := OpenJournal(path).Append(JournalEntry{Action: JournalAdd, Result: &models.Result{Name: "Alice"}})
+	require.NoError(t, err)
+
+	// Simulate a restart: forget the cached instance
+	journalsMu.Lock()
+	delete(journals, path)
+	journalsMu.Unlock()
+
+	e, err := OpenJournal(path).Append(JournalEntry{Action: JournalAdd, Result: &models.Result{Name: "Bob"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, e.Seq)
+}
+
+func TestJournal_MissingFileIsEmpty(t *testing.T) {
+	entries, err := OpenJournal(filepath.Join(t.TempDir(), "none.jsonl")).Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ResultManager – journal-backed persistence
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestResultManager_MutationsAreJournalled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Bob", Discipline: "Bottle", Status: models.StatusDisqualified}))
+	require.NoError(t, rm.UpdateLastResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:04.0000", Status: models.StatusPass}))
+
+	entries, err := rm.JournalEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, JournalAdd, entries[0].Action)
+	assert.Equal(t, JournalDisqualify, entries[1].Action)
+	assert.Equal(t, JournalAmend, entries[2].Action)
+	assert.Equal(t, 0, entries[2].Index)
+}
+
+func TestResultManager_LoadResults_ReplaysJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Bob", Discipline: "Bottle", BaseTime: "00:00:06.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.VoidLastResult(LegacyParticipantID("Alice"), "Bottle", "wrong lane"))
+
+	// results.json is deliberately never saved – the journal alone is enough
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	require.Len(t, rm2.GetResults(), 2)
+	assert.True(t, rm2.GetResults()[0].Voided)
+	assert.Equal(t, "wrong lane", rm2.GetResults()[0].EditReason)
+	require.Len(t, rm2.GetActiveResults(), 1)
+	assert.Equal(t, "Bob", rm2.GetActiveResults()[0].Name)
+	assert.Equal(t, rm.GetResults()[1].ID, rm2.GetResults()[1].ID)
+}
+
+func TestResultManager_LoadResults_ReplaysAmendByID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusDisqualified}))
+	id := rm.GetResults()[0].ID
+	require.NoError(t, rm.AmendResult(id, models.Result{BaseTime: "00:00:05.0000", Status: models.StatusPass}, "judge overruled", builtin(models.DisciplineBottle)))
+
+	entries, err := rm.JournalEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, id, entries[1].ResultID)
+	assert.Equal(t, "judge overruled", entries[1].Reason)
+
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	r := rm2.GetResults()[0]
+	assert.Equal(t, models.StatusPass, r.Status)
+	assert.Equal(t, "00:00:05.0000", r.Time)
+	assert.Equal(t, models.StatusDisqualified, r.OriginalStatus)
+}
+
+func TestResultManager_LoadResults_ImportsLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+	legacy := []map[string]string{
+		{"name": "Alice", "discipline": "Bottle", "time": "00:00:03.0000", "base_time": "00:00:03.0000", "status": "Pass"},
+	}
+	data, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	rm := NewResultManager()
+	require.NoError(t, rm.LoadResults(path))
+
+	entries, err := rm.JournalEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, JournalImport, entries[0].Action)
+	assert.Equal(t, "Alice", entries[0].Result.Name)
+	assert.NotEmpty(t, entries[0].ResultID, "imported rows get a stable ID")
+	assert.Equal(t, entries[0].ResultID, rm.GetResults()[0].ID)
+}
+
+func TestResultManager_VoidLastResult_RequiresReason(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+	assert.Error(t, rm.VoidLastResult(LegacyParticipantID("Alice"), "Bottle", "  "))
+	assert.Len(t, rm.GetResults(), 1)
+}
+
+func TestResultManager_ResultsAt_ReconstructsEarlierState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+
+	entries, err := rm.JournalEntries()
+	require.NoError(t, err)
+	cutoff := entries[0].Timestamp
+
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, rm.UpdateLastResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:09.0000", Status: models.StatusPass}))
+
+	before, err := rm.ResultsAt(cutoff)
+	require.NoError(t, err)
+	require.Len(t, before, 1)
+	assert.Equal(t, "00:00:05.0000", before[0].Time)
+	assert.Equal(t, "00:00:09.0000", rm.GetResults()[0].Time)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Journal – crash tolerance
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestJournal_TornLastLineIsDropped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.JournalFileName)
+	j := OpenJournal(path)
+	_, err := j.Append(JournalEntry{Action: JournalAdd, Result: &models.Result{Name: "Alice"}})
+	require.NoError(t, err)
+
+	// Simulate a crash halfway through the next append
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":2,"action":"ad`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// The next append starts on a fresh line and the journal stays readable
+	_, err = j.Append(JournalEntry{Action: JournalAdd, Result: &models.Result{Name: "Bob"}})
+	require.NoError(t, err)
+	entries, err = j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Bob", entries[1].Result.Name)
+}
+
+func TestJournal_CorruptMiddleLineIsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.JournalFileName)
+	require.NoError(t, os.WriteFile(path, []byte("{\"seq\":1,\"action\":\"add\",\"result\":{}}\nnot json\n{\"seq\":3,\"action\":\"add\",\"result\":{}}\n"), 0644))
+
+	_, err := readJournal(path)
+	assert.Error(t, err)
+}
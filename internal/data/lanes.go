@@ -0,0 +1,155 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// MaxLanes is the number of lanes a heat can run side by side.
+const MaxLanes = 4
+
+// Lane is one lane of a heat: the participant or team in it and its own
+// timer.
+type Lane struct {
+	Participant *models.Participant
+	Team        *models.Team
+	Timer       models.TimerState
+}
+
+// Occupied reports whether a participant or team is in the lane.
+func (l Lane) Occupied() bool {
+	return l.Participant != nil || l.Team != nil
+}
+
+// Name returns the name of the participant or team in the lane.
+func (l Lane) Name() string {
+	switch {
+	case l.Team != nil:
+		return l.Team.Name
+	case l.Participant != nil:
+		return l.Participant.Name
+	}
+	return ""
+}
+
+// Elapsed returns the time on the lane's timer at now: the running time, or
+// the stop time once the lane has been stopped.
+func (l Lane) Elapsed(now time.Time) time.Duration {
+	if l.Timer.Running {
+		return now.Sub(l.Timer.StartTime)
+	}
+	return l.Timer.Duration
+}
+
+// Result makes the result of the lane's competitor in discipline from its
+// result form: base is the stop time, possibly corrected by hand, and
+// additional the penalty time. A pass needs a base time; a time that does
+// not add up disqualifies it.
+func (l Lane) Result(discipline, base, additional, status, comment string) (models.Result, error) {
+	if !l.Occupied() {
+		return models.Result{}, fmt.Errorf("the lane is empty")
+	}
+	r := models.Result{Name: l.Name(), Discipline: discipline, Status: status, Comment: strings.TrimSpace(comment)}
+	if l.Team != nil {
+		r.TeamID = l.Team.ID
+	} else {
+		r.ParticipantID = l.Participant.ID
+	}
+
+	base, additional = strings.TrimSpace(base), strings.TrimSpace(additional)
+	if base != "" {
+		if r.BaseTime = utils.ParseAndPadTimeString(base); r.BaseTime == config.NoKey {
+			return models.Result{}, fmt.Errorf("%s: invalid base time %q", r.Name, base)
+		}
+	} else if status == models.StatusPass {
+		return models.Result{}, fmt.Errorf("%s: a pass needs a base time", r.Name)
+	}
+	if additional != "" && utils.ParseAndPadTimeString(additional) == config.NoKey {
+		return models.Result{}, fmt.Errorf("%s: invalid additional time %q", r.Name, additional)
+	}
+	r.AdditionalTime = additional
+
+	if status == models.StatusDisqualified {
+		r.Time = "NaN"
+		return r, nil
+	}
+	calcResultTime(&r)
+	if r.Time == "NaN" && status == models.StatusPass {
+		r.Status = models.StatusDisqualified
+	}
+	return r, nil
+}
+
+// Heat times up to MaxLanes competitors side by side: one start for all,
+// then every lane is stopped on its own.
+type Heat struct {
+	Lanes []Lane
+}
+
+// NewHeat creates a heat of empty lanes.
+func NewHeat(lanes int) (*Heat, error) {
+	if lanes < 1 || lanes > MaxLanes {
+		return nil, fmt.Errorf("a heat has 1 to %d lanes, not %d", MaxLanes, lanes)
+	}
+	return &Heat{Lanes: make([]Lane, lanes)}, nil
+}
+
+// Start starts the timers of every occupied lane at now.
+func (h *Heat) Start(now time.Time) error {
+	if h.Running() {
+		return fmt.Errorf("the heat is already running")
+	}
+	started := false
+	for i := range h.Lanes {
+		if h.Lanes[i].Occupied() {
+			h.Lanes[i].Timer = models.TimerState{Running: true, StartTime: now}
+			started = true
+		}
+	}
+	if !started {
+		return fmt.Errorf("no one is in the lanes")
+	}
+	return nil
+}
+
+// Stop stops the timer of a lane, counted from 0, at now and returns its
+// time.
+func (h *Heat) Stop(lane int, now time.Time) (time.Duration, error) {
+	if lane < 0 || lane >= len(h.Lanes) {
+		return 0, fmt.Errorf("there is no lane %d", lane+1)
+	}
+	l := &h.Lanes[lane]
+	if !l.Timer.Running {
+		return 0, fmt.Errorf("lane %d is not running", lane+1)
+	}
+	l.Timer.Running = false
+	l.Timer.Duration = now.Sub(l.Timer.StartTime)
+	return l.Timer.Duration, nil
+}
+
+// Running reports whether a lane is still running.
+func (h *Heat) Running() bool {
+	for _, l := range h.Lanes {
+		if l.Timer.Running {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset stops and clears every lane's timer, keeping who is in the lanes.
+func (h *Heat) Reset() {
+	for i := range h.Lanes {
+		h.Lanes[i].Timer = models.TimerState{}
+	}
+}
+
+// FormatElapsed formats a lane time as HH:MM:SS.mmmm.
+func FormatElapsed(d time.Duration) string {
+	return utils.FormatComparisonTime(int64(d / (time.Millisecond / 10)))
+}
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Heat
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestHeat_CommonStartIndependentStops(t *testing.T) {
+	h, err := NewHeat(3)
+	require.NoError(t, err)
+	h.Lanes[0].Participant = &models.Participant{ID: "p1", Name: "Alice"}
+	h.Lanes[2].Team = &models.Team{ID: "t1", Name: "Red"}
+
+	go0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, h.Start(go0))
+	assert.True(t, h.Running())
+	assert.False(t, h.Lanes[1].Timer.Running, "an empty lane does not start")
+	assert.Error(t, h.Start(go0), "a running heat cannot start again")
+
+	d, err := h.Stop(2, go0.Add(7*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Second, d)
+	assert.True(t, h.Running(), "lane 1 is still running")
+	assert.Equal(t, 9*time.Second, h.Lanes[0].Elapsed(go0.Add(9*time.Second)))
+	assert.Equal(t, 7*time.Second, h.Lanes[2].Elapsed(go0.Add(9*time.Second)), "a stopped lane keeps its time")
+
+	_, err = h.Stop(0, go0.Add(8500*time.Millisecond))
+	require.NoError(t, err)
+	assert.False(t, h.Running())
+	assert.Equal(t, "00:00:08.5000", FormatElapsed(h.Lanes[0].Timer.Duration))
+
+	_, err = h.Stop(0, go0.Add(10*time.Second))
+	assert.Error(t, err, "a lane stops once")
+	_, err = h.Stop(5, go0)
+	assert.Error(t, err)
+
+	h.Reset()
+	assert.Zero(t, h.Lanes[0].Timer.Duration)
+	assert.Equal(t, "Alice", h.Lanes[0].Name(), "a reset keeps the lanes")
+}
+
+func TestHeat_Errors(t *testing.T) {
+	_, err := NewHeat(MaxLanes + 1)
+	assert.Error(t, err)
+	h, err := NewHeat(2)
+	require.NoError(t, err)
+	assert.Error(t, h.Start(time.Now()), "no one is in the lanes")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Lane results
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestLane_Result(t *testing.T) {
+	lane := Lane{Participant: &models.Participant{ID: "p1", Name: "Alice"}}
+	r, err := lane.Result(models.DisciplineBottle, "00:00:08.5000", "1", models.StatusPass, " spill ")
+	require.NoError(t, err)
+	assert.Equal(t, "p1", r.ParticipantID)
+	assert.Equal(t, "00:00:09.5000", r.Time)
+	assert.Equal(t, "spill", r.Comment)
+
+	team := Lane{Team: &models.Team{ID: "t1", Name: "Red"}}
+	r, err = team.Result(models.DisciplineTeamClash, "", "", models.StatusDisqualified, "")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", r.TeamID)
+	assert.Equal(t, "NaN", r.Time)
+
+	_, err = lane.Result(models.DisciplineBottle, "", "", models.StatusPass, "")
+	assert.Error(t, err, "a pass needs a base time")
+	_, err = lane.Result(models.DisciplineBottle, "abc", "", models.StatusPass, "")
+	assert.Error(t, err)
+	_, err = lane.Result(models.DisciplineBottle, "8", "x", models.StatusPass, "")
+	assert.Error(t, err)
+	_, err = Lane{}.Result(models.DisciplineBottle, "8", "", models.StatusPass, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"chugware/internal/config"
+)
+
+// LockInfo identifies the ChugWare instance holding a contest lock.
+type LockInfo struct {
+	PID      int       `json:"pid"`
+	Host     string    `json:"host"`
+	Operator string    `json:"operator,omitempty"`
+	Since    time.Time `json:"since"`
+}
+
+// LockedError is returned by AcquireLock when another instance already holds
+// the lock. The lock is advisory: the holder may have crashed, in which case
+// the caller can take it over with force.
+type LockedError struct {
+	Path   string
+	Holder LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("contest is already open on %s (%s), process %d, since %s",
+		e.Holder.Host, e.Holder.Operator, e.Holder.PID, e.Holder.Since.Local().Format("2006-01-02 15:04:05"))
+}
+
+// LockFile is an advisory lock on a contest folder. It only keeps other
+// ChugWare instances from working on the same files; it does not stop anyone
+// from editing them.
+type LockFile struct {
+	path string
+}
+
+// LockPathFor returns the lock file that belongs next to dataFile.
+func LockPathFor(dataFile string) string {
+	return filepath.Join(filepath.Dir(dataFile), config.LockFileName)
+}
+
+// AcquireLock creates the lock file at path. If another instance holds it a
+// *LockedError is returned, unless force is set, in which case the lock is
+// taken over. A lock left behind by this very process is always reused.
+func AcquireLock(path string, force bool) (*LockFile, error) {
+	info := LockInfo{
+		PID:      os.Getpid(),
+		Host:     stationID(),
+		Operator: operatorName(),
+		Since:    time.Now().UTC(),
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating lock directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		holder, readErr := readLock(path)
+		ours := readErr == nil && holder.PID == info.PID && holder.Host == info.Host
+		if !force && !ours && readErr == nil {
+			return nil, &LockedError{Path: path, Holder: holder}
+		}
+		// Ours, taken over, or unreadable (a torn write): replace it
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("error taking over lock %s: %w", path, err)
+		}
+		return &LockFile{path: path}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error creating lock %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return nil, fmt.Errorf("error writing lock %s: %w", path, err)
+	}
+	return &LockFile{path: path}, nil
+}
+
+// readLock returns the holder recorded in a lock file.
+func readLock(path string) (LockInfo, error) {
+	var info LockInfo
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return info, err
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+// Release removes the lock file, provided it still belongs to this process;
+// a lock taken over by another instance is left alone.
+func (l *LockFile) Release() error {
+	holder, err := readLock(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err == nil && (holder.PID != os.Getpid() || holder.Host != stationID()) {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing lock %s: %w", l.path, err)
+	}
+	return nil
+}
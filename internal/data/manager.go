@@ -5,7 +5,9 @@ import (
 	"chugware/internal/models"
 	"chugware/internal/utils"
 	"fmt"
-	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // calcResultTime recomputes result.Time from base_time + additional_time.
@@ -37,10 +39,17 @@ func calcResultTime(result *models.Result) {
 	result.Time = fmt.Sprintf("%02d:%02d:%02d.%04d", hours, minutes, seconds, subms)
 }
 
-// ParticipantManager handles participant data operations
+// ParticipantManager handles participant data operations.
+// It is safe for concurrent use: a shared Session may reload it from its file
+// watcher while a window is reading it.
 type ParticipantManager struct {
+	mu           sync.Mutex
 	participants []models.Participant
 	filePath     string
+	onSave       func()
+	// setupLocked reports whether the contest's setup is locked (see
+	// ContestRules.Locked); it is called with pm.mu held. Nil means never.
+	setupLocked func() bool
 }
 
 // NewParticipantManager creates a new participant manager
@@ -50,135 +59,192 @@ func NewParticipantManager() *ParticipantManager {
 	}
 }
 
-// LoadParticipants loads participants from JSON file
+// LoadParticipants loads participants from the store behind filePath: a JSON
+// file, or an embedded database if the path ends in .db
 func (pm *ParticipantManager) LoadParticipants(filePath string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	pm.filePath = filePath
 
-	data, err := utils.FillListFromJSONFile(filePath)
+	store, err := participantStoreFor(filePath)
 	if err != nil {
 		return fmt.Errorf("error loading participants: %w", err)
 	}
-
-	pm.participants = make([]models.Participant, 0, len(data))
-	for _, entry := range data {
-		participant := models.Participant{
-			Name:        entry["name"],
-			Program:     entry["program"],
-			Team:        entry["team"],
-			Bottle:      entry["bottle"],
-			HalfTankard: entry["half_tankard"],
-			FullTankard: entry["full_tankard"],
-		}
-		pm.participants = append(pm.participants, participant)
+	participants, err := store.LoadParticipants()
+	if err != nil {
+		return fmt.Errorf("error loading participants: %w", err)
 	}
 
+	pm.participants = participants
 	return nil
 }
 
-// SaveParticipants saves participants to JSON file
+// SaveParticipants saves participants to the store behind the file path
 func (pm *ParticipantManager) SaveParticipants() error {
+	pm.mu.Lock()
+	err := pm.save()
+	onSave := pm.onSave
+	pm.mu.Unlock()
+
+	if err == nil && onSave != nil {
+		onSave()
+	}
+	return err
+}
+
+// save writes the participants; the caller holds pm.mu.
+func (pm *ParticipantManager) save() error {
 	if pm.filePath == "" {
 		return fmt.Errorf("no file path set")
 	}
 
-	data := make([]map[string]string, 0, len(pm.participants))
-	for _, p := range pm.participants {
-		entry := map[string]string{
-			"name":         p.Name,
-			"program":      p.Program,
-			"team":         p.Team,
-			"bottle":       p.Bottle,
-			"half_tankard": p.HalfTankard,
-			"full_tankard": p.FullTankard,
-		}
-		data = append(data, entry)
+	store, err := participantStoreFor(pm.filePath)
+	if err != nil {
+		return err
 	}
-
-	return utils.SaveListToJSONFile(pm.filePath, data)
+	return store.SaveParticipants(pm.participants)
 }
 
 // SetFilePath sets the file path without loading from disk
 func (pm *ParticipantManager) SetFilePath(filePath string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	pm.filePath = filePath
 }
 
-// DecrementTries reduces the remaining try count for the given discipline by 1
-// (minimum 0). The participant data is updated in memory; call SaveParticipants to persist.
-func (pm *ParticipantManager) DecrementTries(name, discipline string) error {
-	for i, p := range pm.participants {
-		if p.Name == name {
-			switch discipline {
-			case models.DisciplineBottle:
-				tries, _ := strconv.Atoi(p.Bottle)
-				if tries > 0 {
-					pm.participants[i].Bottle = strconv.Itoa(tries - 1)
-				}
-			case models.DisciplineHalfTankard:
-				tries, _ := strconv.Atoi(p.HalfTankard)
-				if tries > 0 {
-					pm.participants[i].HalfTankard = strconv.Itoa(tries - 1)
-				}
-			case models.DisciplineFullTankard:
-				tries, _ := strconv.Atoi(p.FullTankard)
-				if tries > 0 {
-					pm.participants[i].FullTankard = strconv.Itoa(tries - 1)
-				}
-			default:
-				// Other disciplines don't have try counts — silently ignore
-				return nil
-			}
-			return nil
+// AddParticipant adds a new participant, generating its ID if it has none.
+// Several participants may share a name; they are told apart by ID.
+func (pm *ParticipantManager) AddParticipant(participant models.Participant) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if err := pm.checkSetup(); err != nil {
+		return err
+	}
+	if err := validateParticipant(participant); err != nil {
+		return err
+	}
+	if participant.ID == "" {
+		participant.ID = utils.NewID()
+	}
+	if pm.index(participant.ID) >= 0 {
+		return fmt.Errorf("participant with ID '%s' already exists", participant.ID)
+	}
+
+	pm.participants = append(pm.participants, participant)
+	return nil
+}
+
+// UpdateParticipant replaces the participant with the same ID, e.g. to
+// correct a misspelt name. Results follow via ResultManager.RenameParticipant.
+// While the setup is locked the tries cannot be changed.
+func (pm *ParticipantManager) UpdateParticipant(participant models.Participant) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if err := validateParticipant(participant); err != nil {
+		return err
+	}
+	i := pm.index(participant.ID)
+	if i < 0 {
+		return fmt.Errorf("participant '%s' not found", participant.ID)
+	}
+	if !SameTries(pm.participants[i], participant) {
+		if err := pm.checkSetup(); err != nil {
+			return &SetupLockedError{What: "the tries of " + pm.participants[i].Name}
 		}
 	}
-	return fmt.Errorf("participant '%s' not found", name)
+	pm.participants[i] = participant
+	return nil
 }
 
-// AddParticipant adds a new participant
-func (pm *ParticipantManager) AddParticipant(participant models.Participant) error {
-	// Validate participant data
+// checkSetup returns a *SetupLockedError if the participant list may not be
+// changed. The caller holds pm.mu.
+func (pm *ParticipantManager) checkSetup() error {
+	if pm.setupLocked != nil && pm.setupLocked() {
+		return &SetupLockedError{What: "the participant list"}
+	}
+	return nil
+}
+
+// validateParticipant checks the fields a participant must have.
+func validateParticipant(participant models.Participant) error {
 	if utils.IsNullString(participant.Name) {
 		return fmt.Errorf("participant name cannot be empty")
 	}
-
 	if !utils.IsStringValid(participant.Name) || !utils.IsStringValid(participant.Program) || !utils.IsStringValid(participant.Team) {
 		return fmt.Errorf("participant data exceeds maximum length")
 	}
+	return nil
+}
 
-	// Check for duplicates
-	for _, existing := range pm.participants {
-		if existing.Name == participant.Name {
-			return fmt.Errorf("participant with name '%s' already exists", participant.Name)
+// index returns the position of the participant with the given ID, or -1.
+// The caller holds pm.mu.
+func (pm *ParticipantManager) index(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i := range pm.participants {
+		if pm.participants[i].ID == id {
+			return i
 		}
 	}
+	return -1
+}
 
-	pm.participants = append(pm.participants, participant)
-	return nil
+// GetParticipant returns the participant with the given ID.
+func (pm *ParticipantManager) GetParticipant(id string) (models.Participant, bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if i := pm.index(id); i >= 0 {
+		return pm.participants[i], true
+	}
+	return models.Participant{}, false
 }
 
 // GetParticipants returns a copy of all participants.
 // A copy is returned so callers cannot accidentally mutate the manager's
 // internal slice (e.g. via append) and corrupt data like try-counts.
 func (pm *ParticipantManager) GetParticipants() []models.Participant {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
 	cp := make([]models.Participant, len(pm.participants))
 	copy(cp, pm.participants)
 	return cp
 }
 
-// RemoveParticipant removes a participant by name
-func (pm *ParticipantManager) RemoveParticipant(name string) error {
-	for i, participant := range pm.participants {
-		if participant.Name == name {
-			pm.participants = append(pm.participants[:i], pm.participants[i+1:]...)
-			return nil
-		}
+// RemoveParticipant removes the participant with the given ID
+func (pm *ParticipantManager) RemoveParticipant(id string) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if err := pm.checkSetup(); err != nil {
+		return err
+	}
+	i := pm.index(id)
+	if i < 0 {
+		return fmt.Errorf("participant '%s' not found", id)
 	}
-	return fmt.Errorf("participant '%s' not found", name)
+	pm.participants = append(pm.participants[:i], pm.participants[i+1:]...)
+	return nil
 }
 
 // ResultManager handles contest results
+// Every mutation goes through the journal in the result file's folder; the
+// in-memory list (and therefore results.json) is the replay of that journal.
+// Like ParticipantManager it is safe for concurrent use.
 type ResultManager struct {
+	mu       sync.Mutex
 	results  []models.Result
 	filePath string
+	journal  *Journal
+	onSave   func()
+	// onJournal is called after every journal append, with rm.mu held
+	onJournal func()
 }
 
 // NewResultManager creates a new result manager
@@ -190,80 +256,242 @@ func NewResultManager() *ResultManager {
 
 // SetFilePath sets the file path without loading from disk
 func (rm *ResultManager) SetFilePath(filePath string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
 	rm.filePath = filePath
+	rm.journal = OpenJournal(JournalPathFor(filePath))
 }
 
-// LoadResults loads results from JSON file
+// LoadResults loads results by replaying the journal next to filePath.
+// If no journal exists yet, the rows in the store behind filePath (JSON, or
+// an embedded database for .db paths) are imported into a new one.
 func (rm *ResultManager) LoadResults(filePath string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
 	rm.filePath = filePath
+	rm.journal = OpenJournal(JournalPathFor(filePath))
 
-	data, err := utils.FillListFromJSONFile(filePath)
+	entries, err := rm.journal.Entries()
 	if err != nil {
 		return fmt.Errorf("error loading results: %w", err)
 	}
+	if len(entries) > 0 {
+		results, err := ReplayResults(entries)
+		if err != nil {
+			return fmt.Errorf("error replaying result journal: %w", err)
+		}
+		rm.results = results
+		return nil
+	}
 
-	rm.results = make([]models.Result, 0, len(data))
-	for _, entry := range data {
-		result := models.Result{
-			Name:           entry["name"],
-			Discipline:     entry["discipline"],
-			Time:           entry["time"],
-			BaseTime:       entry["base_time"],
-			AdditionalTime: entry["additional_time"],
-			Status:         entry["status"],
-			Comment:        entry["comment"],
+	store, err := resultStoreFor(filePath)
+	if err != nil {
+		return fmt.Errorf("error loading results: %w", err)
+	}
+	stored, err := store.LoadResults()
+	if err != nil {
+		return fmt.Errorf("error loading results: %w", err)
+	}
+
+	rm.results = make([]models.Result, 0, len(stored))
+	for _, result := range stored {
+		result := result
+		if result.ID == "" {
+			result.ID = utils.NewID()
+		}
+		if err := rm.record(JournalEntry{Action: JournalImport, Index: len(rm.results), ResultID: result.ID, Result: &result}); err != nil {
+			return err
 		}
-		rm.results = append(rm.results, result)
 	}
 
 	return nil
 }
 
-// SaveResults saves results to JSON file
+// SaveResults saves results to the store behind the file path
 func (rm *ResultManager) SaveResults() error {
+	rm.mu.Lock()
+	err := rm.save()
+	onSave := rm.onSave
+	rm.mu.Unlock()
+
+	if err == nil && onSave != nil {
+		onSave()
+	}
+	return err
+}
+
+// save writes the results; the caller holds rm.mu.
+func (rm *ResultManager) save() error {
 	if rm.filePath == "" {
 		return fmt.Errorf("no file path set")
 	}
 
-	data := make([]map[string]string, 0, len(rm.results))
-	for _, r := range rm.results {
-		entry := map[string]string{
-			"name":            r.Name,
-			"discipline":      r.Discipline,
-			"time":            r.Time,
-			"base_time":       r.BaseTime,
-			"additional_time": r.AdditionalTime,
-			"status":          r.Status,
-			"comment":         r.Comment,
+	store, err := resultStoreFor(rm.filePath)
+	if err != nil {
+		return err
+	}
+	return store.SaveResults(rm.results)
+}
+
+// record writes entry to the journal (when one is attached) and then applies
+// it to the in-memory results. Nothing changes if the journal write fails.
+// The caller holds rm.mu.
+func (rm *ResultManager) record(entry JournalEntry) error {
+	if rm.journal != nil {
+		stamped, err := rm.journal.Append(entry)
+		if err != nil {
+			return err
+		}
+		entry = stamped
+		if rm.onJournal != nil {
+			rm.onJournal()
 		}
-		data = append(data, entry)
 	}
 
-	return utils.SaveListToJSONFile(rm.filePath, data)
+	results, err := applyJournalEntry(rm.results, entry)
+	if err != nil {
+		return err
+	}
+	rm.results = results
+	return nil
 }
 
 // AddResult adds a new contest result
 func (rm *ResultManager) AddResult(result models.Result) error {
-	// Validate result data
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if err := validateResult(result); err != nil {
+		return err
+	}
+	return rm.addResult(result)
+}
+
+// AddResults adds the results of a heat run on several lanes at once, in
+// order. Either all of them are added or, if one is invalid or cannot be
+// journalled, none. Results without an ID get one in results.
+func (rm *ResultManager) AddResults(results []models.Result) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	for _, result := range results {
+		if err := validateResult(result); err != nil {
+			return fmt.Errorf("%s: %w", result.Name, err)
+		}
+	}
+	for i, result := range results {
+		if result.ID == "" {
+			result.ID = utils.NewID()
+			results[i].ID = result.ID
+		}
+		if err := rm.addResult(result); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				_ = rm.retract(results[j].ID)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// validateResult checks the fields every result needs.
+func validateResult(result models.Result) error {
 	if utils.IsNullString(result.Name) || utils.IsNullString(result.Discipline) {
 		return fmt.Errorf("name and discipline are required")
 	}
+	return nil
+}
 
+// addResult journals and adds a validated result; the caller holds rm.mu.
+func (rm *ResultManager) addResult(result models.Result) error {
 	// Always recalculate time from base + additional (unless status is Disqualified)
+	action := JournalAdd
 	if result.Status != models.StatusDisqualified {
 		calcResultTime(&result)
 	} else {
 		result.Time = "NaN"
+		action = JournalDisqualify
+	}
+	if result.ID == "" {
+		result.ID = utils.NewID()
 	}
 
 	_ = config.NoKey // keep import used
-	rm.results = append(rm.results, result)
-	return nil
+	return rm.record(JournalEntry{
+		Action:        action,
+		Index:         len(rm.results),
+		ResultID:      result.ID,
+		ParticipantID: result.ParticipantID,
+		TeamID:        result.TeamID,
+		Name:          result.Name,
+		Discipline:    result.Discipline,
+		Result:        &result,
+	})
+}
+
+// RenameParticipant changes the name shown on every result of the participant
+// with the given ID. Nothing is journalled if no result carries another name.
+func (rm *ResultManager) RenameParticipant(participantID, name string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if participantID == "" || utils.IsNullString(name) {
+		return fmt.Errorf("participant ID and name are required")
+	}
+	stale := false
+	for _, r := range rm.results {
+		if r.ParticipantID == participantID && r.Name != name {
+			stale = true
+			break
+		}
+	}
+	if !stale {
+		return nil
+	}
+	return rm.record(JournalEntry{
+		Action:        JournalRename,
+		Index:         -1,
+		ParticipantID: participantID,
+		Name:          name,
+	})
 }
 
-// UpdateLastResult updates the last added result for a participant and discipline.
-// A Disqualified result is never overwritten.
+// RenameTeam changes the name shown on every result of the team with the
+// given ID. Nothing is journalled if no result carries another name.
+func (rm *ResultManager) RenameTeam(teamID, name string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if teamID == "" || utils.IsNullString(name) {
+		return fmt.Errorf("team ID and name are required")
+	}
+	stale := false
+	for _, r := range rm.results {
+		if r.TeamID == teamID && r.Name != name {
+			stale = true
+			break
+		}
+	}
+	if !stale {
+		return nil
+	}
+	return rm.record(JournalEntry{
+		Action: JournalRename,
+		Index:  -1,
+		TeamID: teamID,
+		Name:   name,
+	})
+}
+
+// UpdateLastResult updates the last added result for a participant and
+// discipline. Like AmendResult it may overwrite a Disqualified result, in
+// official and unofficial contests alike.
 func (rm *ResultManager) UpdateLastResult(result models.Result) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
 	// Always recalculate time from base + additional
 	if result.Status != models.StatusDisqualified {
 		calcResultTime(&result)
@@ -271,40 +499,283 @@ func (rm *ResultManager) UpdateLastResult(result models.Result) error {
 		result.Time = "NaN"
 	}
 
+	i := rm.lastResultIndex(result.ParticipantID, result.Name, result.Discipline)
+	if i < 0 {
+		return fmt.Errorf("no result found to update for %s in %s", result.Name, result.Discipline)
+	}
+	result.ID = rm.results[i].ID
+	if result.ParticipantID == "" && result.TeamID == "" {
+		result.ParticipantID = rm.results[i].ParticipantID
+		result.TeamID = rm.results[i].TeamID
+	}
+	return rm.record(JournalEntry{
+		Action:        JournalAmend,
+		Index:         i,
+		ResultID:      result.ID,
+		ParticipantID: result.ParticipantID,
+		TeamID:        result.TeamID,
+		Name:          result.Name,
+		Discipline:    result.Discipline,
+		Result:        &result,
+	})
+}
+
+// AmendResult corrects the times, status, comment and penalties of the result
+// with the given ID. Any result may be amended, including Disqualified ones;
+// the values as first recorded are kept in the Original* fields. Name and
+// discipline cannot be changed. A reason is mandatory.
+//
+// The penalties of updated replace those of the result, and its additional
+// time is worked out from them: updated.AdditionalTime is the time given
+// besides them (see AdditionalTimeWith and ExtraTime). The amended result is
+// checked against the limits of its discipline def like a recorded one (see
+// ApplyDisciplineRules), so an amended pass over the time limit fails, and
+// one that keeps a disqualifying penalty stays disqualified.
+func (rm *ResultManager) AmendResult(id string, updated models.Result, reason string, def models.DisciplineDef) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if utils.IsNullString(reason) {
+		return fmt.Errorf("a reason is required to amend a result")
+	}
+	i := rm.resultIndex(id)
+	if i < 0 {
+		return fmt.Errorf("result '%s' not found", id)
+	}
+	existing := rm.results[i]
+	if existing.Voided {
+		return fmt.Errorf("result '%s' has been voided and cannot be amended", id)
+	}
+
+	amended := existing
+	amended.KeepOriginal()
+	amended.BaseTime = updated.BaseTime
+	amended.AdditionalTime = updated.AdditionalTime
+	amended.Penalties = updated.Penalties
+	if len(updated.Penalties) > 0 {
+		amended.AdditionalTime = AdditionalTimeWith(updated.Penalties, updated.AdditionalTime)
+	}
+	amended.Status = updated.Status
+	amended.Comment = updated.Comment
+	amended.EditReason = strings.TrimSpace(reason)
+	if amended.Status != models.StatusDisqualified {
+		calcResultTime(&amended)
+	} else {
+		amended.Time = "NaN"
+	}
+	ApplyDisciplineRules(def, &amended)
+
+	return rm.record(JournalEntry{
+		Action:     JournalAmend,
+		Index:      i,
+		ResultID:   id,
+		Name:       existing.Name,
+		Discipline: existing.Discipline,
+		Result:     &amended,
+		Reason:     amended.EditReason,
+	})
+}
+
+// VoidResult withdraws the result with the given ID. The row is kept, flagged
+// as voided, and no longer counts towards standings or the participant's
+// tries. A reason is mandatory.
+func (rm *ResultManager) VoidResult(id, reason string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if utils.IsNullString(reason) {
+		return fmt.Errorf("a reason is required to void a result")
+	}
+	i := rm.resultIndex(id)
+	if i < 0 {
+		return fmt.Errorf("result '%s' not found", id)
+	}
+	return rm.voidAt(i, reason)
+}
+
+// VoidLastResult withdraws the last result for a participant and discipline.
+func (rm *ResultManager) VoidLastResult(participantID, discipline, reason string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if utils.IsNullString(reason) {
+		return fmt.Errorf("a reason is required to void a result")
+	}
+	i := rm.lastResultIndex(participantID, "", discipline)
+	if i < 0 {
+		return fmt.Errorf("no result found to void for participant %s in %s", participantID, discipline)
+	}
+	return rm.voidAt(i, reason)
+}
+
+// voidAt records a void for the result at index i.
+func (rm *ResultManager) voidAt(i int, reason string) error {
+	target := rm.results[i]
+	if target.Voided {
+		return fmt.Errorf("result '%s' has already been voided", target.ID)
+	}
+	return rm.record(JournalEntry{
+		Action:     JournalVoid,
+		Index:      i,
+		ResultID:   target.ID,
+		Name:       target.Name,
+		Discipline: target.Discipline,
+		Reason:     strings.TrimSpace(reason),
+	})
+}
+
+// RetractResult takes back the result with the given ID as if it had never
+// been recorded, e.g. when the operator undoes a mis-click. Unlike a void the
+// row is removed; the journal keeps both the result and its retraction.
+func (rm *ResultManager) RetractResult(id string) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	return rm.retract(id)
+}
+
+// retract journals the retraction of the result id; the caller holds rm.mu.
+func (rm *ResultManager) retract(id string) error {
+	i := rm.resultIndex(id)
+	if i < 0 {
+		return fmt.Errorf("result '%s' not found", id)
+	}
+	target := rm.results[i]
+	return rm.record(JournalEntry{
+		Action:        JournalRetract,
+		Index:         i,
+		ResultID:      id,
+		ParticipantID: target.ParticipantID,
+		TeamID:        target.TeamID,
+		Name:          target.Name,
+		Discipline:    target.Discipline,
+	})
+}
+
+// resultIndex returns the index of the result with the given ID, or -1.
+func (rm *ResultManager) resultIndex(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i := range rm.results {
+		if rm.results[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// lastResultIndex returns the index of the newest non-voided result for the
+// participant and discipline, or -1. The participant is matched by ID, or by
+// name when participantID is empty.
+func (rm *ResultManager) lastResultIndex(participantID, name, discipline string) int {
 	for i := len(rm.results) - 1; i >= 0; i-- {
-		if rm.results[i].Name == result.Name && rm.results[i].Discipline == result.Discipline {
-			// Never overwrite an existing Disqualified result
-			if rm.results[i].Status == models.StatusDisqualified {
-				return fmt.Errorf("%s is already disqualified in %s and cannot be overwritten", result.Name, result.Discipline)
-			}
-			rm.results[i] = result
-			return nil
+		r := rm.results[i]
+		if r.Discipline != discipline || r.Voided {
+			continue
 		}
+		if r.IsTeamResult() {
+			continue
+		}
+		if (participantID != "" && r.ParticipantID == participantID) || (participantID == "" && r.Name == name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// JournalEntries returns the full audit trail behind the current results.
+func (rm *ResultManager) JournalEntries() ([]JournalEntry, error) {
+	rm.mu.Lock()
+	journal := rm.journal
+	rm.mu.Unlock()
+
+	if journal == nil {
+		return nil, nil
 	}
-	return fmt.Errorf("no result found to update for %s in %s", result.Name, result.Discipline)
+	return journal.Entries()
 }
 
-// GetResults returns all results
+// ResultsAt reconstructs the result list as it stood at time t.
+func (rm *ResultManager) ResultsAt(t time.Time) ([]models.Result, error) {
+	entries, err := rm.JournalEntries()
+	if err != nil {
+		return nil, err
+	}
+	return ReplayResults(EntriesUntil(entries, t))
+}
+
+// GetResults returns a copy of all results, including voided ones
 func (rm *ResultManager) GetResults() []models.Result {
-	return rm.results
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	cp := make([]models.Result, len(rm.results))
+	copy(cp, rm.results)
+	return cp
+}
+
+// GetActiveResults returns all results that have not been voided
+func (rm *ResultManager) GetActiveResults() []models.Result {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var active []models.Result
+	for _, result := range rm.results {
+		if !result.Voided {
+			active = append(active, result)
+		}
+	}
+	return active
+}
+
+// GetResultByID returns the result with the given ID
+func (rm *ResultManager) GetResultByID(id string) (models.Result, bool) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if i := rm.resultIndex(id); i >= 0 {
+		return rm.results[i], true
+	}
+	return models.Result{}, false
 }
 
-// GetResultsByDiscipline returns results filtered by discipline
+// GetResultsByDiscipline returns non-voided results filtered by discipline
 func (rm *ResultManager) GetResultsByDiscipline(discipline string) []models.Result {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
 	var filtered []models.Result
 	for _, result := range rm.results {
-		if result.Discipline == discipline {
+		if result.Discipline == discipline && !result.Voided {
 			filtered = append(filtered, result)
 		}
 	}
 	return filtered
 }
 
-// GetResultsByParticipant returns results for a specific participant
-func (rm *ResultManager) GetResultsByParticipant(name string) []models.Result {
+// GetResultsByParticipant returns non-voided results for a specific participant
+func (rm *ResultManager) GetResultsByParticipant(participantID string) []models.Result {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	var filtered []models.Result
+	for _, result := range rm.results {
+		if result.ParticipantID == participantID && !result.IsTeamResult() && !result.Voided {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// GetResultsByTeam returns non-voided results for a specific team
+func (rm *ResultManager) GetResultsByTeam(teamID string) []models.Result {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
 	var filtered []models.Result
 	for _, result := range rm.results {
-		if result.Name == name {
+		if result.TeamID == teamID && !result.Voided {
 			filtered = append(filtered, result)
 		}
 	}
@@ -125,10 +125,24 @@ func TestParticipantManager_AddParticipant_WhitespaceName(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestParticipantManager_AddParticipant_Duplicate(t *testing.T) {
+func TestParticipantManager_AddParticipant_AssignsID(t *testing.T) {
 	pm := NewParticipantManager()
 	require.NoError(t, pm.AddParticipant(newParticipant("Alice")))
-	err := pm.AddParticipant(newParticipant("Alice"))
+	require.NoError(t, pm.AddParticipant(newParticipant("Alice")))
+
+	// Two people may share a name; the generated IDs tell them apart
+	p := pm.GetParticipants()
+	require.Len(t, p, 2)
+	assert.Len(t, p[0].ID, 12)
+	assert.NotEqual(t, p[0].ID, p[1].ID)
+}
+
+func TestParticipantManager_AddParticipant_DuplicateID(t *testing.T) {
+	pm := NewParticipantManager()
+	alice := newParticipant("Alice")
+	alice.ID = "p1"
+	require.NoError(t, pm.AddParticipant(alice))
+	err := pm.AddParticipant(alice)
 	assert.Error(t, err)
 	assert.Len(t, pm.GetParticipants(), 1)
 }
@@ -150,7 +164,7 @@ func TestParticipantManager_RemoveParticipant_Success(t *testing.T) {
 	require.NoError(t, pm.AddParticipant(newParticipant("Alice")))
 	require.NoError(t, pm.AddParticipant(newParticipant("Bob")))
 
-	err := pm.RemoveParticipant("Alice")
+	err := pm.RemoveParticipant(pm.GetParticipants()[0].ID)
 	require.NoError(t, err)
 
 	p := pm.GetParticipants()
@@ -169,7 +183,7 @@ func TestParticipantManager_RemoveParticipant_PreservesOrder(t *testing.T) {
 	for _, name := range []string{"A", "B", "C", "D"} {
 		require.NoError(t, pm.AddParticipant(newParticipant(name)))
 	}
-	require.NoError(t, pm.RemoveParticipant("B"))
+	require.NoError(t, pm.RemoveParticipant(pm.GetParticipants()[1].ID))
 
 	names := make([]string, 0)
 	for _, p := range pm.GetParticipants() {
@@ -194,55 +208,73 @@ func TestParticipantManager_GetParticipants_ReturnsCopy(t *testing.T) {
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
-// ParticipantManager – DecrementTries
+// ResultManager – RemainingTries
 // ─────────────────────────────────────────────────────────────────────────────
 
-func TestParticipantManager_DecrementTries_Bottle(t *testing.T) {
-	pm := NewParticipantManager()
-	require.NoError(t, pm.AddParticipant(newParticipant("Alice"))) // Bottle = "3"
-
-	require.NoError(t, pm.DecrementTries("Alice", models.DisciplineBottle))
-	assert.Equal(t, "2", pm.GetParticipants()[0].Bottle)
+// builtin returns the definition of a built-in discipline.
+func builtin(name string) models.DisciplineDef {
+	def, _ := DefaultDisciplineRegistry().Get(name)
+	return def
 }
 
-func TestParticipantManager_DecrementTries_HalfTankard(t *testing.T) {
-	pm := NewParticipantManager()
-	require.NoError(t, pm.AddParticipant(newParticipant("Alice"))) // HalfTankard = "2"
-
-	require.NoError(t, pm.DecrementTries("Alice", models.DisciplineHalfTankard))
-	assert.Equal(t, "1", pm.GetParticipants()[0].HalfTankard)
-}
+func TestResultManager_RemainingTries(t *testing.T) {
+	alice := newParticipant("Alice") // 3 / 2 / 1
+	alice.ID = "p1"
+	rm := NewResultManager()
 
-func TestParticipantManager_DecrementTries_FullTankard(t *testing.T) {
-	pm := NewParticipantManager()
-	require.NoError(t, pm.AddParticipant(newParticipant("Alice"))) // FullTankard = "1"
+	assert.Equal(t, 3, rm.RemainingTries(alice, builtin(models.DisciplineBottle)))
+	assert.Equal(t, 2, rm.RemainingTries(alice, builtin(models.DisciplineHalfTankard)))
+	assert.Equal(t, 1, rm.RemainingTries(alice, builtin(models.DisciplineFullTankard)))
 
-	require.NoError(t, pm.DecrementTries("Alice", models.DisciplineFullTankard))
-	assert.Equal(t, "0", pm.GetParticipants()[0].FullTankard)
+	// Every recorded attempt counts, passed or not
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, Status: models.StatusDisqualified}))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineHalfTankard, BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	assert.Equal(t, 1, rm.RemainingTries(alice, builtin(models.DisciplineBottle)))
+	assert.Equal(t, 1, rm.RemainingTries(alice, builtin(models.DisciplineHalfTankard)))
+	assert.Equal(t, 1, rm.RemainingTries(alice, builtin(models.DisciplineFullTankard)))
 }
 
-func TestParticipantManager_DecrementTries_DoesNotGoBelowZero(t *testing.T) {
-	pm := NewParticipantManager()
-	p := newParticipant("Alice")
-	p.Bottle = "0"
-	require.NoError(t, pm.AddParticipant(p))
+func TestResultManager_RemainingTries_VoidedAndAmended(t *testing.T) {
+	alice := newParticipant("Alice")
+	alice.ID = "p1"
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineHalfTankard, BaseTime: "00:00:05.0000", Status: models.StatusPass}))
 
-	require.NoError(t, pm.DecrementTries("Alice", models.DisciplineBottle))
-	assert.Equal(t, "0", pm.GetParticipants()[0].Bottle)
+	// Amending the attempt keeps it one attempt
+	require.NoError(t, rm.UpdateLastResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineHalfTankard, BaseTime: "00:00:04.0000", Status: models.StatusPass}))
+	assert.Equal(t, 1, rm.RemainingTries(alice, builtin(models.DisciplineHalfTankard)))
+
+	// A voided attempt hands its try back; the re-run uses it again
+	require.NoError(t, rm.VoidLastResult("p1", models.DisciplineHalfTankard, "false start"))
+	assert.Equal(t, 2, rm.RemainingTries(alice, builtin(models.DisciplineHalfTankard)))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineHalfTankard, BaseTime: "00:00:06.0000", Status: models.StatusPass}))
+	assert.Equal(t, 1, rm.RemainingTries(alice, builtin(models.DisciplineHalfTankard)))
 }
 
-func TestParticipantManager_DecrementTries_NotFound(t *testing.T) {
-	pm := NewParticipantManager()
-	err := pm.DecrementTries("Ghost", models.DisciplineBottle)
-	assert.Error(t, err)
+func TestRemainingTries_DoesNotGoBelowZero(t *testing.T) {
+	alice := newParticipant("Alice")
+	alice.ID = "p1"
+	results := []models.Result{
+		{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineFullTankard, Status: models.StatusPass},
+		{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineFullTankard, Status: models.StatusPass},
+	}
+	assert.Equal(t, 0, RemainingTries(alice, builtin(models.DisciplineFullTankard), results))
 }
 
-func TestParticipantManager_DecrementTries_OtherDiscipline(t *testing.T) {
-	pm := NewParticipantManager()
-	require.NoError(t, pm.AddParticipant(newParticipant("Alice")))
-	// BierStaphette has no try-count – should return nil without panicking
-	err := pm.DecrementTries("Alice", models.DisciplineBierStaphette)
-	assert.NoError(t, err)
+func TestRemainingTries_OtherParticipantsAndDisciplines(t *testing.T) {
+	alice := newParticipant("Alice")
+	alice.ID = "p1"
+	results := []models.Result{
+		{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineBottle, Status: models.StatusPass},
+		{TeamID: "t1", Name: "Alice", Discipline: models.DisciplineBottle, Status: models.StatusPass},
+	}
+	assert.Equal(t, 3, RemainingTries(alice, builtin(models.DisciplineBottle), results))
+
+	// Team disciplines have no try counts
+	_, ok := TriesEntitled(alice, builtin(models.DisciplineBierStaphette))
+	assert.False(t, ok)
+	assert.Equal(t, 0, RemainingTries(alice, builtin(models.DisciplineBierStaphette), nil))
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -351,15 +383,15 @@ func TestResultManager_UpdateLastResult_Success(t *testing.T) {
 	assert.Equal(t, "00:00:04.0000", rm.GetResults()[0].Time)
 }
 
-func TestResultManager_UpdateLastResult_CannotOverwriteDisqualified(t *testing.T) {
+func TestResultManager_UpdateLastResult_OverwritesDisqualified(t *testing.T) {
 	rm := NewResultManager()
 	dq := models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusDisqualified}
 	require.NoError(t, rm.AddResult(dq))
 
 	updated := models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:03.0000", Status: models.StatusPass}
-	err := rm.UpdateLastResult(updated)
-	assert.Error(t, err)
-	assert.Equal(t, "NaN", rm.GetResults()[0].Time)
+	require.NoError(t, rm.UpdateLastResult(updated))
+	assert.Equal(t, models.StatusPass, rm.GetResults()[0].Status)
+	assert.Equal(t, "00:00:03.0000", rm.GetResults()[0].Time)
 }
 
 func TestResultManager_UpdateLastResult_NotFound(t *testing.T) {
@@ -387,6 +419,203 @@ func TestResultManager_UpdateLastResult_UpdatesLastMatchOnly(t *testing.T) {
 	assert.Equal(t, "00:00:04.0000", results[1].Time)
 }
 
+// ─────────────────────────────────────────────────────────────────────────────
+// ResultManager – AmendResult / VoidResult
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestResultManager_AddResult_AssignsUniqueIDs(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+
+	results := rm.GetResults()
+	assert.NotEmpty(t, results[0].ID)
+	assert.NotEqual(t, results[0].ID, results[1].ID)
+}
+
+func TestResultManager_UpdateLastResult_KeepsID(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	id := rm.GetResults()[0].ID
+
+	require.NoError(t, rm.UpdateLastResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:04.0000", Status: models.StatusPass}))
+	assert.Equal(t, id, rm.GetResults()[0].ID)
+}
+
+func TestResultManager_AmendResult_TargetsSpecificAttempt(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:06.0000", Status: models.StatusPass}))
+	first := rm.GetResults()[0]
+
+	require.NoError(t, rm.AmendResult(first.ID, models.Result{
+		BaseTime:       "00:00:05.0000",
+		AdditionalTime: "00:00:02.0000",
+		Status:         models.StatusPass,
+		Comment:        "Spill",
+	}, "penalty missed", builtin(models.DisciplineBottle)))
+
+	amended, ok := rm.GetResultByID(first.ID)
+	require.True(t, ok)
+	assert.Equal(t, "00:00:07.0000", amended.Time)
+	assert.Equal(t, "Spill", amended.Comment)
+	assert.Equal(t, "penalty missed", amended.EditReason)
+	assert.Equal(t, "00:00:05.0000", amended.OriginalTime)
+	assert.Equal(t, models.StatusPass, amended.OriginalStatus)
+	assert.Equal(t, "00:00:06.0000", rm.GetResults()[1].Time, "second attempt must be untouched")
+}
+
+func TestResultManager_AmendResult_CanChangeDisqualified(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusDisqualified, Comment: "Overflow"}))
+	id := rm.GetResults()[0].ID
+
+	require.NoError(t, rm.AmendResult(id, models.Result{BaseTime: "00:00:05.0000", Status: models.StatusPass}, "video review", builtin(models.DisciplineBottle)))
+	r := rm.GetResults()[0]
+	assert.Equal(t, models.StatusPass, r.Status)
+	assert.Equal(t, "00:00:05.0000", r.Time)
+	assert.Equal(t, models.StatusDisqualified, r.OriginalStatus)
+	assert.Equal(t, "Overflow", r.OriginalComment)
+}
+
+func TestResultManager_AmendResult_AppliesDisciplineRules(t *testing.T) {
+	def := models.DisciplineDef{Name: "Shot", TimeLimit: "10", Penalty: models.PenaltyRule{Max: "2"}}
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Shot", BaseTime: "00:00:08.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Bob", Discipline: "Shot", BaseTime: "00:00:07.0000", Status: models.StatusPass}))
+	alice, bob := rm.GetResults()[0].ID, rm.GetResults()[1].ID
+
+	require.NoError(t, rm.AmendResult(alice, models.Result{BaseTime: "00:00:12.0000", Status: models.StatusPass}, "clock misread", def))
+	r, _ := rm.GetResultByID(alice)
+	assert.Equal(t, models.StatusFail, r.Status, "an amended time over the time limit fails")
+	assert.Equal(t, "over time limit 10", r.Comment)
+
+	require.NoError(t, rm.AmendResult(bob, models.Result{BaseTime: "00:00:07.0000", AdditionalTime: "3", Status: models.StatusPass}, "spill on video", def))
+	r, _ = rm.GetResultByID(bob)
+	assert.Equal(t, models.StatusDisqualified, r.Status, "amended penalties over the maximum disqualify")
+}
+
+func TestResultManager_AmendResult_OverturnsPenaltyDisqualification(t *testing.T) {
+	def := catalogDiscipline()
+	spill := models.Penalty{Name: "Spill", Time: "00:00:02.0000"}
+	rm := NewResultManager()
+	recorded := models.Result{Name: "Alice", Discipline: def.Name, BaseTime: "00:00:05.0000", AdditionalTime: "00:00:06.0000",
+		Status: models.StatusPass, Penalties: []models.Penalty{spill, spill, spill}}
+	ApplyDisciplineRules(def, &recorded)
+	require.Equal(t, models.StatusDisqualified, recorded.Status, "the third spill disqualifies")
+	require.NoError(t, rm.AddResult(recorded))
+	id := rm.GetResults()[0].ID
+
+	// Keeping the three spills keeps the disqualification
+	kept := models.Result{BaseTime: "00:00:05.0000", Status: models.StatusPass, Comment: "Spill", Penalties: recorded.Penalties}
+	require.NoError(t, rm.AmendResult(id, kept, "appeal", def))
+	r, _ := rm.GetResultByID(id)
+	assert.Equal(t, models.StatusDisqualified, r.Status)
+	assert.Equal(t, "Spill", r.Comment, "the reason is not repeated")
+
+	// The video shows two spills: the result passes with their time
+	overturned := models.Result{BaseTime: "00:00:05.0000", AdditionalTime: "0.5", Status: models.StatusPass, Penalties: []models.Penalty{spill, spill}}
+	require.NoError(t, rm.AmendResult(id, overturned, "video shows two spills", def))
+	r, _ = rm.GetResultByID(id)
+	assert.Equal(t, models.StatusPass, r.Status)
+	assert.Equal(t, []models.Penalty{spill, spill}, r.Penalties)
+	assert.Equal(t, "00:00:04.5000", r.AdditionalTime, "worked out from the penalties and the extra time")
+	assert.Equal(t, "00:00:09.5000", r.Time)
+	assert.Len(t, r.OriginalPenalties, 3, "the penalties as first recorded are kept")
+}
+
+func TestResultManager_AmendResult_KeepsFirstOriginal(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	id := rm.GetResults()[0].ID
+
+	require.NoError(t, rm.AmendResult(id, models.Result{BaseTime: "00:00:06.0000", Status: models.StatusPass}, "first fix", builtin(models.DisciplineBottle)))
+	require.NoError(t, rm.AmendResult(id, models.Result{BaseTime: "00:00:07.0000", Status: models.StatusPass}, "second fix", builtin(models.DisciplineBottle)))
+
+	r := rm.GetResults()[0]
+	assert.Equal(t, "00:00:07.0000", r.Time)
+	assert.Equal(t, "00:00:05.0000", r.OriginalTime)
+	assert.Equal(t, "second fix", r.EditReason)
+}
+
+func TestResultManager_AmendResult_RequiresReason(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	id := rm.GetResults()[0].ID
+
+	assert.Error(t, rm.AmendResult(id, models.Result{BaseTime: "00:00:09.0000", Status: models.StatusPass}, "", builtin(models.DisciplineBottle)))
+	assert.Equal(t, "00:00:05.0000", rm.GetResults()[0].Time)
+}
+
+func TestResultManager_AmendResult_UnknownID(t *testing.T) {
+	rm := NewResultManager()
+	assert.Error(t, rm.AmendResult("nope", models.Result{Status: models.StatusPass}, "reason", builtin(models.DisciplineBottle)))
+}
+
+func TestResultManager_VoidResult_KeepsRowButExcludesIt(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Bob", Discipline: "Bottle", BaseTime: "00:00:06.0000", Status: models.StatusPass}))
+	id := rm.GetResults()[0].ID
+
+	require.NoError(t, rm.VoidResult(id, "false start"))
+
+	r, ok := rm.GetResultByID(id)
+	require.True(t, ok)
+	assert.True(t, r.Voided)
+	assert.Equal(t, "false start", r.EditReason)
+	assert.Equal(t, models.StatusPass, r.OriginalStatus)
+	assert.Len(t, rm.GetResults(), 2)
+	assert.Len(t, rm.GetActiveResults(), 1)
+	assert.Len(t, rm.GetResultsByDiscipline("Bottle"), 1)
+	assert.Empty(t, rm.GetResultsByParticipant(LegacyParticipantID("Alice")))
+}
+
+func TestResultManager_VoidResult_Twice(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+	id := rm.GetResults()[0].ID
+
+	require.NoError(t, rm.VoidResult(id, "false start"))
+	assert.Error(t, rm.VoidResult(id, "again"))
+	assert.Error(t, rm.AmendResult(id, models.Result{Status: models.StatusPass}, "revive", builtin(models.DisciplineBottle)))
+}
+
+func TestResultManager_UpdateLastResult_SkipsVoided(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:06.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.VoidResult(rm.GetResults()[1].ID, "wrong lane"))
+
+	require.NoError(t, rm.UpdateLastResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:04.0000", Status: models.StatusPass}))
+	assert.Equal(t, "00:00:04.0000", rm.GetResults()[0].Time)
+	assert.Equal(t, "00:00:06.0000", rm.GetResults()[1].Time)
+}
+
+func TestResultManager_SaveLoad_PreservesCorrections(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.VoidResult(rm.GetResults()[0].ID, "false start"))
+	require.NoError(t, rm.SaveResults())
+
+	// Drop the journal so the saved file itself is imported
+	require.NoError(t, os.Remove(JournalPathFor(path)))
+	journalsMu.Lock()
+	delete(journals, path)
+	journalsMu.Unlock()
+
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	r := rm2.GetResults()[0]
+	assert.Equal(t, rm.GetResults()[0].ID, r.ID)
+	assert.True(t, r.Voided)
+	assert.Equal(t, "false start", r.EditReason)
+	assert.Equal(t, "00:00:05.0000", r.OriginalTime)
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // ResultManager – GetResultsByDiscipline / GetResultsByParticipant
 // ─────────────────────────────────────────────────────────────────────────────
@@ -406,17 +635,70 @@ func TestResultManager_GetResultsByDiscipline(t *testing.T) {
 
 func TestResultManager_GetResultsByParticipant(t *testing.T) {
 	rm := NewResultManager()
-	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
-	require.NoError(t, rm.AddResult(models.Result{Name: "Bob", Discipline: "Bottle", Status: models.StatusPass}))
-	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Half Tankard", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p2", Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: "Half Tankard", Status: models.StatusPass}))
 
-	aliceResults := rm.GetResultsByParticipant("Alice")
+	aliceResults := rm.GetResultsByParticipant("p1")
 	require.Len(t, aliceResults, 2)
 	for _, r := range aliceResults {
-		assert.Equal(t, "Alice", r.Name)
+		assert.Equal(t, "p1", r.ParticipantID)
 	}
 }
 
+func TestResultManager_AddResult_LinksLegacyName(t *testing.T) {
+	rm := NewResultManager()
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: "Bottle", Status: models.StatusPass}))
+	assert.Equal(t, LegacyParticipantID("Alice"), rm.GetResults()[0].ParticipantID)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// UpdateParticipant / RenameParticipant
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestParticipantManager_UpdateParticipant(t *testing.T) {
+	pm := NewParticipantManager()
+	require.NoError(t, pm.AddParticipant(newParticipant("Alice")))
+	p := pm.GetParticipants()[0]
+
+	p.Name = "Alicia"
+	require.NoError(t, pm.UpdateParticipant(p))
+	got, ok := pm.GetParticipant(p.ID)
+	require.True(t, ok)
+	assert.Equal(t, "Alicia", got.Name)
+
+	p.ID = "ghost"
+	assert.Error(t, pm.UpdateParticipant(p))
+}
+
+func TestResultManager_RenameParticipant(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p1", Name: "Alice", Discipline: "Bottle", BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{ParticipantID: "p2", Name: "Bob", Discipline: "Bottle", BaseTime: "00:00:06.0000", Status: models.StatusPass}))
+
+	require.NoError(t, rm.RenameParticipant("p1", "Alicia"))
+	results := rm.GetResults()
+	assert.Equal(t, "Alicia", results[0].Name)
+	assert.Equal(t, "Bob", results[1].Name)
+
+	// The rename is journalled, so a reload keeps it
+	require.NoError(t, rm.SaveResults())
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	assert.Equal(t, "Alicia", rm2.GetResults()[0].Name)
+
+	// Renaming to the current name records nothing
+	entries, err := rm.JournalEntries()
+	require.NoError(t, err)
+	require.NoError(t, rm.RenameParticipant("p1", "Alicia"))
+	again, err := rm.JournalEntries()
+	require.NoError(t, err)
+	assert.Len(t, again, len(entries))
+}
+
 func TestResultManager_GetResultsByDiscipline_Empty(t *testing.T) {
 	rm := NewResultManager()
 	results := rm.GetResultsByDiscipline("Bottle")
@@ -482,7 +764,8 @@ func TestResultManager_SetFilePath_PathIsUsed(t *testing.T) {
 	// Save with no results – should succeed and produce an empty JSON array.
 	require.NoError(t, rm.SaveResults())
 
-	// Confirm the file exists and contains a valid JSON array.
+	// Confirm the file exists and contains a versioned envelope around an
+	// empty JSON array.
 	path := filepath.Join(t.TempDir(), "ok_results.json")
 	rm2 := NewResultManager()
 	rm2.SetFilePath(path)
@@ -491,7 +774,14 @@ func TestResultManager_SetFilePath_PathIsUsed(t *testing.T) {
 	data, err := os.ReadFile(path)
 	require.NoError(t, err)
 
-	var loaded []map[string]string
-	require.NoError(t, json.Unmarshal(data, &loaded))
-	assert.Empty(t, loaded)
+	var env struct {
+		Schema  string              `json:"schema"`
+		Version int                 `json:"version"`
+		Data    []map[string]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &env))
+	assert.Equal(t, SchemaResults, env.Schema)
+	assert.Equal(t, CurrentSchemaVersion, env.Version)
+	assert.NotNil(t, env.Data)
+	assert.Empty(t, env.Data)
 }
@@ -0,0 +1,153 @@
+package data
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// Backend names accepted by MigrateContest.
+const (
+	BackendJSON     = "json"
+	BackendDatabase = "db"
+)
+
+// MigrationReport describes one converted contest folder.
+type MigrationReport struct {
+	ContestDir   string
+	From         string
+	To           string
+	Participants int
+	Teams        int
+	Results      int
+}
+
+// ContestStorePaths returns the participant and result locations of the
+// contest/ folder for a backend.
+func ContestStorePaths(contestDir, backend string) (participantFile, resultFile string) {
+	if backend == BackendDatabase {
+		db := filepath.Join(contestDir, config.DatabaseFileName)
+		return db, db
+	}
+	return filepath.Join(contestDir, "participants.json"), filepath.Join(contestDir, "results.json")
+}
+
+// OpenContestStore opens the data of a contest/ folder with whichever
+// backend it uses: the database if there is one, the JSON files otherwise.
+func OpenContestStore(contestDir string) (Store, error) {
+	if utils.DoesFileExist(filepath.Join(contestDir, config.DatabaseFileName)) {
+		_, resultFile := ContestStorePaths(contestDir, BackendDatabase)
+		return OpenBoltStore(resultFile)
+	}
+	return NewJSONStore(ContestStorePaths(contestDir, BackendJSON)), nil
+}
+
+// MigrateContest copies the participants, teams and results of contestDir (a
+// contest's contest/ folder) from one backend to the other. The source is left
+// untouched. Results are taken from the result journal when there is one,
+// since it is more up to date than a results file that was not saved yet.
+// The target must be empty unless force is set.
+func MigrateContest(contestDir, to string, force bool) (MigrationReport, error) {
+	from := BackendJSON
+	if to == BackendJSON {
+		from = BackendDatabase
+	} else if to != BackendDatabase {
+		return MigrationReport{}, fmt.Errorf("unknown storage backend %q", to)
+	}
+	report := MigrationReport{ContestDir: contestDir, From: from, To: to}
+
+	srcParticipants, srcResults := ContestStorePaths(contestDir, from)
+	dstParticipants, dstResults := ContestStorePaths(contestDir, to)
+	if !utils.DoesFileExist(srcParticipants) && !utils.DoesFileExist(srcResults) {
+		return report, fmt.Errorf("no %s data found in %s", from, contestDir)
+	}
+
+	src, err := participantStoreFor(srcParticipants)
+	if err != nil {
+		return report, err
+	}
+	if from == BackendDatabase {
+		defer src.Close()
+	}
+	participants, err := src.LoadParticipants()
+	if err != nil {
+		return report, err
+	}
+	teams, err := src.LoadTeams()
+	if err != nil {
+		return report, err
+	}
+	results, err := migrationResults(srcResults)
+	if err != nil {
+		return report, err
+	}
+
+	dst := Store(NewJSONStore(dstParticipants, dstResults))
+	if to == BackendDatabase {
+		db, err := OpenBoltStore(dstResults)
+		if err != nil {
+			return report, err
+		}
+		defer db.Close()
+		dst = db
+	}
+	if !force {
+		existingP, err := dst.LoadParticipants()
+		if err != nil {
+			return report, err
+		}
+		existingR, err := dst.LoadResults()
+		if err != nil {
+			return report, err
+		}
+		if len(existingP) > 0 || len(existingR) > 0 {
+			return report, fmt.Errorf("%s already holds %s data; use force to overwrite", contestDir, to)
+		}
+	}
+
+	if err := dst.SaveParticipants(participants); err != nil {
+		return report, err
+	}
+	if err := dst.SaveTeams(teams); err != nil {
+		return report, err
+	}
+	if err := dst.SaveResults(results); err != nil {
+		return report, err
+	}
+
+	report.Participants = len(participants)
+	report.Teams = len(teams)
+	report.Results = len(results)
+	return report, nil
+}
+
+// migrationResults reads the results to migrate without writing anything:
+// the journal replay if there is a journal, otherwise the stored rows with
+// IDs filled in.
+func migrationResults(resultFile string) ([]models.Result, error) {
+	entries, err := readJournal(JournalPathFor(resultFile))
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		return ReplayResults(entries)
+	}
+
+	store, err := resultStoreFor(resultFile)
+	if err != nil {
+		return nil, err
+	}
+	results, err := store.LoadResults()
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		if results[i].ID == "" {
+			results[i].ID = utils.NewID()
+		}
+	}
+	return results, nil
+}
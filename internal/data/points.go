@@ -0,0 +1,186 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// DefaultPoints is the championship points table of a discipline without
+// one of its own: points for 1st to 8th place.
+var DefaultPoints = []int{10, 8, 6, 5, 4, 3, 2, 1}
+
+// PointsStanding is one line of an overall standings table: a participant or
+// a faction with the points they collected in each discipline.
+type PointsStanding struct {
+	Name        string         `json:"name"`
+	Program     string         `json:"program,omitempty"` // individuals: the faction they compete for
+	Points      int            `json:"points"`
+	Rank        int            `json:"rank"`
+	Tied        bool           `json:"tied,omitempty"`
+	Place       string         `json:"place"` // Rank as shown, "T-2" for a shared placing
+	Disciplines map[string]int `json:"disciplines"`
+}
+
+// Championship holds the overall standings of a contest.
+type Championship struct {
+	// Individuals ranks the participants by their points in the individual
+	// disciplines.
+	Individuals []PointsStanding
+	// Factions ranks the programs by the points of their participants and
+	// teams in every discipline.
+	Factions []PointsStanding
+}
+
+// PlacePoints returns the points the rank of a standing is worth in def: its
+// own points table, or DefaultPoints. Shared placings each get the points of
+// the place they share.
+func PlacePoints(def models.DisciplineDef, rank int) int {
+	table := def.Points
+	if len(table) == 0 {
+		table = DefaultPoints
+	}
+	if rank < 1 || rank > len(table) {
+		return 0
+	}
+	return table[rank-1]
+}
+
+// NewChampionship awards points by placing in every discipline of the
+// registry (see Standings) and totals them per participant and per faction.
+// A faction is a program; team results count for the program of the team.
+// Competitors without points are left out.
+func NewChampionship(registry *DisciplineRegistry, participants []models.Participant, teams []models.Team, results []models.Result) Championship {
+	byDiscipline := make(map[string][]models.Result)
+	for _, r := range results {
+		byDiscipline[r.Discipline] = append(byDiscipline[r.Discipline], r)
+	}
+	participantByID := make(map[string]models.Participant, len(participants))
+	participantByName := make(map[string]models.Participant, len(participants))
+	for _, p := range participants {
+		participantByID[p.ID] = p
+		participantByName[p.Name] = p
+	}
+	teamByID := make(map[string]models.Team, len(teams))
+	for _, t := range teams {
+		teamByID[t.ID] = t
+	}
+
+	individuals := newPointsTable()
+	factions := newPointsTable()
+	for _, def := range registry.Defs() {
+		for _, st := range Standings(def, byDiscipline[def.Name]) {
+			points := PlacePoints(def, st.Rank)
+			if points == 0 {
+				continue
+			}
+			r := st.Result
+			if r.IsTeamResult() {
+				factions.add(teamByID[r.TeamID].Program, "", def.Name, points)
+				continue
+			}
+			p, ok := participantByID[r.ParticipantID]
+			if !ok {
+				p = participantByName[r.Name]
+			}
+			key := competitorKey(r)
+			individuals.add(key, r.Name, def.Name, points)
+			individuals.entries[key].Program = p.Program
+			factions.add(p.Program, "", def.Name, points)
+		}
+	}
+	return Championship{Individuals: individuals.ranked(), Factions: factions.ranked()}
+}
+
+// pointsTable collects points per key in the order keys first score.
+type pointsTable struct {
+	keys    []string
+	entries map[string]*PointsStanding
+}
+
+func newPointsTable() *pointsTable {
+	return &pointsTable{entries: make(map[string]*PointsStanding)}
+}
+
+// add gives points in a discipline to the entry of key, named name or, if
+// that is empty, key. An empty key, e.g. a participant without a program,
+// is not counted.
+func (t *pointsTable) add(key, name, discipline string, points int) {
+	if key == "" {
+		return
+	}
+	e, ok := t.entries[key]
+	if !ok {
+		if name == "" {
+			name = key
+		}
+		e = &PointsStanding{Name: name, Disciplines: make(map[string]int)}
+		t.entries[key] = e
+		t.keys = append(t.keys, key)
+	}
+	e.Points += points
+	e.Disciplines[discipline] += points
+}
+
+// ranked returns the entries most points first, equal points sharing a
+// placing as in 1, 2, 2, 4 and ordered by name.
+func (t *pointsTable) ranked() []PointsStanding {
+	out := make([]PointsStanding, len(t.keys))
+	for i, key := range t.keys {
+		out[i] = *t.entries[key]
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Points != out[j].Points {
+			return out[i].Points > out[j].Points
+		}
+		return out[i].Name < out[j].Name
+	})
+	points := make([]int, len(out))
+	for i := range out {
+		points[i] = out[i].Points
+	}
+	for i, p := range pointsPlacings(points) {
+		out[i].Rank, out[i].Tied, out[i].Place = p.rank, p.tied, p.place
+	}
+	return out
+}
+
+type placing struct {
+	rank  int
+	tied  bool
+	place string
+}
+
+// pointsPlacings numbers totals sorted most first; equal totals share a
+// placing as in 1, 2, 2, 4.
+func pointsPlacings(points []int) []placing {
+	out := make([]placing, len(points))
+	for i := range points {
+		out[i].rank = i + 1
+		if i > 0 && points[i] == points[i-1] {
+			out[i].rank = out[i-1].rank
+			out[i-1].tied, out[i].tied = true, true
+		}
+	}
+	for i := range out {
+		out[i].place = fmt.Sprintf("%d", out[i].rank)
+		if out[i].tied {
+			out[i].place = fmt.Sprintf("T-%d", out[i].rank)
+		}
+	}
+	return out
+}
+
+// SaveChampionship writes the individual standings of c to leaderBoardPath
+// and the faction standings to factionPath.
+func SaveChampionship(leaderBoardPath, factionPath string, c Championship) error {
+	if err := utils.SaveJSONFile(leaderBoardPath, c.Individuals); err != nil {
+		return fmt.Errorf("error saving leaderboard %s: %w", leaderBoardPath, err)
+	}
+	if err := utils.SaveJSONFile(factionPath, c.Factions); err != nil {
+		return fmt.Errorf("error saving faction standings %s: %w", factionPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func championshipContest() ([]models.Participant, []models.Team, []models.Result) {
+	participants := []models.Participant{
+		{ID: "p1", Name: "Alice", Program: "D"},
+		{ID: "p2", Name: "Bob", Program: "E"},
+		{ID: "p3", Name: "Cleo", Program: "D"},
+	}
+	teams := []models.Team{{ID: "t1", Name: "Red", Program: "E"}}
+	results := []models.Result{
+		{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, Time: "00:00:07.0000", Status: models.StatusPass},
+		{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineBottle, Time: "00:00:08.0000", Status: models.StatusPass},
+		{ParticipantID: "p3", Name: "Cleo", Discipline: models.DisciplineBottle, Time: "NaN", Status: models.StatusDisqualified},
+		{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineHalfTankard, Time: "00:00:05.0000", Status: models.StatusPass},
+		{ParticipantID: "p3", Name: "Cleo", Discipline: models.DisciplineHalfTankard, Time: "00:00:06.0000", Status: models.StatusPass},
+		{TeamID: "t1", Name: "Red", Discipline: models.DisciplineBierStaphette, Time: "00:00:30.0000", Status: models.StatusPass},
+	}
+	return participants, teams, results
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Championship points
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestPlacePoints(t *testing.T) {
+	def := builtin(models.DisciplineBottle)
+	assert.Equal(t, 10, PlacePoints(def, 1))
+	assert.Equal(t, 1, PlacePoints(def, 8))
+	assert.Equal(t, 0, PlacePoints(def, 9), "beyond the table")
+	assert.Equal(t, 0, PlacePoints(def, 0), "not ranked")
+
+	def.Points = []int{3, 1}
+	assert.Equal(t, 1, PlacePoints(def, 2))
+	assert.Equal(t, 0, PlacePoints(def, 3))
+}
+
+func TestNewChampionship(t *testing.T) {
+	participants, teams, results := championshipContest()
+	c := NewChampionship(DefaultDisciplineRegistry(), participants, teams, results)
+
+	require.Len(t, c.Individuals, 3, "Cleo's DQ earns nothing but her Half Tankard does")
+	assert.Equal(t, "Bob", c.Individuals[0].Name)
+	assert.Equal(t, 18, c.Individuals[0].Points, "8 for 2nd in Bottle, 10 for 1st in Half Tankard")
+	assert.Equal(t, map[string]int{models.DisciplineBottle: 8, models.DisciplineHalfTankard: 10}, c.Individuals[0].Disciplines)
+	assert.Equal(t, "E", c.Individuals[0].Program)
+	assert.Equal(t, []string{"1", "2", "3"}, []string{c.Individuals[0].Place, c.Individuals[1].Place, c.Individuals[2].Place})
+	assert.Equal(t, "Alice", c.Individuals[1].Name)
+
+	require.Len(t, c.Factions, 2)
+	assert.Equal(t, PointsStanding{Name: "E", Points: 28, Rank: 1, Place: "1",
+		Disciplines: map[string]int{models.DisciplineBottle: 8, models.DisciplineHalfTankard: 10, models.DisciplineBierStaphette: 10}},
+		c.Factions[0], "team results count for the team's program")
+	assert.Equal(t, 18, c.Factions[1].Points)
+}
+
+func TestNewChampionship_SharedPlacings(t *testing.T) {
+	participants, teams, results := championshipContest()
+	results[1].Time = "00:00:07.0000" // Bob ties Alice in Bottle
+	results = results[:3]
+
+	c := NewChampionship(DefaultDisciplineRegistry(), participants, teams, results)
+	require.Len(t, c.Individuals, 2)
+	assert.Equal(t, 10, c.Individuals[0].Points, "a shared placing gets the points of that place")
+	assert.Equal(t, 10, c.Individuals[1].Points)
+	assert.Equal(t, "T-1", c.Individuals[0].Place)
+	assert.Equal(t, "Alice", c.Individuals[0].Name, "equal points are listed by name")
+	assert.Equal(t, 1, c.Individuals[1].Rank)
+}
+
+func TestSaveChampionship(t *testing.T) {
+	participants, teams, results := championshipContest()
+	c := NewChampionship(DefaultDisciplineRegistry(), participants, teams, results)
+	dir := t.TempDir()
+	leaderBoard, factions := filepath.Join(dir, "leaderboard.json"), filepath.Join(dir, "factions.json")
+
+	require.NoError(t, SaveChampionship(leaderBoard, factions, c))
+	assert.FileExists(t, leaderBoard)
+	assert.FileExists(t, factions)
+}
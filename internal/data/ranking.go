@@ -0,0 +1,250 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// Standing is one competitor's place in a discipline: the attempt that counts
+// under the discipline's attempt policy and the attempts that do not.
+type Standing struct {
+	// Result is the attempt that counts. Under CountAverage it is the
+	// competitor's fastest attempt carrying the average time instead.
+	Result models.Result
+	// Rank is the 1-based place; 0 if the competitor is not ranked.
+	Rank int
+	// Tied is set if the competitor shares Rank with another competitor.
+	Tied bool
+	// Others are the competitor's remaining attempts in recorded order.
+	Others []models.Result
+	// Note explains an unranked standing, e.g. too few passed attempts for an
+	// average.
+	Note string
+
+	// attempts are all the competitor's attempts in recorded order; recorded
+	// is the position of the counting attempt among the discipline's results.
+	attempts []models.Result
+	recorded int
+}
+
+// Place shows the rank of a standing: "2", "T-2" for a shared placing, or
+// nothing for a competitor who is not ranked.
+func (s Standing) Place() string {
+	switch {
+	case s.Rank == 0:
+		return ""
+	case s.Tied:
+		return fmt.Sprintf("T-%d", s.Rank)
+	}
+	return fmt.Sprintf("%d", s.Rank)
+}
+
+// Standings collapses the results of the discipline def to one standing per
+// participant or team, ranked by the discipline's ranking time under its
+// attempt policy. Voided results are ignored. Ranked standings come first,
+// fastest first with equal times ordered by the discipline's tie-break rules;
+// competitors still tied share a placing, as in 1, 2, 2, 4. The rest follow
+// in the order their competitors first appear.
+func Standings(def models.DisciplineDef, results []models.Result) []Standing {
+	var keys []string
+	attempts := make(map[string][]models.Result)
+	positions := make(map[string][]int)
+	for i, r := range results {
+		if r.Voided {
+			continue
+		}
+		key := competitorKey(r)
+		if _, ok := attempts[key]; !ok {
+			keys = append(keys, key)
+		}
+		attempts[key] = append(attempts[key], r)
+		positions[key] = append(positions[key], i)
+	}
+
+	var ranked, unranked []Standing
+	for _, key := range keys {
+		s, counted := standingOf(def, attempts[key])
+		s.attempts, s.recorded = attempts[key], positions[key][counted]
+		if s.Rank > 0 {
+			ranked = append(ranked, s)
+		} else {
+			unranked = append(unranked, s)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return compareStandings(def, ranked[i], ranked[j]) < 0
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+		if i > 0 && compareStandings(def, ranked[i-1], ranked[i]) == 0 {
+			ranked[i].Rank = ranked[i-1].Rank
+			ranked[i-1].Tied, ranked[i].Tied = true, true
+		}
+	}
+	return append(ranked, unranked...)
+}
+
+// compareStandings orders two ranked standings by ranking time and then by
+// the tie-break rules of def: negative if a comes first, 0 if they share a
+// placing.
+func compareStandings(def models.DisciplineDef, a, b Standing) int {
+	if c := compareTimes(RankingTime(def, a.Result), RankingTime(def, b.Result)); c != 0 {
+		return c
+	}
+	for _, rule := range def.TieBreak {
+		var c int
+		switch rule {
+		case models.TieBreakPenalty:
+			c = compareTimes(penaltyOf(a.Result), penaltyOf(b.Result))
+		case models.TieBreakSecond:
+			c = compareTimes(secondBest(def, a.attempts), secondBest(def, b.attempts))
+		case models.TieBreakEarlier:
+			c = compareTimes(int64(a.recorded), int64(b.recorded))
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareTimes(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// penaltyOf returns the penalty time of a result; none counts as 0.
+func penaltyOf(r models.Result) int64 {
+	if p := penaltyTime(r); p > 0 {
+		return p
+	}
+	return 0
+}
+
+// secondBest returns the ranking time of the second fastest passed attempt,
+// or math.MaxInt64 if there is none, so that having one breaks the tie.
+func secondBest(def models.DisciplineDef, attempts []models.Result) int64 {
+	var times []int64
+	for _, r := range attempts {
+		if validAttempt(def, r) {
+			times = append(times, RankingTime(def, r))
+		}
+	}
+	if len(times) < 2 {
+		return math.MaxInt64
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times[1]
+}
+
+// competitorKey identifies whose attempt a result is: the team, the
+// participant or, for results without a reference, the name.
+func competitorKey(r models.Result) string {
+	switch {
+	case r.TeamID != "":
+		return "team:" + r.TeamID
+	case r.ParticipantID != "":
+		return "participant:" + r.ParticipantID
+	}
+	return "name:" + r.Name
+}
+
+// validAttempt reports whether r can be ranked: it passed with a valid time.
+func validAttempt(def models.DisciplineDef, r models.Result) bool {
+	return r.Status == models.StatusPass && RankingTime(def, r) > 0
+}
+
+// standingOf picks the attempt that counts out of one competitor's attempts,
+// given in recorded order, and returns its index; for an average, the index
+// of the last attempt averaged. Rank is set to 1 for a rankable standing and
+// numbered by Standings.
+func standingOf(def models.DisciplineDef, attempts []models.Result) (Standing, int) {
+	// Valid attempts fastest first; equal times keep the earlier attempt
+	var valid []int
+	for i, r := range attempts {
+		if validAttempt(def, r) {
+			valid = append(valid, i)
+		}
+	}
+	sort.SliceStable(valid, func(i, j int) bool {
+		return RankingTime(def, attempts[valid[i]]) < RankingTime(def, attempts[valid[j]])
+	})
+
+	last := len(attempts) - 1
+	switch def.Attempts {
+	case models.CountLast:
+		s := Standing{Result: attempts[last], Others: without(attempts, last)}
+		if validAttempt(def, attempts[last]) {
+			s.Rank = 1
+		}
+		return s, last
+
+	case models.CountAverage:
+		if len(valid) < def.AverageOf {
+			s, counted := standingOf(models.DisciplineDef{Ranking: def.Ranking}, attempts)
+			s.Rank = 0
+			s.Note = fmt.Sprintf("%d of %d passed attempts for the average", len(valid), def.AverageOf)
+			return s, counted
+		}
+		picked := valid[:def.AverageOf]
+		counted := 0
+		for _, i := range picked {
+			if i > counted {
+				counted = i
+			}
+		}
+		return Standing{Result: averageOf(def, attempts, picked), Rank: 1, Others: attempts}, counted
+	}
+
+	if len(valid) == 0 {
+		return Standing{Result: attempts[last], Others: without(attempts, last)}, last
+	}
+	return Standing{Result: attempts[valid[0]], Rank: 1, Others: without(attempts, valid[0])}, valid[0]
+}
+
+// averageOf returns the fastest of the picked attempts carrying their mean
+// times. The base time is averaged only if every picked attempt has one.
+func averageOf(def models.DisciplineDef, attempts []models.Result, picked []int) models.Result {
+	var total, base int64
+	hasBase := true
+	for _, i := range picked {
+		total += utils.ParseTimeForComparison(attempts[i].Time)
+		if b := utils.ParseTimeForComparison(attempts[i].BaseTime); attempts[i].BaseTime != "" && b >= 0 {
+			base += b
+		} else {
+			hasBase = false
+		}
+	}
+	n := int64(len(picked))
+
+	avg := attempts[picked[0]]
+	avg.Time = utils.FormatComparisonTime(total / n)
+	avg.BaseTime, avg.AdditionalTime = "", ""
+	if hasBase {
+		avg.BaseTime = utils.FormatComparisonTime(base / n)
+		avg.AdditionalTime = utils.FormatComparisonTime((total - base) / n)
+	}
+	avg.Comment = fmt.Sprintf("average of %d", len(picked))
+	return avg
+}
+
+// without returns attempts without the one at index skip.
+func without(attempts []models.Result, skip int) []models.Result {
+	var others []models.Result
+	for i, r := range attempts {
+		if i != skip {
+			others = append(others, r)
+		}
+	}
+	return others
+}
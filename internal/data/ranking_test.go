@@ -0,0 +1,170 @@
+package data
+
+import (
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attempt returns a result of participant id with total time t.
+func attempt(id, name, t, status string) models.Result {
+	return models.Result{ID: id + t, ParticipantID: id, Name: name, Discipline: models.DisciplineBottle, Time: t, Status: status}
+}
+
+func bottleAttempts() []models.Result {
+	return []models.Result{
+		attempt("p1", "Alice", "00:00:09.0000", models.StatusPass),
+		attempt("p2", "Bob", "00:00:08.0000", models.StatusPass),
+		attempt("p1", "Alice", "00:00:07.0000", models.StatusPass),
+		attempt("p3", "Cleo", "NaN", models.StatusDisqualified),
+		attempt("p1", "Alice", "00:00:07.5000", models.StatusPass),
+		attempt("p2", "Bob", "00:00:12.0000", models.StatusDisqualified),
+	}
+}
+
+func names(standings []Standing) []string {
+	var out []string
+	for _, s := range standings {
+		out = append(out, s.Result.Name)
+	}
+	return out
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Standings
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestStandings_Best(t *testing.T) {
+	standings := Standings(builtin(models.DisciplineBottle), bottleAttempts())
+
+	require.Equal(t, []string{"Alice", "Bob", "Cleo"}, names(standings), "one standing per participant")
+	assert.Equal(t, 1, standings[0].Rank)
+	assert.Equal(t, "00:00:07.0000", standings[0].Result.Time)
+	assert.Len(t, standings[0].Others, 2)
+	assert.Equal(t, "00:00:09.0000", standings[0].Others[0].Time, "other attempts keep their recorded order")
+
+	assert.Equal(t, 2, standings[1].Rank)
+	assert.Equal(t, "00:00:08.0000", standings[1].Result.Time)
+
+	assert.Equal(t, 0, standings[2].Rank, "no passed attempt, no rank")
+	assert.Equal(t, models.StatusDisqualified, standings[2].Result.Status)
+}
+
+func TestStandings_Last(t *testing.T) {
+	def := builtin(models.DisciplineBottle)
+	def.Attempts = models.CountLast
+	standings := Standings(def, bottleAttempts())
+
+	require.Equal(t, []string{"Alice", "Bob", "Cleo"}, names(standings))
+	assert.Equal(t, "00:00:07.5000", standings[0].Result.Time)
+	assert.Equal(t, 0, standings[1].Rank, "Bob's last attempt was disqualified")
+	assert.Equal(t, models.StatusDisqualified, standings[1].Result.Status)
+}
+
+func TestStandings_Average(t *testing.T) {
+	def := builtin(models.DisciplineBottle)
+	def.Attempts, def.AverageOf = models.CountAverage, 2
+	standings := Standings(def, bottleAttempts())
+
+	require.Equal(t, []string{"Alice", "Bob", "Cleo"}, names(standings))
+	assert.Equal(t, 1, standings[0].Rank)
+	assert.Equal(t, "00:00:07.2500", standings[0].Result.Time, "mean of the two fastest")
+	assert.Equal(t, "average of 2", standings[0].Result.Comment)
+	assert.Len(t, standings[0].Others, 3, "every attempt is listed under an average")
+
+	assert.Equal(t, 0, standings[1].Rank)
+	assert.Equal(t, "00:00:08.0000", standings[1].Result.Time)
+	assert.Equal(t, "1 of 2 passed attempts for the average", standings[1].Note)
+}
+
+func TestStandings_TeamsAndVoided(t *testing.T) {
+	results := []models.Result{
+		{TeamID: "t1", Name: "Red", Time: "00:00:30.0000", Status: models.StatusPass},
+		{TeamID: "t2", Name: "Blue", Time: "00:00:20.0000", Status: models.StatusPass, Voided: true},
+		{TeamID: "t2", Name: "Blue", Time: "00:00:40.0000", Status: models.StatusPass},
+		{TeamID: "t1", Name: "Red", Time: "00:00:25.0000", Status: models.StatusPass},
+	}
+	standings := Standings(builtin(models.DisciplineBierStaphette), results)
+
+	require.Equal(t, []string{"Red", "Blue"}, names(standings))
+	assert.Equal(t, "00:00:25.0000", standings[0].Result.Time)
+	assert.Equal(t, "00:00:40.0000", standings[1].Result.Time, "the voided attempt does not count")
+	assert.Empty(t, standings[1].Others)
+}
+
+func TestStandings_RankByBase(t *testing.T) {
+	results := []models.Result{
+		{ParticipantID: "p1", Name: "Alice", Time: "00:00:09.0000", BaseTime: "00:00:06.0000", Status: models.StatusPass},
+		{ParticipantID: "p2", Name: "Bob", Time: "00:00:08.0000", BaseTime: "00:00:08.0000", Status: models.StatusPass},
+	}
+	standings := Standings(models.DisciplineDef{Ranking: models.RankByBase}, results)
+	assert.Equal(t, []string{"Alice", "Bob"}, names(standings))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Tie-breaks
+// ─────────────────────────────────────────────────────────────────────────────
+
+// tiedAttempts has Alice and Bob tie on 7.0 in the best attempt: Bob with
+// less penalty, Alice with the faster second attempt and the earlier 7.0.
+func tiedAttempts() []models.Result {
+	alice := attempt("p1", "Alice", "00:00:07.0000", models.StatusPass)
+	alice.AdditionalTime = "00:00:01.0000"
+	bob := attempt("p2", "Bob", "00:00:07.0000", models.StatusPass)
+	return []models.Result{
+		attempt("p3", "Cleo", "00:00:09.0000", models.StatusPass),
+		attempt("p2", "Bob", "00:00:08.5000", models.StatusPass),
+		alice,
+		bob,
+		attempt("p1", "Alice", "00:00:08.0000", models.StatusPass),
+	}
+}
+
+func places(standings []Standing) []string {
+	var out []string
+	for _, s := range standings {
+		out = append(out, s.Result.Name+" "+s.Place())
+	}
+	return out
+}
+
+func TestStandings_TieShared(t *testing.T) {
+	standings := Standings(builtin(models.DisciplineBottle), tiedAttempts())
+	assert.Equal(t, []string{"Bob T-1", "Alice T-1", "Cleo 3"}, places(standings),
+		"without tie-break rules a tie is shared and the next placing skipped")
+	assert.True(t, standings[0].Tied)
+	assert.False(t, standings[2].Tied)
+}
+
+func TestStandings_TieBreakChain(t *testing.T) {
+	def := builtin(models.DisciplineBottle)
+	cases := map[string][]string{
+		models.TieBreakPenalty: {"Bob 1", "Alice 2", "Cleo 3"},
+		models.TieBreakSecond:  {"Alice 1", "Bob 2", "Cleo 3"},
+		models.TieBreakEarlier: {"Alice 1", "Bob 2", "Cleo 3"},
+	}
+	for rule, want := range cases {
+		def.TieBreak = []string{rule}
+		assert.Equal(t, want, places(Standings(def, tiedAttempts())), rule)
+	}
+
+	// A rule that cannot decide passes the tie on to the next one
+	results := tiedAttempts()
+	results[2].AdditionalTime = ""
+	def.TieBreak = []string{models.TieBreakPenalty, models.TieBreakSecond, models.TieBreakShared}
+	assert.Equal(t, []string{"Alice 1", "Bob 2", "Cleo 3"}, places(Standings(def, results)))
+}
+
+func TestStandings_TieBreakHandTimes(t *testing.T) {
+	// Hand-timed results are only judged to the tenth of a second
+	results := []models.Result{
+		attempt("p1", "Alice", "00:00:07.1", models.StatusPass),
+		attempt("p2", "Bob", "00:00:07.1000", models.StatusPass),
+	}
+	def := builtin(models.DisciplineBottle)
+	def.TieBreak = []string{models.TieBreakEarlier}
+	assert.Equal(t, []string{"Alice 1", "Bob 2"}, places(Standings(def, results)))
+}
@@ -0,0 +1,280 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// Record scopes: the results a record is the fastest of.
+const (
+	RecordContest = "contest"  // within one contest
+	RecordProgram = "program"  // of a program, across all contests
+	RecordTeam    = "team"     // of a team, across all contests
+	RecordAllTime = "all-time" // across all contests
+)
+
+// recordScopes lists the scopes widest first, the order records are
+// announced in.
+var recordScopes = []string{RecordAllTime, RecordProgram, RecordTeam, RecordContest}
+
+// Record is the fastest pass of a discipline within a scope.
+type Record struct {
+	Discipline string `json:"discipline"`
+	Scope      string `json:"scope"`
+	Holder     string `json:"holder,omitempty"` // the program or team whose record it is
+	Name       string `json:"name"`             // who set it
+	Time       string `json:"time"`             // the ranking time
+	Contest    string `json:"contest"`          // the contest folder it was set in
+	ResultID   string `json:"result_id,omitempty"`
+	// Previous is the time of the record it broke; empty for the first
+	// record of its kind.
+	Previous string `json:"previous,omitempty"`
+}
+
+// Label names the record, e.g. "all-time record" or "program record (D)".
+func (r Record) Label() string {
+	if r.Holder != "" {
+		return fmt.Sprintf("%s record (%s)", r.Scope, r.Holder)
+	}
+	return r.Scope + " record"
+}
+
+// RecordSummary flags the records a result broke, e.g. "NEW RECORD:
+// all-time record, contest record"; empty if there are none.
+func RecordSummary(records []Record) string {
+	if len(records) == 0 {
+		return ""
+	}
+	labels := make([]string, len(records))
+	for i, r := range records {
+		labels[i] = r.Label()
+	}
+	return "NEW RECORD: " + strings.Join(labels, ", ")
+}
+
+// RecordBook keeps the records of every scope as contests and results are
+// added, oldest first. A pass breaks a record only by being faster; the
+// first pass of its kind sets the record without breaking one.
+type RecordBook struct {
+	records map[string]Record
+	// History lists every program, team and all-time record in the order
+	// they were set.
+	History []Record
+	broken  map[string][]Record // by contest and result ID
+	contest string              // the contest added last
+}
+
+// NewRecordBook creates an empty record book.
+func NewRecordBook() *RecordBook {
+	return &RecordBook{records: make(map[string]Record), broken: make(map[string][]Record)}
+}
+
+// Clone returns a copy of the book that can be added to without changing b.
+func (b *RecordBook) Clone() *RecordBook {
+	c := NewRecordBook()
+	for k, r := range b.records {
+		c.records[k] = r
+	}
+	for k, rs := range b.broken {
+		c.broken[k] = rs
+	}
+	c.History = append([]Record(nil), b.History...)
+	c.contest = b.contest
+	return c
+}
+
+// AddContest adds the results of a contest in recorded order. Voided results
+// and disciplines the contest does not define are left out.
+func (b *RecordBook) AddContest(c SeriesContest) {
+	registry := c.Disciplines
+	if registry == nil {
+		registry = DefaultDisciplineRegistry()
+	}
+	holders := recordHolders(c.Participants, c.Teams)
+	b.contest = c.Folder
+	for _, r := range c.Results {
+		def, ok := registry.Get(r.Discipline)
+		if !ok || r.Voided {
+			continue
+		}
+		program, team := holders(r)
+		b.Add(c.Folder, def, r, program, team)
+	}
+}
+
+// Add checks a result of contest against the records of its discipline and
+// keeps the records it sets. program and team are those the competitor
+// belongs to; either may be empty. It returns the records the result broke,
+// widest scope first.
+func (b *RecordBook) Add(contest string, def models.DisciplineDef, r models.Result, program, team string) []Record {
+	set := b.Check(contest, def, r, program, team)
+	b.contest = contest
+	var broken []Record
+	for _, rec := range set {
+		b.records[recordKey(rec)] = rec
+		if rec.Scope != RecordContest {
+			b.History = append(b.History, rec)
+		}
+		if rec.Previous != "" {
+			broken = append(broken, rec)
+		}
+	}
+	if len(broken) > 0 && r.ID != "" {
+		b.broken[contest+"\x00"+r.ID] = broken
+	}
+	return broken
+}
+
+// Check returns the records a result of contest would set if it were added
+// now, widest scope first, without keeping them. Only passes with a valid
+// ranking time set records.
+func (b *RecordBook) Check(contest string, def models.DisciplineDef, r models.Result, program, team string) []Record {
+	if !validAttempt(def, r) {
+		return nil
+	}
+	t := RankingTime(def, r)
+	var set []Record
+	for _, scope := range recordScopes {
+		rec := Record{Discipline: def.Name, Scope: scope, Name: r.Name, Time: utils.FormatComparisonTime(t), Contest: contest, ResultID: r.ID}
+		switch scope {
+		case RecordProgram:
+			rec.Holder = program
+		case RecordTeam:
+			rec.Holder = team
+		}
+		if (scope == RecordProgram || scope == RecordTeam) && rec.Holder == "" {
+			continue
+		}
+		prev, ok := b.records[recordKey(rec)]
+		if ok && scope == RecordContest && prev.Contest != contest {
+			ok = false // a new contest starts without contest records
+		}
+		if ok {
+			if t >= utils.ParseTimeForComparison(prev.Time) {
+				continue
+			}
+			rec.Previous = prev.Time
+		}
+		set = append(set, rec)
+	}
+	return set
+}
+
+// BrokenBy returns the records the result of contest with the given ID broke
+// when it was added, widest scope first.
+func (b *RecordBook) BrokenBy(contest, resultID string) []Record {
+	if resultID == "" {
+		return nil
+	}
+	return b.broken[contest+"\x00"+resultID]
+}
+
+// Records returns the current records of a scope ordered by discipline and
+// holder. Contest records are those of the contest added last.
+func (b *RecordBook) Records(scope string) []Record {
+	var out []Record
+	for _, r := range b.records {
+		if r.Scope != scope {
+			continue
+		}
+		if scope == RecordContest && r.Contest != b.contest {
+			continue
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Discipline != out[j].Discipline {
+			return out[i].Discipline < out[j].Discipline
+		}
+		return out[i].Holder < out[j].Holder
+	})
+	return out
+}
+
+// recordKey identifies a record: scope, holder and discipline. Holders are
+// matched ignoring case, as names are typed in again for every contest.
+func recordKey(r Record) string {
+	return r.Scope + "\x00" + strings.ToLower(strings.TrimSpace(r.Holder)) + "\x00" + r.Discipline
+}
+
+// recordHolders returns a function giving the program and team of a result:
+// the participant's, or for a team result the team's program and name.
+func recordHolders(participants []models.Participant, teams []models.Team) func(models.Result) (string, string) {
+	byID := make(map[string]models.Participant, len(participants))
+	byName := make(map[string]models.Participant, len(participants))
+	for _, p := range participants {
+		byID[p.ID] = p
+		byName[p.Name] = p
+	}
+	teamByID := make(map[string]models.Team, len(teams))
+	for _, t := range teams {
+		teamByID[t.ID] = t
+	}
+	return func(r models.Result) (string, string) {
+		if r.IsTeamResult() {
+			t, ok := teamByID[r.TeamID]
+			if !ok {
+				return "", r.Name
+			}
+			return t.Program, t.Name
+		}
+		p, ok := byID[r.ParticipantID]
+		if !ok {
+			p = byName[r.Name]
+		}
+		return p.Program, p.Team
+	}
+}
+
+// RecordHolders returns the program and team a result of a contest counts
+// for in the records.
+func RecordHolders(participants []models.Participant, teams []models.Team, r models.Result) (program, team string) {
+	return recordHolders(participants, teams)(r)
+}
+
+// ContestFolders returns every contest folder under root, oldest first by
+// the date in their name.
+func ContestFolders(root string) ([]string, error) {
+	folders, err := SeriesDef{Contests: []string{"*"}}.Folders(root)
+	if err != nil {
+		return nil, err
+	}
+	var contests []string
+	for _, f := range folders {
+		if folderDate(f) != "" {
+			contests = append(contests, f)
+		}
+	}
+	return contests, nil
+}
+
+// LoadRecordBook adds every official contest under root but skip, oldest
+// first, to a new record book. Unofficial contests do not count towards
+// records. Contests that cannot be read are left out and reported in the
+// error, which leaves the book usable.
+func LoadRecordBook(root, skip string) (*RecordBook, error) {
+	b := NewRecordBook()
+	folders, err := ContestFolders(root)
+	if err != nil {
+		return b, err
+	}
+	var errs []error
+	for _, folder := range folders {
+		if filepath.Base(skip) == folder || !RulesFor(folder).Counted {
+			continue
+		}
+		c, err := LoadSeriesContest(root, folder)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		b.AddContest(c)
+	}
+	return b, errors.Join(errs...)
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordContest is a Bottle contest of Alice (program D, team Red) and Bob
+// (program E, team Blue) with results given as name and time pairs.
+func recordContest(folder string, results ...string) SeriesContest {
+	c := SeriesContest{Folder: folder, Participants: []models.Participant{
+		{ID: folder + "a", Name: "Alice", Program: "D", Team: "Red"},
+		{ID: folder + "b", Name: "Bob", Program: "E", Team: "Blue"},
+	}}
+	for i := 0; i < len(results); i += 2 {
+		id := c.Participants[0].ID
+		if results[i] == "Bob" {
+			id = c.Participants[1].ID
+		}
+		c.Results = append(c.Results, models.Result{ID: folder + results[i+1], ParticipantID: id, Name: results[i],
+			Discipline: models.DisciplineBottle, Time: results[i+1], Status: models.StatusPass})
+	}
+	return c
+}
+
+func labels(records []Record) []string {
+	var out []string
+	for _, r := range records {
+		out = append(out, r.Label())
+	}
+	return out
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RecordBook
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestRecordBook_AddContest(t *testing.T) {
+	b := NewRecordBook()
+	b.AddContest(recordContest("Spring_2026-03-01_Official", "Alice", "00:00:09.0000", "Bob", "00:00:08.0000", "Alice", "00:00:07.5000"))
+
+	spring := "Spring_2026-03-01_Official"
+	assert.Empty(t, b.BrokenBy(spring, spring+"00:00:09.0000"), "the first pass sets records without breaking any")
+	assert.Equal(t, []string{"all-time record", "contest record"}, labels(b.BrokenBy(spring, spring+"00:00:08.0000")),
+		"Bob's first program and team records break nothing")
+	broken := b.BrokenBy(spring, spring+"00:00:07.5000")
+	assert.Equal(t, []string{"all-time record", "program record (D)", "team record (Red)", "contest record"}, labels(broken))
+	assert.Equal(t, "00:00:08.0000", broken[0].Previous)
+	assert.Equal(t, "00:00:09.0000", broken[1].Previous)
+
+	b.AddContest(recordContest("Summer_2026-06-01_Official", "Bob", "00:00:07.8000"))
+	assert.Equal(t, []string{"program record (E)", "team record (Blue)"}, labels(b.BrokenBy("Summer_2026-06-01_Official", "Summer_2026-06-01_Official00:00:07.8000")),
+		"slower than the all-time record; the first pass of a contest breaks no contest record")
+
+	allTime := b.Records(RecordAllTime)
+	require.Len(t, allTime, 1)
+	assert.Equal(t, "Alice", allTime[0].Name)
+	assert.Equal(t, spring, allTime[0].Contest)
+	contest := b.Records(RecordContest)
+	require.Len(t, contest, 1)
+	assert.Equal(t, "Bob", contest[0].Name, "contest records are those of the last contest")
+	assert.Len(t, b.Records(RecordProgram), 2)
+
+	var history []string
+	for _, r := range b.History {
+		history = append(history, r.Label()+" "+r.Time)
+	}
+	assert.Equal(t, []string{
+		"all-time record 00:00:09.0000", "program record (D) 00:00:09.0000", "team record (Red) 00:00:09.0000",
+		"all-time record 00:00:08.0000", "program record (E) 00:00:08.0000", "team record (Blue) 00:00:08.0000",
+		"all-time record 00:00:07.5000", "program record (D) 00:00:07.5000", "team record (Red) 00:00:07.5000",
+		"program record (E) 00:00:07.8000", "team record (Blue) 00:00:07.8000",
+	}, history)
+}
+
+func TestRecordBook_Check(t *testing.T) {
+	b := NewRecordBook()
+	b.AddContest(recordContest("Spring_2026-03-01_Official", "Alice", "00:00:08.0000"))
+	def := builtin(models.DisciplineBottle)
+
+	r := models.Result{Name: "Bob", Discipline: models.DisciplineBottle, Time: "00:00:08.0000", Status: models.StatusPass}
+	for _, rec := range b.Check("Spring_2026-03-01_Official", def, r, "D", "") {
+		assert.Empty(t, rec.Previous, "equalling a record does not break it")
+	}
+
+	r.Time = "00:00:07.0000"
+	set := b.Check("Spring_2026-03-01_Official", def, r, "d", "")
+	assert.Equal(t, []string{"all-time record", "program record (d)", "contest record"}, labels(set))
+	assert.Equal(t, "00:00:08.0000", set[1].Previous, "programs are matched ignoring case")
+	assert.Equal(t, "Alice", b.Records(RecordAllTime)[0].Name, "checking keeps nothing")
+
+	r.Status = models.StatusDisqualified
+	assert.Empty(t, b.Check("Spring_2026-03-01_Official", def, r, "D", ""), "only passes set records")
+}
+
+func TestRecordBook_Clone(t *testing.T) {
+	b := NewRecordBook()
+	b.AddContest(recordContest("Spring_2026-03-01_Official", "Alice", "00:00:08.0000"))
+	c := b.Clone()
+	c.AddContest(recordContest("Summer_2026-06-01_Official", "Bob", "00:00:07.0000"))
+
+	assert.Equal(t, "Bob", c.Records(RecordAllTime)[0].Name)
+	assert.Equal(t, "Alice", b.Records(RecordAllTime)[0].Name)
+	assert.Len(t, b.History, 3)
+}
+
+func TestRecordSummary(t *testing.T) {
+	assert.Empty(t, RecordSummary(nil))
+	assert.Equal(t, "NEW RECORD: all-time record, team record (Red)",
+		RecordSummary([]Record{{Scope: RecordAllTime}, {Scope: RecordTeam, Holder: "Red"}}))
+}
+
+func TestLoadRecordBook(t *testing.T) {
+	root := t.TempDir()
+	for _, c := range []SeriesContest{
+		recordContest("Summer_2026-06-01_Official", "Bob", "00:00:07.0000"),
+		recordContest("Spring_2026-03-01_Official", "Alice", "00:00:08.0000"),
+		recordContest("Party_2026-04-01_Unofficial", "Cleo", "00:00:05.0000"),
+	} {
+		store := NewJSONStore(ContestStorePaths(filepath.Join(root, c.Folder, config.ContestDirectory), BackendJSON))
+		require.NoError(t, store.SaveParticipants(c.Participants))
+		require.NoError(t, store.SaveResults(c.Results))
+	}
+
+	b, err := LoadRecordBook(root, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all-time record"}, labels(b.BrokenBy("Summer_2026-06-01_Official", "Summer_2026-06-01_Official00:00:07.0000")),
+		"contests are added oldest first and unofficial ones are left out")
+
+	b, err = LoadRecordBook(root, filepath.Join(root, "Summer_2026-06-01_Official"))
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", b.Records(RecordAllTime)[0].Name, "the skipped contest is left out")
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// RelaySplits turns the times captured at each changeover of a relay into
+// per-leg splits. marks are cumulative times since the start, one per leg;
+// the last one is the team's total. members are the runners in leg order.
+func RelaySplits(members []models.Participant, marks []string) ([]models.Split, error) {
+	if len(marks) != len(members) {
+		return nil, fmt.Errorf("relay has %d legs but %d split(s) were captured", len(members), len(marks))
+	}
+
+	splits := make([]models.Split, len(members))
+	var previous int64
+	for i, mark := range marks {
+		t := utils.ParseTimeForComparison(utils.ParseAndPadTimeString(mark))
+		if t < 0 {
+			return nil, fmt.Errorf("split %d has no valid time: %s", i+1, mark)
+		}
+		if t < previous {
+			return nil, fmt.Errorf("split %d (%s) is earlier than split %d", i+1, mark, i)
+		}
+		splits[i] = models.Split{
+			ParticipantID: members[i].ID,
+			Name:          members[i].Name,
+			Time:          utils.FormatComparisonTime(t - previous),
+		}
+		previous = t
+	}
+	return splits, nil
+}
+
+// Leg is a single relay leg taken out of a team result, for the fastest-leg
+// lists.
+type Leg struct {
+	TeamID        string
+	Team          string
+	Leg           int // 1-based position in the running order
+	ParticipantID string
+	Name          string
+	Time          string
+}
+
+// FastestLegs returns every leg of the passed, non-voided results of the
+// given relay disciplines, fastest first.
+func FastestLegs(results []models.Result, disciplines ...string) []Leg {
+	relay := make(map[string]bool, len(disciplines))
+	for _, d := range disciplines {
+		relay[d] = true
+	}
+	var legs []Leg
+	for _, r := range results {
+		if r.Voided || r.Status != models.StatusPass || !relay[r.Discipline] {
+			continue
+		}
+		for i, s := range r.Splits {
+			legs = append(legs, Leg{
+				TeamID:        r.TeamID,
+				Team:          r.Name,
+				Leg:           i + 1,
+				ParticipantID: s.ParticipantID,
+				Name:          s.Name,
+				Time:          s.Time,
+			})
+		}
+	}
+
+	sort.SliceStable(legs, func(i, j int) bool {
+		ti, tj := utils.ParseTimeForComparison(legs[i].Time), utils.ParseTimeForComparison(legs[j].Time)
+		if ti != tj {
+			return ti < tj
+		}
+		return legs[i].Name < legs[j].Name
+	})
+	return legs
+}
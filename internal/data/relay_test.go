@@ -0,0 +1,92 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// RelaySplits
+// ─────────────────────────────────────────────────────────────────────────────
+
+var relayMembers = []models.Participant{
+	{ID: "p1", Name: "Alice"},
+	{ID: "p2", Name: "Bob"},
+	{ID: "p3", Name: "Carol"},
+}
+
+func TestRelaySplits(t *testing.T) {
+	splits, err := RelaySplits(relayMembers, []string{"00:00:05.0000", "00:00:11.5000", "00:00:15.2500"})
+	require.NoError(t, err)
+	require.Len(t, splits, 3)
+
+	assert.Equal(t, models.Split{ParticipantID: "p1", Name: "Alice", Time: "00:00:05.0000"}, splits[0])
+	assert.Equal(t, "00:00:06.5000", splits[1].Time)
+	assert.Equal(t, "00:00:03.7500", splits[2].Time)
+}
+
+func TestRelaySplits_Invalid(t *testing.T) {
+	_, err := RelaySplits(relayMembers, []string{"00:00:05.0000", "00:00:11.5000"})
+	assert.Error(t, err, "one split per leg")
+
+	_, err = RelaySplits(relayMembers, []string{"00:00:05.0000", "00:00:04.0000", "00:00:15.0000"})
+	assert.Error(t, err, "splits must not go backwards")
+
+	_, err = RelaySplits(relayMembers, []string{"00:00:05.0000", "NaN", "00:00:15.0000"})
+	assert.Error(t, err)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// FastestLegs
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestFastestLegs(t *testing.T) {
+	results := []models.Result{
+		{TeamID: "t1", Name: "Red", Discipline: models.DisciplineBierStaphette, Status: models.StatusPass, Splits: []models.Split{
+			{ParticipantID: "p1", Name: "Alice", Time: "00:00:05.0000"},
+			{ParticipantID: "p2", Name: "Bob", Time: "00:00:03.0000"},
+		}},
+		{TeamID: "t2", Name: "Blue", Discipline: models.DisciplineBierStaphette, Status: models.StatusPass, Splits: []models.Split{
+			{ParticipantID: "p3", Name: "Carol", Time: "00:00:04.0000"},
+		}},
+		// Disqualified, voided and other disciplines do not count
+		{TeamID: "t3", Name: "Green", Discipline: models.DisciplineBierStaphette, Status: models.StatusDisqualified, Splits: []models.Split{
+			{Name: "Dave", Time: "00:00:01.0000"},
+		}},
+		{TeamID: "t1", Name: "Red", Discipline: models.DisciplineBierStaphette, Status: models.StatusPass, Voided: true, Splits: []models.Split{
+			{Name: "Alice", Time: "00:00:01.0000"},
+		}},
+		{TeamID: "t1", Name: "Red", Discipline: models.DisciplineMegaMedley, Status: models.StatusPass, Splits: []models.Split{
+			{Name: "Alice", Time: "00:00:01.0000"},
+		}},
+	}
+
+	legs := FastestLegs(results, models.DisciplineBierStaphette)
+	require.Len(t, legs, 3)
+	assert.Equal(t, Leg{TeamID: "t1", Team: "Red", Leg: 2, ParticipantID: "p2", Name: "Bob", Time: "00:00:03.0000"}, legs[0])
+	assert.Equal(t, "Carol", legs[1].Name)
+	assert.Equal(t, "Alice", legs[2].Name)
+}
+
+func TestResultManager_SplitsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+
+	splits, err := RelaySplits(relayMembers[:2], []string{"00:00:05.0000", "00:00:09.0000"})
+	require.NoError(t, err)
+	require.NoError(t, rm.AddResult(models.Result{TeamID: "t1", Name: "Red", Discipline: models.DisciplineBierStaphette,
+		BaseTime: "00:00:09.0000", Status: models.StatusPass, Splits: splits}))
+	require.NoError(t, rm.SaveResults())
+
+	// The splits are rebuilt from the journal like the rest of the result
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	require.Len(t, rm2.GetResults(), 1)
+	assert.Equal(t, splits, rm2.GetResults()[0].Splits)
+}
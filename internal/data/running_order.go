@@ -0,0 +1,182 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// RunningOrderPathFor returns the running order file of a contest, stored
+// next to its participant file.
+func RunningOrderPathFor(participantFile string) string {
+	return filepath.Join(filepath.Dir(participantFile), config.RunningOrderFileName)
+}
+
+// LoadRunningOrders reads a running order file: a JSON array with a running
+// order per discipline. A missing file means nothing has been drawn or
+// skipped yet.
+func LoadRunningOrders(path string) ([]models.RunningOrder, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading running orders %s: %w", path, err)
+	}
+	var orders []models.RunningOrder
+	if err := json.Unmarshal(content, &orders); err != nil {
+		return nil, fmt.Errorf("error parsing running orders %s: %w", path, err)
+	}
+	return orders, nil
+}
+
+// SaveRunningOrders writes the running orders of a contest to path.
+func SaveRunningOrders(path string, orders []models.RunningOrder) error {
+	if err := utils.SaveJSONFile(path, orders); err != nil {
+		return fmt.Errorf("error saving running orders %s: %w", path, err)
+	}
+	return nil
+}
+
+// FindRunningOrder returns the position of the running order of discipline,
+// or -1.
+func FindRunningOrder(orders []models.RunningOrder, discipline string) int {
+	for i, o := range orders {
+		if o.Discipline == discipline {
+			return i
+		}
+	}
+	return -1
+}
+
+// DrawRunningOrder draws the order of ids at random from seed. The draw does
+// not depend on the order ids are given in: the same seed and the same field
+// always draw the same order.
+func DrawRunningOrder(discipline string, ids []string, seed int64) models.RunningOrder {
+	order := append([]string(nil), ids...)
+	sort.Strings(order)
+	r := rand.New(rand.NewSource(seed))
+	r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return models.RunningOrder{Discipline: discipline, Seed: seed, Order: order}
+}
+
+// ArrangeByRunningOrder returns ids in the running order of o. The IDs the
+// order does not hold, such as late entries, follow in the order given, and
+// the IDs of the order that are not in ids are left out.
+func ArrangeByRunningOrder(o models.RunningOrder, ids []string) []string {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	arranged := make([]string, 0, len(ids))
+	placed := make(map[string]bool, len(ids))
+	for _, id := range o.Order {
+		if wanted[id] && !placed[id] {
+			arranged = append(arranged, id)
+			placed[id] = true
+		}
+	}
+	for _, id := range ids {
+		if !placed[id] {
+			arranged = append(arranged, id)
+			placed[id] = true
+		}
+	}
+	return arranged
+}
+
+// MoveInRunningOrder moves the competitor at position from of the field ids,
+// arranged by o, to position to, and keeps the field in that order.
+func MoveInRunningOrder(o *models.RunningOrder, ids []string, from, to int) error {
+	order := ArrangeByRunningOrder(*o, ids)
+	if from < 0 || from >= len(order) || to < 0 || to >= len(order) {
+		return fmt.Errorf("cannot move position %d to %d of %d", from+1, to+1, len(order))
+	}
+	id := order[from]
+	order = append(order[:from], order[from+1:]...)
+	order = append(order[:to], append([]string{id}, order[to:]...)...)
+	o.Order = order
+	return nil
+}
+
+// IsSkipped reports whether id is on the skipped list of o.
+func IsSkipped(o models.RunningOrder, id string) bool {
+	for _, s := range o.Skipped {
+		if s == id {
+			return true
+		}
+	}
+	return false
+}
+
+// StartList formats the running order o as a numbered start list for the
+// MC: every participant with program and team, or every team with program
+// and roster, in the order they run. Skipped competitors are marked.
+func StartList(o models.RunningOrder, participants []models.Participant, teams []models.Team) string {
+	var sb strings.Builder
+	title := "START LIST - " + o.Discipline
+	sb.WriteString(title + "\n")
+	sb.WriteString(strings.Repeat("=", len(title)) + "\n")
+	if len(o.Order) > 0 {
+		sb.WriteString(fmt.Sprintf("Drawn with seed %d\n", o.Seed))
+	}
+	sb.WriteString("\n")
+
+	n := 0
+	for _, id := range o.Order {
+		var name, details string
+		if p := findParticipant(participants, id); p != nil {
+			name, details = p.Name, strings.Join(nonEmpty(p.Program, p.Team), " / ")
+		} else if t := findTeam(teams, id); t != nil {
+			name, details = t.Name, strings.Join(nonEmpty(t.Program, strings.Join(RosterNames(*t, participants), ", ")), " / ")
+		} else {
+			continue
+		}
+		n++
+		line := fmt.Sprintf("%3d. %-30s %s", n, name, details)
+		if IsSkipped(o, id) {
+			line += " (skipped)"
+		}
+		sb.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	if n == 0 {
+		sb.WriteString("No running order has been drawn.\n")
+	}
+	return sb.String()
+}
+
+func findParticipant(participants []models.Participant, id string) *models.Participant {
+	for i := range participants {
+		if participants[i].ID == id {
+			return &participants[i]
+		}
+	}
+	return nil
+}
+
+func findTeam(teams []models.Team, id string) *models.Team {
+	for i := range teams {
+		if teams[i].ID == id {
+			return &teams[i]
+		}
+	}
+	return nil
+}
+
+func nonEmpty(values ...string) []string {
+	var kept []string
+	for _, v := range values {
+		if v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Drawing and arranging
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestDrawRunningOrder_Reproducible(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	o := DrawRunningOrder(models.DisciplineBottle, ids, 42)
+	assert.Equal(t, models.DisciplineBottle, o.Discipline)
+	assert.Equal(t, int64(42), o.Seed)
+	assert.ElementsMatch(t, ids, o.Order)
+
+	again := DrawRunningOrder(models.DisciplineBottle, []string{"h", "g", "f", "e", "d", "c", "b", "a"}, 42)
+	assert.Equal(t, o.Order, again.Order, "the same seed and field draw the same order")
+
+	different := false
+	for seed := int64(1); seed <= 5 && !different; seed++ {
+		different = !assert.ObjectsAreEqual(o.Order, DrawRunningOrder(models.DisciplineBottle, ids, seed).Order)
+	}
+	assert.True(t, different, "other seeds draw other orders")
+}
+
+func TestArrangeByRunningOrder(t *testing.T) {
+	o := models.RunningOrder{Order: []string{"c", "gone", "a", "b"}}
+	assert.Equal(t, []string{"c", "a", "b", "late"}, ArrangeByRunningOrder(o, []string{"a", "late", "b", "c"}),
+		"late entries follow the drawn field and those no longer in the field are left out")
+	assert.Equal(t, []string{"b", "a"}, ArrangeByRunningOrder(models.RunningOrder{}, []string{"b", "a"}),
+		"without a draw the given order is kept")
+}
+
+func TestMoveInRunningOrder(t *testing.T) {
+	o := models.RunningOrder{Order: []string{"a", "b", "c"}}
+	ids := []string{"a", "b", "c", "d"}
+
+	require.NoError(t, MoveInRunningOrder(&o, ids, 3, 0))
+	assert.Equal(t, []string{"d", "a", "b", "c"}, o.Order)
+	require.NoError(t, MoveInRunningOrder(&o, ids, 1, 2))
+	assert.Equal(t, []string{"d", "b", "a", "c"}, o.Order)
+
+	assert.Error(t, MoveInRunningOrder(&o, ids, 0, 4))
+	assert.Error(t, MoveInRunningOrder(&o, ids, -1, 0))
+}
+
+func TestStartList(t *testing.T) {
+	participants := []models.Participant{
+		{ID: "p1", Name: "Alice", Program: "F", Team: "Red"},
+		{ID: "p2", Name: "Bob", Program: "D"},
+	}
+	o := models.RunningOrder{Discipline: models.DisciplineBottle, Seed: 7, Order: []string{"p2", "gone", "p1"}, Skipped: []string{"p1"}}
+	list := StartList(o, participants, nil)
+	assert.Contains(t, list, "START LIST - Bottle")
+	assert.Contains(t, list, "Drawn with seed 7")
+	assert.Regexp(t, `  1\. Bob +D\n`, list)
+	assert.Regexp(t, `  2\. Alice +F / Red \(skipped\)\n`, list)
+
+	teams := []models.Team{{ID: "t1", Name: "Red", Program: "F", Members: []string{"p1"}}}
+	list = StartList(models.RunningOrder{Discipline: models.DisciplineTeamClash, Order: []string{"t1"}}, participants, teams)
+	assert.Regexp(t, `  1\. Red +F / Alice\n`, list)
+
+	assert.Contains(t, StartList(models.RunningOrder{Discipline: models.DisciplineBottle}, participants, nil), "No running order has been drawn")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Running order file
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestLoadSaveRunningOrders(t *testing.T) {
+	path := RunningOrderPathFor(filepath.Join(t.TempDir(), "participants.json"))
+	orders, err := LoadRunningOrders(path)
+	require.NoError(t, err)
+	assert.Empty(t, orders, "a missing file means nothing has been drawn")
+
+	o := DrawRunningOrder(models.DisciplineBottle, []string{"p1", "p2", "p3"}, 1234)
+	o.Skipped = []string{"p2"}
+	require.NoError(t, SaveRunningOrders(path, []models.RunningOrder{o}))
+	orders, err = LoadRunningOrders(path)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, o, orders[0])
+	assert.True(t, IsSkipped(orders[0], "p2"))
+	assert.False(t, IsSkipped(orders[0], "p1"))
+	assert.Equal(t, 0, FindRunningOrder(orders, models.DisciplineBottle))
+	assert.Equal(t, -1, FindRunningOrder(orders, models.DisciplineFullTankard))
+}
@@ -0,0 +1,385 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// Schemas of the versioned data files. Each file is written as
+//
+//	{"schema": "chugware/results", "version": 7, "data": [ ...records... ]}
+//
+// Version 1 is the original bare JSON array of string maps without an
+// envelope; it is still read and upgraded in memory. Version 3 added
+// participant IDs, version 4 teams and team results, version 5 relay splits;
+// from version 6 participants store the tries they are entitled to instead of
+// a counter of the tries left, version 7 added itemized penalties and
+// version 8 participant categories.
+const (
+	SchemaParticipants = "chugware/participants"
+	SchemaResults      = "chugware/results"
+	SchemaTeams        = "chugware/teams"
+
+	// CurrentSchemaVersion is the version written by this build.
+	CurrentSchemaVersion = 8
+)
+
+// envelope is the on-disk wrapper around a data file's records.
+type envelope struct {
+	Schema  string          `json:"schema"`
+	Version int             `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// UnsupportedVersionError is returned for files written by a newer ChugWare,
+// or carrying a schema this build does not know.
+type UnsupportedVersionError struct {
+	Path    string
+	Schema  string
+	Version int
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	if e.Version > CurrentSchemaVersion {
+		return fmt.Sprintf("%s uses %s version %d, but this ChugWare only understands up to version %d – please update ChugWare",
+			e.Path, e.Schema, e.Version, CurrentSchemaVersion)
+	}
+	return fmt.Sprintf("%s has unsupported schema %q version %d", e.Path, e.Schema, e.Version)
+}
+
+// Migration upgrades the records of one schema from version From to From+1.
+// Records are handled as generic JSON objects so a migration never depends on
+// the current Go types. Apply is given the path of the file or database being
+// upgraded for the rare step that needs the rest of the contest.
+type Migration struct {
+	Schema      string
+	From        int
+	Description string
+	Apply       func(path string, records []map[string]any) ([]map[string]any, error)
+}
+
+// migrations is the registry of schema upgrades, applied in order of From.
+var migrations = []Migration{
+	{
+		Schema:      SchemaParticipants,
+		From:        1,
+		Description: "wrap participant list in a versioned envelope; require a name on every row",
+		Apply: func(_ string, records []map[string]any) ([]map[string]any, error) {
+			for i, r := range records {
+				if s, _ := r["name"].(string); s == "" {
+					return nil, fmt.Errorf("participant row %d has no name", i+1)
+				}
+			}
+			return records, nil
+		},
+	},
+	{
+		Schema:      SchemaResults,
+		From:        1,
+		Description: "give every result a stable ID and store the voided flag as a boolean",
+		Apply: func(_ string, records []map[string]any) ([]map[string]any, error) {
+			for i, r := range records {
+				name, _ := r["name"].(string)
+				discipline, _ := r["discipline"].(string)
+				if name == "" || discipline == "" {
+					return nil, fmt.Errorf("result row %d has no name or discipline", i+1)
+				}
+				if id, _ := r["id"].(string); id == "" {
+					r["id"] = LegacyResultID(i, r)
+				}
+				if v, ok := r["voided"].(string); ok {
+					if v == "true" {
+						r["voided"] = true
+					} else {
+						delete(r, "voided")
+					}
+				}
+			}
+			return records, nil
+		},
+	},
+	{
+		Schema:      SchemaParticipants,
+		From:        2,
+		Description: "give every participant a stable ID",
+		Apply: func(_ string, records []map[string]any) ([]map[string]any, error) {
+			for _, r := range records {
+				if id, _ := r["id"].(string); id == "" {
+					name, _ := r["name"].(string)
+					r["id"] = LegacyParticipantID(name)
+				}
+			}
+			return records, nil
+		},
+	},
+	{
+		Schema:      SchemaResults,
+		From:        2,
+		Description: "link every result to its participant's ID, matched by name",
+		Apply: func(_ string, records []map[string]any) ([]map[string]any, error) {
+			for _, r := range records {
+				if id, _ := r["participant_id"].(string); id == "" {
+					name, _ := r["name"].(string)
+					r["participant_id"] = LegacyParticipantID(name)
+				}
+			}
+			return records, nil
+		},
+	},
+}
+
+// The version 6 step reads the contest's results, which are themselves loaded
+// through the registry, so it is registered once the registry exists.
+func init() {
+	migrations = append(migrations, Migration{
+		Schema:      SchemaParticipants,
+		From:        5,
+		Description: "store the tries each participant is entitled to; remaining tries follow from their results",
+		Apply: func(path string, records []map[string]any) ([]map[string]any, error) {
+			taken, err := triesTaken(path)
+			if err != nil {
+				return nil, fmt.Errorf("error reading the result journal to restore try counts: %w", err)
+			}
+			for _, r := range records {
+				id, _ := r["id"].(string)
+				for key, discipline := range map[string]string{
+					"bottle":       models.DisciplineBottle,
+					"half_tankard": models.DisciplineHalfTankard,
+					"full_tankard": models.DisciplineFullTankard,
+				} {
+					n := taken[[2]string{id, discipline}]
+					if n <= 0 {
+						continue
+					}
+					left, _ := r[key].(string)
+					tries, err := strconv.Atoi(left)
+					if err != nil {
+						continue
+					}
+					r[key] = strconv.Itoa(tries + n)
+				}
+			}
+			return records, nil
+		},
+	})
+}
+
+// triesTaken returns how far the try counters of a contest from before
+// version 6 had been lowered, keyed by participant ID and discipline. Every
+// decrement and restore was journalled; attempts from before the journal were
+// imported into it, or are still only in results.json if nothing has been
+// journalled yet. Nothing here changes once a contest is past version 6, so
+// the upgrade gives the same answer each time the old file is read.
+func triesTaken(path string) (map[[2]string]int, error) {
+	entries, err := readJournal(JournalPathFor(path))
+	if err != nil {
+		return nil, err
+	}
+
+	taken := make(map[[2]string]int)
+	var unjournalled []models.Result
+	for _, e := range entries {
+		id := e.ParticipantID
+		if id == "" {
+			id = LegacyParticipantID(e.Name)
+		}
+		switch e.Action {
+		case JournalDecrementTries:
+			taken[[2]string{id, e.Discipline}]++
+		case JournalRestoreTries:
+			taken[[2]string{id, e.Discipline}]--
+		case JournalImport:
+			if e.Result != nil {
+				unjournalled = append(unjournalled, linkedResult(*e.Result))
+			}
+		}
+	}
+	// A database is already open while it is upgraded, so only its journal
+	// is read
+	if len(entries) == 0 && !IsDatabasePath(path) {
+		_, resultFile := ContestStorePaths(filepath.Dir(path), BackendJSON)
+		if unjournalled, err = migrationResults(resultFile); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range unjournalled {
+		if !r.Voided && !r.IsTeamResult() {
+			taken[[2]string{r.ParticipantID, r.Discipline}]++
+		}
+	}
+	return taken, nil
+}
+
+// LegacyParticipantID is the ID given to a participant registered before IDs
+// existed. It is derived from the name, which was unique within a contest
+// back then, so the participant and result files (and the result journal)
+// can each be upgraded on their own and still agree.
+func LegacyParticipantID(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:6])
+}
+
+// LegacyResultID is the ID given to a result recorded before IDs existed. It
+// is derived from the row's position and contents, so a version 1 file that is
+// only ever read, e.g. by htmlgen or the record book, gives its results the
+// same IDs every time it is upgraded in memory.
+func LegacyResultID(index int, record map[string]any) string {
+	// encoding/json writes map keys in sorted order, so equal rows encode
+	// alike
+	contents, _ := json.Marshal(record)
+	sum := sha256.Sum256([]byte(strconv.Itoa(index) + "\x00" + string(contents)))
+	return hex.EncodeToString(sum[:6])
+}
+
+// migrationsFor returns the registered migrations for schema starting at
+// version from, in order.
+func migrationsFor(schema string, from int) []Migration {
+	var steps []Migration
+	for _, m := range migrations {
+		if m.Schema == schema && m.From >= from {
+			steps = append(steps, m)
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].From < steps[j].From })
+	return steps
+}
+
+// decodedFile is a data file read into generic records at the current version.
+type decodedFile struct {
+	FromVersion int
+	Applied     []Migration
+	Records     []map[string]any
+}
+
+// decodeDataFile reads path, checks its schema and version and upgrades the
+// records to CurrentSchemaVersion. A missing file decodes to no records.
+func decodeDataFile(path, schema string) (decodedFile, error) {
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return decodedFile{FromVersion: CurrentSchemaVersion}, nil
+	}
+	if err != nil {
+		return decodedFile{}, fmt.Errorf("error reading file %s: %w", path, err)
+	}
+
+	corrupt := func(err error) error {
+		return &utils.CorruptFileError{
+			Path:   path,
+			Backup: utils.NewestValidBackup(path),
+			Err:    fmt.Errorf("error parsing JSON file %s: %w", path, err),
+		}
+	}
+
+	version := 1
+	payload := raw
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var env envelope
+		if err := json.Unmarshal(trimmed, &env); err != nil {
+			return decodedFile{}, corrupt(err)
+		}
+		if env.Schema != schema || env.Version < 1 || env.Version > CurrentSchemaVersion {
+			return decodedFile{}, &UnsupportedVersionError{Path: path, Schema: env.Schema, Version: env.Version}
+		}
+		version = env.Version
+		payload = env.Data
+	}
+
+	var records []map[string]any
+	if err := json.Unmarshal(payload, &records); err != nil {
+		return decodedFile{}, corrupt(err)
+	}
+
+	file := decodedFile{FromVersion: version, Records: records}
+	for _, m := range migrationsFor(schema, version) {
+		if file.Records, err = m.Apply(path, file.Records); err != nil {
+			return decodedFile{}, fmt.Errorf("error upgrading %s from version %d: %w", path, m.From, err)
+		}
+		file.Applied = append(file.Applied, m)
+	}
+	return file, nil
+}
+
+// decodeRecords converts generic records into typed values.
+func decodeRecords(records []map[string]any, out any) error {
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// encodeDataFile writes records in a current-version envelope.
+func encodeDataFile(path, schema string, records any) error {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+	return utils.SaveJSONFile(path, envelope{Schema: schema, Version: CurrentSchemaVersion, Data: data})
+}
+
+// UpgradeReport describes what UpgradeContest did (or would do) to one file.
+type UpgradeReport struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	Records     int
+	Steps       []string
+}
+
+// NeedsUpgrade reports whether the file is older than CurrentSchemaVersion.
+func (r UpgradeReport) NeedsUpgrade() bool {
+	return r.FromVersion < r.ToVersion
+}
+
+// UpgradeContest upgrades participants.json, results.json and teams.json in
+// contestDir (a contest's contest/ folder) to CurrentSchemaVersion in place. With dryRun set
+// nothing is written and the report only says what would change. Files that
+// are missing or already current are reported but left alone; a file from a
+// newer ChugWare aborts the upgrade with an UnsupportedVersionError.
+func UpgradeContest(contestDir string, dryRun bool) ([]UpgradeReport, error) {
+	participantFile, resultFile := ContestStorePaths(contestDir, BackendJSON)
+	var reports []UpgradeReport
+	for _, f := range []struct{ path, schema string }{
+		{participantFile, SchemaParticipants},
+		{resultFile, SchemaResults},
+		{TeamPathFor(participantFile), SchemaTeams},
+	} {
+		if !utils.DoesFileExist(f.path) {
+			continue
+		}
+		file, err := decodeDataFile(f.path, f.schema)
+		if err != nil {
+			return reports, err
+		}
+		report := UpgradeReport{
+			Path:        f.path,
+			FromVersion: file.FromVersion,
+			ToVersion:   CurrentSchemaVersion,
+			Records:     len(file.Records),
+		}
+		for _, m := range file.Applied {
+			report.Steps = append(report.Steps, fmt.Sprintf("v%d → v%d: %s", m.From, m.From+1, m.Description))
+		}
+		if report.NeedsUpgrade() && !dryRun {
+			records := file.Records
+			if records == nil {
+				records = []map[string]any{}
+			}
+			if err := encodeDataFile(f.path, f.schema, records); err != nil {
+				return reports, err
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
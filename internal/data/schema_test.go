@@ -0,0 +1,316 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// writeLegacyContest writes version-1 (bare array) data files into dir.
+func writeLegacyContest(t *testing.T, dir string) (participantFile, resultFile string) {
+	t.Helper()
+	participantFile, resultFile = ContestStorePaths(dir, BackendJSON)
+	require.NoError(t, os.WriteFile(participantFile,
+		[]byte(`[{"name":"Alice","program":"F","team":"T1","bottle":"Yes","half_tankard":"No","full_tankard":"No"}]`), 0644))
+	require.NoError(t, os.WriteFile(resultFile,
+		[]byte(`[{"name":"Alice","discipline":"Bottle","time":"00:00:05.0000","status":"Pass"},`+
+			`{"id":"keep","name":"Alice","discipline":"Bottle","time":"00:00:09.0000","status":"Pass","voided":"true"}]`), 0644))
+	return participantFile, resultFile
+}
+
+func readEnvelope(t *testing.T, path string) envelope {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var env envelope
+	require.NoError(t, json.Unmarshal(raw, &env))
+	return env
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// decoding
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestJSONStore_UpgradesLegacyFilesInMemory(t *testing.T) {
+	dir := t.TempDir()
+	pFile, rFile := writeLegacyContest(t, dir)
+	store := NewJSONStore(pFile, rFile)
+
+	participants, err := store.LoadParticipants()
+	require.NoError(t, err)
+	require.Len(t, participants, 1)
+	assert.Equal(t, "T1", participants[0].Team)
+
+	results, err := store.LoadResults()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Len(t, results[0].ID, 12, "missing IDs are assigned")
+	assert.False(t, results[0].Voided)
+	assert.Equal(t, "keep", results[1].ID)
+	assert.True(t, results[1].Voided, "string voided flag becomes a bool")
+
+	// Loading never rewrites the file
+	raw, err := os.ReadFile(rFile)
+	require.NoError(t, err)
+	assert.Equal(t, byte('['), raw[0])
+}
+
+func TestJSONStore_LegacyFilesShareParticipantIDs(t *testing.T) {
+	dir := t.TempDir()
+	pFile, rFile := writeLegacyContest(t, dir)
+	store := NewJSONStore(pFile, rFile)
+
+	participants, err := store.LoadParticipants()
+	require.NoError(t, err)
+	results, err := store.LoadResults()
+	require.NoError(t, err)
+
+	// Both files are upgraded independently and still agree on who is who
+	require.Len(t, participants[0].ID, 12)
+	for _, r := range results {
+		assert.Equal(t, participants[0].ID, r.ParticipantID)
+		assert.True(t, r.BelongsTo(participants[0]))
+	}
+}
+
+func TestJSONStore_LegacyResultIDsAreStable(t *testing.T) {
+	dir := t.TempDir()
+	pFile, rFile := ContestStorePaths(dir, BackendJSON)
+	row := `{"name":"Alice","discipline":"Bottle","time":"00:00:05.0000","status":"Pass"}`
+	require.NoError(t, os.WriteFile(rFile, []byte("["+row+","+row+"]"), 0644))
+
+	first, err := NewJSONStore(pFile, rFile).LoadResults()
+	require.NoError(t, err)
+	again, err := NewJSONStore(pFile, rFile).LoadResults()
+	require.NoError(t, err)
+
+	// Read-only consumers each upgrade the file in memory and must agree
+	require.Len(t, first, 2)
+	assert.Equal(t, first[0].ID, again[0].ID)
+	assert.Equal(t, first[1].ID, again[1].ID)
+	assert.NotEqual(t, first[0].ID, first[1].ID, "equal rows get their own IDs")
+}
+
+func TestJSONStore_RefusesNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema":"chugware/results","version":99,"data":[]}`), 0644))
+
+	_, err := NewJSONStore("", path).LoadResults()
+	var unsupported *UnsupportedVersionError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, 99, unsupported.Version)
+	assert.Contains(t, err.Error(), "update ChugWare")
+}
+
+func TestJSONStore_RefusesWrongSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema":"chugware/participants","version":2,"data":[]}`), 0644))
+
+	_, err := NewJSONStore("", path).LoadResults()
+	var unsupported *UnsupportedVersionError
+	assert.ErrorAs(t, err, &unsupported)
+}
+
+func TestJSONStore_LegacyRowWithoutNameFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "participants.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"program":"F"}]`), 0644))
+
+	_, err := NewJSONStore(path, "").LoadParticipants()
+	assert.ErrorContains(t, err, "has no name")
+}
+
+func TestJSONStore_BrokenEnvelopeIsCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"schema":"chugware/res`), 0644))
+
+	_, err := NewJSONStore("", path).LoadResults()
+	_, ok := utils.IsCorruptFile(err)
+	assert.True(t, ok)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// version 6: try entitlements
+// ─────────────────────────────────────────────────────────────────────────────
+
+// writeTryCounterParticipants writes a version 5 participant file, whose try
+// fields still count down.
+func writeTryCounterParticipants(t *testing.T, dir string) string {
+	t.Helper()
+	pFile, _ := ContestStorePaths(dir, BackendJSON)
+	require.NoError(t, os.WriteFile(pFile, []byte(`{"schema":"chugware/participants","version":5,"data":[`+
+		`{"id":"p1","name":"Alice","bottle":"1","half_tankard":"2","full_tankard":"0"}]}`), 0644))
+	return pFile
+}
+
+func TestJSONStore_RestoresTryEntitlementFromJournal(t *testing.T) {
+	dir := t.TempDir()
+	pFile := writeTryCounterParticipants(t, dir)
+
+	var lines []byte
+	for _, e := range []JournalEntry{
+		{Seq: 1, Action: JournalDecrementTries, ParticipantID: "p1", Discipline: models.DisciplineBottle},
+		{Seq: 2, Action: JournalDecrementTries, ParticipantID: "p1", Discipline: models.DisciplineBottle},
+		{Seq: 3, Action: JournalDecrementTries, ParticipantID: "p1", Discipline: models.DisciplineBottle},
+		{Seq: 4, Action: JournalRestoreTries, ParticipantID: "p1", Discipline: models.DisciplineBottle},
+		{Seq: 5, Action: JournalDecrementTries, ParticipantID: "p1", Discipline: models.DisciplineFullTankard},
+	} {
+		line, err := json.Marshal(e)
+		require.NoError(t, err)
+		lines = append(append(lines, line...), '\n')
+	}
+	require.NoError(t, os.WriteFile(JournalPathFor(pFile), lines, 0644))
+
+	// Reading the old file again gives the same entitlement
+	for i := 0; i < 2; i++ {
+		participants, err := NewJSONStore(pFile, "").LoadParticipants()
+		require.NoError(t, err)
+		require.Len(t, participants, 1)
+		assert.Equal(t, "3", participants[0].Bottle)
+		assert.Equal(t, "2", participants[0].HalfTankard)
+		assert.Equal(t, "1", participants[0].FullTankard)
+	}
+}
+
+func TestJSONStore_RestoresTryEntitlementFromResultsWithoutJournal(t *testing.T) {
+	dir := t.TempDir()
+	pFile := writeTryCounterParticipants(t, dir)
+	_, rFile := ContestStorePaths(dir, BackendJSON)
+	require.NoError(t, os.WriteFile(rFile, []byte(`{"schema":"chugware/results","version":5,"data":[`+
+		`{"id":"r1","participant_id":"p1","name":"Alice","discipline":"Half Tankard","status":"Pass"},`+
+		`{"id":"r2","participant_id":"p1","name":"Alice","discipline":"Half Tankard","status":"Pass","voided":true}]}`), 0644))
+
+	participants, err := NewJSONStore(pFile, "").LoadParticipants()
+	require.NoError(t, err)
+	assert.Equal(t, "1", participants[0].Bottle)
+	assert.Equal(t, "3", participants[0].HalfTankard, "the voided attempt had handed its try back")
+	assert.Equal(t, "0", participants[0].FullTankard)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// UpgradeContest
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestUpgradeContest_DryRunWritesNothing(t *testing.T) {
+	dir := t.TempDir()
+	pFile, rFile := writeLegacyContest(t, dir)
+	before, err := os.ReadFile(rFile)
+	require.NoError(t, err)
+
+	reports, err := UpgradeContest(dir, true)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	for _, r := range reports {
+		assert.True(t, r.NeedsUpgrade())
+		assert.Equal(t, 1, r.FromVersion)
+		assert.NotEmpty(t, r.Steps)
+	}
+	assert.Equal(t, pFile, reports[0].Path)
+	assert.Equal(t, 2, reports[1].Records)
+
+	after, err := os.ReadFile(rFile)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+}
+
+func TestUpgradeContest_RewritesAtCurrentVersion(t *testing.T) {
+	dir := t.TempDir()
+	pFile, rFile := writeLegacyContest(t, dir)
+
+	_, err := UpgradeContest(dir, false)
+	require.NoError(t, err)
+
+	for path, schema := range map[string]string{pFile: SchemaParticipants, rFile: SchemaResults} {
+		env := readEnvelope(t, path)
+		assert.Equal(t, schema, env.Schema)
+		assert.Equal(t, CurrentSchemaVersion, env.Version)
+	}
+	results, err := NewJSONStore("", rFile).LoadResults()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	id := results[0].ID
+
+	// A second run finds nothing to do and keeps the assigned IDs
+	reports, err := UpgradeContest(dir, false)
+	require.NoError(t, err)
+	for _, r := range reports {
+		assert.False(t, r.NeedsUpgrade())
+	}
+	results, err = NewJSONStore("", rFile).LoadResults()
+	require.NoError(t, err)
+	assert.Equal(t, id, results[0].ID)
+}
+
+func TestUpgradeContest_StopsAtNewerFile(t *testing.T) {
+	dir := t.TempDir()
+	_, rFile := ContestStorePaths(dir, BackendJSON)
+	require.NoError(t, os.WriteFile(rFile, []byte(`{"schema":"chugware/results","version":99,"data":[]}`), 0644))
+
+	_, err := UpgradeContest(dir, false)
+	var unsupported *UnsupportedVersionError
+	assert.ErrorAs(t, err, &unsupported)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// database version
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestBoltStore_RefusesNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contest.db")
+	db, err := bolt.Open(path, 0644, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket(bucketMeta)
+		if err != nil {
+			return err
+		}
+		return b.Put(metaVersion, []byte("99"))
+	}))
+	require.NoError(t, db.Close())
+
+	_, err = OpenBoltStore(path)
+	var unsupported *UnsupportedVersionError
+	assert.ErrorAs(t, err, &unsupported)
+}
+
+func TestBoltStore_UpgradesOlderRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contest.db")
+	db, err := bolt.Open(path, 0644, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucket(bucketMeta)
+		if err != nil {
+			return err
+		}
+		if err := meta.Put(metaVersion, []byte("1")); err != nil {
+			return err
+		}
+		results, err := tx.CreateBucket(bucketResults)
+		if err != nil {
+			return err
+		}
+		return results.Put(positionKey(1), []byte(`{"name":"Alice","discipline":"Bottle","status":"Pass"}`))
+	}))
+	require.NoError(t, db.Close())
+
+	s, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	results, err := s.ResultsByParticipant(LegacyParticipantID("Alice"))
+	require.NoError(t, err)
+	require.Len(t, results, 1, "indexes are rebuilt after the upgrade")
+	assert.Len(t, results[0].ID, 12)
+}
@@ -0,0 +1,299 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+)
+
+// SeriesDef is a season series: a set of contests under the ChugWare root
+// whose championship points are added up. Contests lists folder names or
+// patterns such as "*_2026-*_Official". With BestOf set only a competitor's
+// best BestOf contests count, e.g. the best 3 of 5.
+type SeriesDef struct {
+	Name     string   `json:"name"`
+	Contests []string `json:"contests"`
+	BestOf   int      `json:"best_of,omitempty"`
+}
+
+// SeriesContest is one contest of a series as loaded from its folder.
+type SeriesContest struct {
+	Folder       string
+	Disciplines  *DisciplineRegistry
+	Participants []models.Participant
+	Teams        []models.Team
+	Results      []models.Result
+}
+
+// SeriesEntry is a competitor's line in a season table.
+type SeriesEntry struct {
+	Name   string
+	Points int // the points that count under the series' BestOf
+	Rank   int
+	Tied   bool
+	Place  string // Rank as shown, "T-2" for a shared placing
+	// PerContest holds the points of every contest of the series in order,
+	// 0 where the competitor scored none, and Counted which of them count.
+	PerContest []int
+	Counted    []bool
+}
+
+// SeriesTable is a season table: the overall standings or those of one
+// discipline.
+type SeriesTable struct {
+	Name    string
+	Entries []SeriesEntry
+}
+
+// SeriesStandings holds the season tables of a series.
+type SeriesStandings struct {
+	Name     string
+	BestOf   int
+	Contests []string // folder names in series order
+	// Overall adds up the overall individual points of each contest (see
+	// NewChampionship); Disciplines holds a table per discipline in the
+	// order they first appear.
+	Overall     SeriesTable
+	Disciplines []SeriesTable
+}
+
+// SeriesPathFor returns the series file of a ChugWare root folder.
+func SeriesPathFor(root string) string {
+	return filepath.Join(root, config.SeriesFileName)
+}
+
+// LoadSeries reads a series file: a JSON array of series definitions. A
+// missing file means no series.
+func LoadSeries(path string) ([]SeriesDef, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading series %s: %w", path, err)
+	}
+	var defs []SeriesDef
+	if err := json.Unmarshal(content, &defs); err != nil {
+		return nil, fmt.Errorf("error parsing series %s: %w", path, err)
+	}
+	for i, d := range defs {
+		switch {
+		case strings.TrimSpace(d.Name) == "":
+			return nil, fmt.Errorf("error in series %s: series %d has no name", path, i+1)
+		case len(d.Contests) == 0:
+			return nil, fmt.Errorf("error in series %s: series %q has no contests", path, d.Name)
+		case d.BestOf < 0:
+			return nil, fmt.Errorf("error in series %s: series %q: best_of cannot be negative", path, d.Name)
+		}
+		for _, pattern := range d.Contests {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("error in series %s: series %q: bad contest pattern %q", path, d.Name, pattern)
+			}
+		}
+	}
+	return defs, nil
+}
+
+var folderDateRe = regexp.MustCompile(`_(\d{4}-\d{2}-\d{2})_[^_]+$`)
+
+// Folders returns the contest folders under root that the series selects,
+// oldest first by the date in their name.
+func (d SeriesDef) Folders(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read root folder %q: %w", root, err)
+	}
+	var folders []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		for _, pattern := range d.Contests {
+			if ok, _ := filepath.Match(pattern, e.Name()); ok {
+				folders = append(folders, e.Name())
+				break
+			}
+		}
+	}
+	sort.SliceStable(folders, func(i, j int) bool {
+		return folderDate(folders[i]) < folderDate(folders[j])
+	})
+	return folders, nil
+}
+
+func folderDate(folder string) string {
+	if m := folderDateRe.FindStringSubmatch(folder); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// LoadSeriesContest reads a contest folder under root for a series. Voided
+// results are left in; the standings ignore them.
+func LoadSeriesContest(root, folder string) (SeriesContest, error) {
+	c := SeriesContest{Folder: folder}
+	contestDir := filepath.Join(root, folder, config.ContestDirectory)
+	store, err := OpenContestStore(contestDir)
+	if err != nil {
+		return c, err
+	}
+	defer store.Close()
+
+	if c.Participants, err = store.LoadParticipants(); err != nil {
+		return c, fmt.Errorf("%s: participants: %w", folder, err)
+	}
+	if c.Teams, err = store.LoadTeams(); err != nil {
+		return c, fmt.Errorf("%s: teams: %w", folder, err)
+	}
+	if c.Results, err = store.LoadResults(); err != nil {
+		return c, fmt.Errorf("%s: results: %w", folder, err)
+	}
+	if c.Disciplines, err = LoadDisciplines(filepath.Join(contestDir, config.DisciplineFileName)); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// NewSeriesStandings adds up the championship points of the contests of a
+// series, given in series order. Competitors are matched across contests by
+// name, ignoring case, since every contest has its own participant IDs.
+func NewSeriesStandings(def SeriesDef, contests []SeriesContest) SeriesStandings {
+	s := SeriesStandings{Name: def.Name, BestOf: def.BestOf}
+	overall := newSeriesTable("Overall", len(contests))
+	var disciplineOrder []string
+	disciplines := make(map[string]*seriesTable)
+
+	for i, c := range contests {
+		s.Contests = append(s.Contests, c.Folder)
+		registry := c.Disciplines
+		if registry == nil {
+			registry = DefaultDisciplineRegistry()
+		}
+
+		for _, ps := range NewChampionship(registry, c.Participants, c.Teams, c.Results).Individuals {
+			overall.add(ps.Name, i, ps.Points)
+		}
+
+		byDiscipline := make(map[string][]models.Result)
+		for _, r := range c.Results {
+			byDiscipline[r.Discipline] = append(byDiscipline[r.Discipline], r)
+		}
+		for _, d := range registry.Defs() {
+			if len(byDiscipline[d.Name]) == 0 {
+				continue
+			}
+			table, ok := disciplines[d.Name]
+			if !ok {
+				table = newSeriesTable(d.Name, len(contests))
+				disciplines[d.Name] = table
+				disciplineOrder = append(disciplineOrder, d.Name)
+			}
+			for _, st := range Standings(d, byDiscipline[d.Name]) {
+				table.add(st.Result.Name, i, PlacePoints(d, st.Rank))
+			}
+		}
+	}
+
+	s.Overall = overall.ranked(def.BestOf)
+	for _, name := range disciplineOrder {
+		s.Disciplines = append(s.Disciplines, disciplines[name].ranked(def.BestOf))
+	}
+	return s
+}
+
+// LoadSeriesStandings loads the official contests a series selects under
+// root and adds up their points. Unofficial contests are left out even when
+// a pattern matches them.
+func LoadSeriesStandings(root string, def SeriesDef) (SeriesStandings, error) {
+	folders, err := def.Folders(root)
+	if err != nil {
+		return SeriesStandings{}, err
+	}
+	contests := make([]SeriesContest, 0, len(folders))
+	for _, folder := range folders {
+		if !RulesFor(folder).Counted {
+			continue
+		}
+		c, err := LoadSeriesContest(root, folder)
+		if err != nil {
+			return SeriesStandings{}, err
+		}
+		contests = append(contests, c)
+	}
+	return NewSeriesStandings(def, contests), nil
+}
+
+// seriesTable collects points per competitor and contest.
+type seriesTable struct {
+	name     string
+	contests int
+	keys     []string
+	entries  map[string]*SeriesEntry
+}
+
+func newSeriesTable(name string, contests int) *seriesTable {
+	return &seriesTable{name: name, contests: contests, entries: make(map[string]*SeriesEntry)}
+}
+
+// add gives a competitor points in contest i. Competitors without points are
+// not listed.
+func (t *seriesTable) add(name string, i, points int) {
+	if points == 0 {
+		return
+	}
+	key := strings.ToLower(strings.TrimSpace(name))
+	e, ok := t.entries[key]
+	if !ok {
+		e = &SeriesEntry{Name: name, PerContest: make([]int, t.contests)}
+		t.entries[key] = e
+		t.keys = append(t.keys, key)
+	}
+	e.PerContest[i] += points
+}
+
+// ranked counts the best bestOf contests of every competitor (all with 0)
+// and ranks them most points first.
+func (t *seriesTable) ranked(bestOf int) SeriesTable {
+	out := SeriesTable{Name: t.name, Entries: make([]SeriesEntry, len(t.keys))}
+	for n, key := range t.keys {
+		e := *t.entries[key]
+		e.Counted = make([]bool, len(e.PerContest))
+		order := make([]int, 0, len(e.PerContest))
+		for i, p := range e.PerContest {
+			if p > 0 {
+				order = append(order, i)
+			}
+		}
+		sort.SliceStable(order, func(a, b int) bool { return e.PerContest[order[a]] > e.PerContest[order[b]] })
+		if bestOf > 0 && len(order) > bestOf {
+			order = order[:bestOf]
+		}
+		for _, i := range order {
+			e.Counted[i] = true
+			e.Points += e.PerContest[i]
+		}
+		out.Entries[n] = e
+	}
+
+	sort.SliceStable(out.Entries, func(i, j int) bool {
+		if out.Entries[i].Points != out.Entries[j].Points {
+			return out.Entries[i].Points > out.Entries[j].Points
+		}
+		return out.Entries[i].Name < out.Entries[j].Name
+	})
+	points := make([]int, len(out.Entries))
+	for i, e := range out.Entries {
+		points[i] = e.Points
+	}
+	for i, p := range pointsPlacings(points) {
+		out.Entries[i].Rank, out.Entries[i].Tied, out.Entries[i].Place = p.rank, p.tied, p.place
+	}
+	return out
+}
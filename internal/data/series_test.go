@@ -0,0 +1,126 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bottleContest is a contest folder in which names finish Bottle in order.
+func bottleContest(folder string, names ...string) SeriesContest {
+	c := SeriesContest{Folder: folder}
+	for i, name := range names {
+		id := folder + name
+		c.Participants = append(c.Participants, models.Participant{ID: id, Name: name, Program: "D"})
+		c.Results = append(c.Results, models.Result{ParticipantID: id, Name: name, Discipline: models.DisciplineBottle,
+			Time: bottleTime(i), Status: models.StatusPass})
+	}
+	return c
+}
+
+// bottleTime returns the i-th fastest Bottle time of a contest.
+func bottleTime(i int) string {
+	return []string{"00:00:07.0000", "00:00:08.0000", "00:00:09.0000"}[i]
+}
+
+func seriesContests() []SeriesContest {
+	return []SeriesContest{
+		bottleContest("Spring_2026-03-01_Official", "Alice", "Bob", "Cleo"),
+		bottleContest("Summer_2026-06-01_Official", "Bob", "alice"),
+		bottleContest("Autumn_2026-09-01_Official", "Cleo", "Bob", "Alice"),
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Series definitions
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestLoadSeries(t *testing.T) {
+	dir := t.TempDir()
+	defs, err := LoadSeries(SeriesPathFor(dir))
+	require.NoError(t, err)
+	assert.Empty(t, defs, "no series file, no series")
+
+	require.NoError(t, os.WriteFile(SeriesPathFor(dir), []byte(`[{"name":"Season 2026","contests":["*_2026-*"],"best_of":2}]`), 0644))
+	defs, err = LoadSeries(SeriesPathFor(dir))
+	require.NoError(t, err)
+	assert.Equal(t, []SeriesDef{{Name: "Season 2026", Contests: []string{"*_2026-*"}, BestOf: 2}}, defs)
+
+	for _, bad := range []string{`[{"contests":["x"]}]`, `[{"name":"S"}]`, `[{"name":"S","contests":["["]}]`, `{`} {
+		require.NoError(t, os.WriteFile(SeriesPathFor(dir), []byte(bad), 0644))
+		_, err = LoadSeries(SeriesPathFor(dir))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSeriesDef_Folders(t *testing.T) {
+	root := t.TempDir()
+	for _, f := range []string{"Summer_2026-06-01_Official", "Spring_2026-03-01_Official", "Old_2025-05-01_Official", "Party_2026-04-01_Unofficial"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, f), 0755))
+	}
+	def := SeriesDef{Name: "S", Contests: []string{"*_2026-*_Official", "Old_2025-05-01_Official"}}
+
+	folders, err := def.Folders(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Old_2025-05-01_Official", "Spring_2026-03-01_Official", "Summer_2026-06-01_Official"}, folders,
+		"oldest first; unofficial contests are not matched")
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Season standings
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestNewSeriesStandings_AllCount(t *testing.T) {
+	s := NewSeriesStandings(SeriesDef{Name: "S"}, seriesContests())
+
+	require.Len(t, s.Contests, 3)
+	require.Len(t, s.Disciplines, 1)
+	assert.Equal(t, models.DisciplineBottle, s.Disciplines[0].Name)
+
+	overall := s.Overall.Entries
+	require.Len(t, overall, 3)
+	assert.Equal(t, "Bob", overall[0].Name)
+	assert.Equal(t, []int{8, 10, 8}, overall[0].PerContest)
+	assert.Equal(t, 26, overall[0].Points)
+	assert.Equal(t, "Alice", overall[1].Name, "names match across contests whatever their case")
+	assert.Equal(t, []int{10, 8, 6}, overall[1].PerContest)
+	assert.Equal(t, 24, overall[1].Points)
+	assert.Equal(t, []string{"1", "2", "3"}, []string{overall[0].Place, overall[1].Place, overall[2].Place})
+}
+
+func TestNewSeriesStandings_BestOf(t *testing.T) {
+	s := NewSeriesStandings(SeriesDef{Name: "S", BestOf: 2}, seriesContests())
+
+	overall := s.Overall.Entries
+	assert.Equal(t, []string{"Alice", "Bob", "Cleo"}, []string{overall[0].Name, overall[1].Name, overall[2].Name})
+	assert.Equal(t, 18, overall[0].Points, "Alice's 10 and 8; her 6 does not count")
+	assert.Equal(t, []bool{true, true, false}, overall[0].Counted)
+	assert.Equal(t, 18, overall[1].Points)
+	assert.Equal(t, "T-1", overall[1].Place)
+	assert.Equal(t, 16, overall[2].Points)
+	assert.Equal(t, []bool{true, false, true}, overall[2].Counted)
+}
+
+func TestLoadSeriesStandings(t *testing.T) {
+	root := t.TempDir()
+	for _, c := range append(seriesContests(), bottleContest("Party_2026-07-01_Unofficial", "Cleo", "Bob")) {
+		store := NewJSONStore(ContestStorePaths(filepath.Join(root, c.Folder, config.ContestDirectory), BackendJSON))
+		require.NoError(t, store.SaveParticipants(c.Participants))
+		require.NoError(t, store.SaveResults(c.Results))
+	}
+
+	s, err := LoadSeriesStandings(root, SeriesDef{Name: "S", Contests: []string{"*_Official"}, BestOf: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Spring_2026-03-01_Official", "Summer_2026-06-01_Official", "Autumn_2026-09-01_Official"}, s.Contests)
+	assert.Equal(t, 18, s.Overall.Entries[0].Points)
+
+	s, err = LoadSeriesStandings(root, SeriesDef{Name: "S", Contests: []string{"*"}, BestOf: 2})
+	require.NoError(t, err)
+	assert.Len(t, s.Contests, 3, "unofficial contests never count towards a series")
+}
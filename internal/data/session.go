@@ -0,0 +1,424 @@
+package data
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups the burst of events a single save produces (temp file,
+// rename, backup) into one reload.
+const watchDebounce = 150 * time.Millisecond
+
+// Change tells session subscribers which lists changed.
+type Change struct {
+	Participants bool
+	Teams        bool
+	Results      bool
+	// External is set when the change was read from disk, i.e. written by
+	// another program, rather than saved by a window of this process.
+	External bool
+}
+
+// Session is the in-process owner of one contest's participant and result
+// data. Every window working on the same files shares one Session, and so one
+// ParticipantManager and ResultManager; a save from any of them notifies the
+// others. The contest folder is marked with an advisory lock file so a second
+// ChugWare instance is warned, and the files are watched so changes made by
+// another program are reloaded straight away. Teams live next to the
+// participant file (see TeamPathFor).
+type Session struct {
+	key             string
+	participantFile string
+	teamFile        string
+	resultFile      string
+	rules           ContestRules
+	participants    *ParticipantManager
+	teams           *TeamManager
+	results         *ResultManager
+	lock            *LockFile
+	watcher         *fsnotify.Watcher
+
+	mu      sync.Mutex
+	refs    int
+	nextSub int
+	subs    map[int]func(Change)
+	known   map[string][sha256.Size]byte
+}
+
+var (
+	sessionsMu sync.Mutex
+	sessions   = make(map[string]*Session)
+)
+
+// OpenSession returns the session for the given files, creating it (and
+// taking the contest lock) on first use. Every call must be paired with
+// Release. If another instance holds the lock a *LockedError is returned,
+// unless force is set. The managers are not loaded; callers load them through
+// the usual LoadParticipants/LoadResults so load errors surface as before.
+func OpenSession(participantFile, resultFile string, force bool) (*Session, error) {
+	if participantFile == "" && resultFile == "" {
+		return nil, fmt.Errorf("no contest files set")
+	}
+	participantFile = absPath(participantFile)
+	resultFile = absPath(resultFile)
+	key := participantFile + "\x00" + resultFile
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	if s, ok := sessions[key]; ok {
+		s.mu.Lock()
+		s.refs++
+		s.mu.Unlock()
+		return s, nil
+	}
+
+	lockFor := resultFile
+	if lockFor == "" {
+		lockFor = participantFile
+	}
+	lock, err := AcquireLock(LockPathFor(lockFor), force)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{
+		key:             key,
+		participantFile: participantFile,
+		resultFile:      resultFile,
+		participants:    NewParticipantManager(),
+		teams:           NewTeamManager(),
+		results:         NewResultManager(),
+		lock:            lock,
+		refs:            1,
+		subs:            make(map[int]func(Change)),
+		known:           make(map[string][sha256.Size]byte),
+	}
+	if participantFile != "" {
+		s.participants.SetFilePath(participantFile)
+		s.teamFile = TeamPathFor(participantFile)
+		s.teams.SetFilePath(s.teamFile)
+	}
+	if resultFile != "" {
+		s.results.SetFilePath(resultFile)
+	}
+	s.participants.onSave = func() { s.saved(Change{Participants: true}) }
+	s.teams.onSave = func() { s.saved(Change{Teams: true}) }
+	s.results.onSave = func() {
+		s.sealSetup()
+		s.saved(Change{Results: true})
+	}
+	if resultFile != "" {
+		// The participants and teams of an official contest are locked from
+		// its first result
+		s.rules = RulesFor(ContestFolderOf(resultFile))
+		locked := func() bool { return s.rules.Locked(s.results.GetResults()) }
+		s.participants.setupLocked = locked
+		s.teams.setupLocked = locked
+
+		// A result is journalled before results.json is saved; remember the
+		// journal straight away so the watcher does not take this process's
+		// own append for another station's
+		journal := JournalPathFor(resultFile)
+		s.results.onJournal = func() { s.rememberFile(journal) }
+	}
+	s.remember()
+	s.startWatching()
+
+	sessions[key] = s
+	return s, nil
+}
+
+// absPath cleans a data file path; "" stays "".
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// Participants returns the shared participant manager.
+func (s *Session) Participants() *ParticipantManager {
+	return s.participants
+}
+
+// Teams returns the shared team manager.
+func (s *Session) Teams() *TeamManager {
+	return s.teams
+}
+
+// TeamFile returns the team file of the session, or "" if the session has no
+// participant file.
+func (s *Session) TeamFile() string {
+	return s.teamFile
+}
+
+// Results returns the shared result manager.
+func (s *Session) Results() *ResultManager {
+	return s.results
+}
+
+// Matches reports whether the session is over the given files.
+func (s *Session) Matches(participantFile, resultFile string) bool {
+	return s.participantFile == absPath(participantFile) && s.resultFile == absPath(resultFile)
+}
+
+// Subscribe registers fn to be called after every change. fn runs on the
+// goroutine that saved or on the file watcher's goroutine. The returned
+// function removes the subscription.
+func (s *Session) Subscribe(fn func(Change)) (unsubscribe func()) {
+	s.mu.Lock()
+	id := s.nextSub
+	s.nextSub++
+	s.subs[id] = fn
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		delete(s.subs, id)
+		s.mu.Unlock()
+	}
+}
+
+// Release drops one reference. The last release stops the watcher and
+// removes the lock file.
+func (s *Session) Release() error {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	s.mu.Lock()
+	if s.refs <= 0 {
+		// Already closed by CloseSessions
+		s.mu.Unlock()
+		return nil
+	}
+	s.refs--
+	last := s.refs == 0
+	s.mu.Unlock()
+	if !last {
+		return nil
+	}
+
+	delete(sessions, s.key)
+	s.participants.mu.Lock()
+	s.participants.onSave = nil
+	s.participants.setupLocked = nil
+	s.participants.mu.Unlock()
+	s.teams.mu.Lock()
+	s.teams.onSave = nil
+	s.teams.setupLocked = nil
+	s.teams.mu.Unlock()
+	s.results.mu.Lock()
+	s.results.onSave = nil
+	s.results.onJournal = nil
+	s.results.mu.Unlock()
+	if s.watcher != nil {
+		s.watcher.Close()
+	}
+	return s.lock.Release()
+}
+
+// CloseSessions releases every open session, e.g. when the application exits.
+func CloseSessions() {
+	sessionsMu.Lock()
+	open := make([]*Session, 0, len(sessions))
+	for _, s := range sessions {
+		open = append(open, s)
+	}
+	sessionsMu.Unlock()
+
+	for _, s := range open {
+		s.mu.Lock()
+		s.refs = 1
+		s.mu.Unlock()
+		s.Release()
+	}
+}
+
+// watchedFiles returns the files whose changes trigger a reload. A contest
+// database is not watched: bbolt lets only one process open it, so it cannot
+// change behind this one's back.
+func (s *Session) watchedFiles() []string {
+	var files []string
+	if s.participantFile != "" && !IsDatabasePath(s.participantFile) {
+		files = append(files, s.participantFile, s.teamFile)
+	}
+	if s.resultFile != "" {
+		if !IsDatabasePath(s.resultFile) {
+			files = append(files, s.resultFile)
+		}
+		files = append(files, JournalPathFor(s.resultFile))
+	}
+	return files
+}
+
+// remember records the current contents of the watched files, so that the
+// watcher can tell this process's own writes from other programs'.
+func (s *Session) remember() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, f := range s.watchedFiles() {
+		s.known[f] = fileHash(f)
+	}
+}
+
+// rememberFile records the current contents of one watched file.
+func (s *Session) rememberFile(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.known[path] = fileHash(path)
+}
+
+// fileHash returns the SHA-256 of a file's contents; a missing file hashes
+// as empty.
+func fileHash(path string) [sha256.Size]byte {
+	data, _ := os.ReadFile(path)
+	return sha256.Sum256(data)
+}
+
+// sealSetup seals the discipline and category files of an official contest
+// once its setup is locked, and unseals them if voids have opened it again
+// (see SealSetup). A seal that cannot be written is tried again with the next
+// change of the results.
+func (s *Session) sealSetup() {
+	if s.participantFile == "" || !s.rules.LockConfiguration {
+		return
+	}
+	if s.rules.Locked(s.results.GetResults()) {
+		_ = SealSetup(s.participantFile)
+	} else {
+		_ = UnsealSetup(s.participantFile)
+	}
+}
+
+// saved is called by the managers after a successful save.
+func (s *Session) saved(c Change) {
+	s.remember()
+	s.notify(c)
+}
+
+// notify calls every subscriber with c.
+func (s *Session) notify(c Change) {
+	s.mu.Lock()
+	subs := make([]func(Change), 0, len(s.subs))
+	for _, fn := range s.subs {
+		subs = append(subs, fn)
+	}
+	s.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(c)
+	}
+}
+
+// startWatching watches the folders of the data files. Live reload is a
+// convenience, so if the platform cannot watch files the session simply
+// works without it.
+func (s *Session) startWatching() {
+	files := s.watchedFiles()
+	if len(files) == 0 {
+		return
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+	// Watch directories rather than files: atomic saves replace the file,
+	// which would silently end a watch on the old one
+	dirs := make(map[string]bool)
+	for _, f := range files {
+		dir := filepath.Dir(f)
+		if dirs[dir] {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err == nil && watcher.Add(dir) == nil {
+			dirs[dir] = true
+		}
+	}
+	if len(dirs) == 0 {
+		watcher.Close()
+		return
+	}
+	s.watcher = watcher
+	go s.watch(files)
+}
+
+// watch runs until the watcher is closed, reloading after each burst of
+// events on the data files.
+func (s *Session) watch(files []string) {
+	interesting := make(map[string]bool, len(files))
+	for _, f := range files {
+		interesting[f] = true
+	}
+
+	var fire <-chan time.Time
+	for {
+		select {
+		case ev, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			if interesting[filepath.Clean(ev.Name)] {
+				fire = time.After(watchDebounce)
+			}
+		case _, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-fire:
+			fire = nil
+			s.reloadChanged()
+		}
+	}
+}
+
+// reloadChanged reloads whichever lists changed on disk since this process
+// last read or wrote them, and notifies subscribers.
+func (s *Session) reloadChanged() {
+	s.mu.Lock()
+	var change Change
+	for _, f := range s.watchedFiles() {
+		if fileHash(f) == s.known[f] {
+			continue
+		}
+		switch f {
+		case s.participantFile:
+			change.Participants = true
+		case s.teamFile:
+			change.Teams = true
+		default:
+			change.Results = true
+		}
+	}
+	s.mu.Unlock()
+
+	if !change.Participants && !change.Teams && !change.Results {
+		return
+	}
+	if change.Participants {
+		_ = s.participants.LoadParticipants(s.participantFile)
+	}
+	if change.Teams {
+		_ = s.teams.LoadTeams(s.teamFile)
+	}
+	if change.Results {
+		OpenJournal(JournalPathFor(s.resultFile)).forget()
+		_ = s.results.LoadResults(s.resultFile)
+		s.sealSetup()
+	}
+	// Loading may itself write (importing rows into a new journal), so take
+	// the snapshot only afterwards
+	s.remember()
+
+	change.External = true
+	s.notify(change)
+}
@@ -0,0 +1,192 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func openTestSession(t *testing.T) (*Session, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	pFile, rFile := ContestStorePaths(dir, BackendJSON)
+	s, err := OpenSession(pFile, rFile, false)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Release() })
+	return s, pFile, rFile
+}
+
+// changes collects session notifications on a channel.
+func changes(s *Session) (<-chan Change, func()) {
+	ch := make(chan Change, 16)
+	unsubscribe := s.Subscribe(func(c Change) { ch <- c })
+	return ch, unsubscribe
+}
+
+func waitChange(t *testing.T, ch <-chan Change) Change {
+	t.Helper()
+	select {
+	case c := <-ch:
+		return c
+	case <-time.After(3 * time.Second):
+		t.Fatal("no change notification")
+		return Change{}
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// lock file
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestAcquireLock_RefusesOtherHolderUnlessForced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chugware.lock")
+	other, err := json.Marshal(LockInfo{PID: os.Getpid() + 1, Host: "other-pc", Since: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, other, 0644))
+
+	_, err = AcquireLock(path, false)
+	var locked *LockedError
+	require.ErrorAs(t, err, &locked)
+	assert.Equal(t, "other-pc", locked.Holder.Host)
+
+	lock, err := AcquireLock(path, true)
+	require.NoError(t, err)
+	holder, err := readLock(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), holder.PID)
+
+	require.NoError(t, lock.Release())
+	assert.NoFileExists(t, path)
+}
+
+func TestAcquireLock_ReusesOwnLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chugware.lock")
+	_, err := AcquireLock(path, false)
+	require.NoError(t, err)
+
+	lock, err := AcquireLock(path, false)
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
+
+func TestLockFile_ReleaseLeavesTakenOverLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chugware.lock")
+	lock, err := AcquireLock(path, false)
+	require.NoError(t, err)
+
+	other, err := json.Marshal(LockInfo{PID: os.Getpid() + 1, Host: "other-pc", Since: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, other, 0644))
+
+	require.NoError(t, lock.Release())
+	assert.FileExists(t, path)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// session
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestOpenSession_SharedAndLocked(t *testing.T) {
+	s, pFile, rFile := openTestSession(t)
+	lockPath := LockPathFor(rFile)
+	assert.FileExists(t, lockPath)
+
+	again, err := OpenSession(pFile, rFile, false)
+	require.NoError(t, err)
+	assert.Same(t, s, again)
+	assert.Same(t, s.Participants(), again.Participants())
+	assert.True(t, s.Matches(pFile, rFile))
+
+	require.NoError(t, again.Release())
+	assert.FileExists(t, lockPath, "lock is held until the last release")
+	require.NoError(t, s.Release())
+	assert.NoFileExists(t, lockPath)
+}
+
+func TestSession_SaveNotifiesSubscribers(t *testing.T) {
+	s, _, _ := openTestSession(t)
+	ch, unsubscribe := changes(s)
+	defer unsubscribe()
+
+	require.NoError(t, s.Participants().AddParticipant(models.Participant{Name: "Alice", Program: "F", Team: "T1", Bottle: "3"}))
+	require.NoError(t, s.Participants().SaveParticipants())
+	c := waitChange(t, ch)
+	assert.True(t, c.Participants)
+	assert.False(t, c.External)
+
+	require.NoError(t, s.Teams().AddTeam(models.Team{Name: "Red"}))
+	require.NoError(t, s.Teams().SaveTeams())
+	c = waitChange(t, ch)
+	assert.True(t, c.Teams)
+
+	require.NoError(t, s.Results().AddResult(models.Result{Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "5", Status: models.StatusPass}))
+	require.NoError(t, s.Results().SaveResults())
+	c = waitChange(t, ch)
+	assert.True(t, c.Results)
+
+	// Our own writes are not reported again by the file watcher
+	select {
+	case c := <-ch:
+		t.Fatalf("unexpected notification %+v", c)
+	case <-time.After(3 * watchDebounce):
+	}
+}
+
+func TestSession_OwnJournalAppendIsNotExternal(t *testing.T) {
+	s, _, _ := openTestSession(t)
+	ch, unsubscribe := changes(s)
+	defer unsubscribe()
+
+	// The result is journalled but results.json is not saved yet, as between
+	// recording a result and its save
+	require.NoError(t, s.Results().AddResult(models.Result{Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "5", Status: models.StatusPass}))
+	select {
+	case c := <-ch:
+		t.Fatalf("own journal append reported as %+v", c)
+	case <-time.After(3 * watchDebounce):
+	}
+
+	require.NoError(t, s.Results().SaveResults())
+	c := waitChange(t, ch)
+	assert.True(t, c.Results)
+	assert.False(t, c.External)
+}
+
+func TestSession_ReloadsExternalChanges(t *testing.T) {
+	s, pFile, _ := openTestSession(t)
+	require.NoError(t, s.Participants().SaveParticipants())
+	ch, unsubscribe := changes(s)
+	defer unsubscribe()
+
+	// Another program rewrites participants.json
+	other := NewJSONStore(pFile, "")
+	require.NoError(t, other.SaveParticipants([]models.Participant{{Name: "Bob", Bottle: "2"}}))
+
+	c := waitChange(t, ch)
+	assert.True(t, c.External)
+	assert.True(t, c.Participants)
+	participants := s.Participants().GetParticipants()
+	require.Len(t, participants, 1)
+	assert.Equal(t, "Bob", participants[0].Name)
+}
+
+func TestCloseSessions_ReleasesEverything(t *testing.T) {
+	s, _, rFile := openTestSession(t)
+	_, err := OpenSession(filepath.Join(filepath.Dir(rFile), "participants.json"), rFile, false)
+	require.NoError(t, err)
+
+	CloseSessions()
+	assert.NoFileExists(t, LockPathFor(rFile))
+	assert.NoError(t, s.Release(), "releasing a closed session is a no-op")
+}
@@ -0,0 +1,83 @@
+package data
+
+import (
+	"path/filepath"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/models"
+)
+
+// Store persists the participant, team and result lists of a contest.
+// The managers keep the working copy in memory and use a Store only to load
+// and save it; the result journal is kept separately next to the data.
+type Store interface {
+	LoadParticipants() ([]models.Participant, error)
+	SaveParticipants(participants []models.Participant) error
+
+	LoadTeams() ([]models.Team, error)
+	SaveTeams(teams []models.Team) error
+
+	LoadResults() ([]models.Result, error)
+	SaveResults(results []models.Result) error
+
+	// Indexed lookups for archive and statistics queries that should not
+	// need to load every row. Voided results are included.
+	ResultsByDiscipline(discipline string) ([]models.Result, error)
+	ResultsByParticipant(participantID string) ([]models.Result, error)
+
+	Close() error
+}
+
+// DatabaseExt is the file extension that selects the embedded database store.
+const DatabaseExt = ".db"
+
+// IsDatabasePath reports whether path points at an embedded database rather
+// than a JSON file.
+func IsDatabasePath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), DatabaseExt)
+}
+
+// participantStoreFor returns the store behind a participant file setting.
+func participantStoreFor(path string) (Store, error) {
+	if IsDatabasePath(path) {
+		return OpenBoltStore(path)
+	}
+	return NewJSONStore(path, ""), nil
+}
+
+// TeamPathFor returns the team file that belongs with a participant file
+// setting. A contest database holds the teams itself.
+func TeamPathFor(participantFile string) string {
+	if IsDatabasePath(participantFile) {
+		return participantFile
+	}
+	return filepath.Join(filepath.Dir(participantFile), config.TeamFileName)
+}
+
+// teamStoreFor returns the store behind a team file (see TeamPathFor).
+func teamStoreFor(path string) (Store, error) {
+	if IsDatabasePath(path) {
+		return OpenBoltStore(path)
+	}
+	return &JSONStore{teamFile: path}, nil
+}
+
+// resultStoreFor returns the store behind a result file setting.
+func resultStoreFor(path string) (Store, error) {
+	if IsDatabasePath(path) {
+		return OpenBoltStore(path)
+	}
+	return NewJSONStore("", path), nil
+}
+
+// filterResults returns the results for which keep is true.
+func filterResults(results []models.Result, keep func(models.Result) bool) []models.Result {
+	var filtered []models.Result
+	for _, r := range results {
+		if keep(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,420 @@
+package data
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"chugware/internal/models"
+)
+
+// Bucket names in the contest database. Rows are keyed by their 1-based
+// position so list order survives a round trip; the index buckets map
+// "<value>\x00<position>" to nothing and are kept in step on every write.
+var (
+	bucketMeta                 = []byte("meta")
+	bucketParticipants         = []byte("participants")
+	bucketTeams                = []byte("teams")
+	bucketResults              = []byte("results")
+	bucketResultsByDiscipline  = []byte("results_by_discipline")
+	bucketResultsByParticipant = []byte("results_by_participant")
+)
+
+// metaVersion is the meta-bucket key holding the schema version of the rows.
+var metaVersion = []byte("version")
+
+// BoltStore keeps a whole contest in a single embedded bbolt database file.
+// Saves only touch rows that changed, and results are indexed by discipline
+// and participant.
+type BoltStore struct {
+	path string
+	db   *bolt.DB
+}
+
+var (
+	boltStoresMu sync.Mutex
+	boltStores   = make(map[string]*BoltStore)
+)
+
+// OpenBoltStore opens (creating if needed) the database at path. bbolt allows
+// only one handle per file, so every manager in the process that points at
+// the same file shares one store.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+
+	boltStoresMu.Lock()
+	defer boltStoresMu.Unlock()
+
+	if s, ok := boltStores[abs]; ok {
+		return s, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+		return nil, fmt.Errorf("error creating database directory: %w", err)
+	}
+	db, err := bolt.Open(abs, 0644, &bolt.Options{Timeout: time.Second})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("database %s is in use by another program", abs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening database %s: %w", abs, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketMeta, bucketParticipants, bucketTeams, bucketResults, bucketResultsByDiscipline, bucketResultsByParticipant} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return upgradeBolt(tx, abs)
+	})
+	if err != nil {
+		db.Close()
+		var unsupported *UnsupportedVersionError
+		if errors.As(err, &unsupported) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error initialising database %s: %w", abs, err)
+	}
+
+	s := &BoltStore{path: abs, db: db}
+	boltStores[abs] = s
+	return s, nil
+}
+
+// Path returns the database file path.
+func (s *BoltStore) Path() string {
+	return s.path
+}
+
+// Close releases the database file.
+func (s *BoltStore) Close() error {
+	boltStoresMu.Lock()
+	delete(boltStores, s.path)
+	boltStoresMu.Unlock()
+	return s.db.Close()
+}
+
+// LoadParticipants returns all participants in stored order.
+func (s *BoltStore) LoadParticipants() ([]models.Participant, error) {
+	participants := make([]models.Participant, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketParticipants).ForEach(func(_, v []byte) error {
+			var p models.Participant
+			if err := json.Unmarshal(v, &p); err != nil {
+				return err
+			}
+			participants = append(participants, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading participants from %s: %w", s.path, err)
+	}
+	return participants, nil
+}
+
+// SaveParticipants stores participants, writing only the rows that changed.
+func (s *BoltStore) SaveParticipants(participants []models.Participant) error {
+	rows := make([][]byte, len(participants))
+	for i, p := range participants {
+		v, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("error encoding participant %s: %w", p.Name, err)
+		}
+		rows[i] = v
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return syncRows(tx.Bucket(bucketParticipants), rows, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving participants to %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// LoadTeams returns all teams in stored order.
+func (s *BoltStore) LoadTeams() ([]models.Team, error) {
+	teams := make([]models.Team, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketTeams).ForEach(func(_, v []byte) error {
+			var t models.Team
+			if err := json.Unmarshal(v, &t); err != nil {
+				return err
+			}
+			teams = append(teams, t)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading teams from %s: %w", s.path, err)
+	}
+	return teams, nil
+}
+
+// SaveTeams stores teams, writing only the rows that changed.
+func (s *BoltStore) SaveTeams(teams []models.Team) error {
+	rows := make([][]byte, len(teams))
+	for i, t := range teams {
+		v, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Errorf("error encoding team %s: %w", t.Name, err)
+		}
+		rows[i] = v
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return syncRows(tx.Bucket(bucketTeams), rows, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving teams to %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// LoadResults returns all results in stored order.
+func (s *BoltStore) LoadResults() ([]models.Result, error) {
+	results := make([]models.Result, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketResults).ForEach(func(_, v []byte) error {
+			var r models.Result
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			results = append(results, r)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading results from %s: %w", s.path, err)
+	}
+	return results, nil
+}
+
+// SaveResults stores results, writing only the rows that changed and keeping
+// the discipline and participant indexes in step.
+func (s *BoltStore) SaveResults(results []models.Result) error {
+	rows := make([][]byte, len(results))
+	for i, r := range results {
+		v, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("error encoding result for %s: %w", r.Name, err)
+		}
+		rows[i] = v
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		byDiscipline := tx.Bucket(bucketResultsByDiscipline)
+		byParticipant := tx.Bucket(bucketResultsByParticipant)
+		index := func(key, v []byte, add bool) error {
+			var r models.Result
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			for _, ix := range []struct {
+				b     *bolt.Bucket
+				value string
+			}{{byDiscipline, r.Discipline}, {byParticipant, r.ParticipantID}} {
+				k := indexKey(ix.value, key)
+				var err error
+				if add {
+					err = ix.b.Put(k, nil)
+				} else {
+					err = ix.b.Delete(k)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return syncRows(tx.Bucket(bucketResults), rows, index)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving results to %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// ResultsByDiscipline returns the results of one discipline via its index.
+func (s *BoltStore) ResultsByDiscipline(discipline string) ([]models.Result, error) {
+	return s.resultsByIndex(bucketResultsByDiscipline, discipline)
+}
+
+// ResultsByParticipant returns the results of one participant via its index.
+func (s *BoltStore) ResultsByParticipant(participantID string) ([]models.Result, error) {
+	return s.resultsByIndex(bucketResultsByParticipant, participantID)
+}
+
+// resultsByIndex collects the rows listed under value in an index bucket.
+func (s *BoltStore) resultsByIndex(bucket []byte, value string) ([]models.Result, error) {
+	var results []models.Result
+	prefix := indexKey(value, nil)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		rows := tx.Bucket(bucketResults)
+		c := tx.Bucket(bucket).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			v := rows.Get(k[len(prefix):])
+			if v == nil {
+				continue
+			}
+			var r models.Result
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			results = append(results, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying %s: %w", s.path, err)
+	}
+	return results, nil
+}
+
+// syncRows makes bucket hold exactly rows, keyed by position. Unchanged rows
+// are left alone; index (if non-nil) is called to drop the index entries of a
+// row being replaced or removed and to add those of a row being written.
+func syncRows(b *bolt.Bucket, rows [][]byte, index func(key, v []byte, add bool) error) error {
+	for i, v := range rows {
+		key := positionKey(i + 1)
+		old := b.Get(key)
+		if bytes.Equal(old, v) {
+			continue
+		}
+		if old != nil && index != nil {
+			if err := index(key, old, false); err != nil {
+				return err
+			}
+		}
+		if err := b.Put(key, v); err != nil {
+			return err
+		}
+		if index != nil {
+			if err := index(key, v, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Drop rows beyond the new end of the list. Keys are collected first
+	// because deleting while iterating a bbolt cursor skips entries.
+	var stale [][]byte
+	c := b.Cursor()
+	for k, v := c.Seek(positionKey(len(rows) + 1)); k != nil; k, v = c.Next() {
+		if index != nil {
+			if err := index(k, v, false); err != nil {
+				return err
+			}
+		}
+		stale = append(stale, append([]byte(nil), k...))
+	}
+	for _, k := range stale {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upgradeBolt brings the rows of a database up to CurrentSchemaVersion using
+// the same migration registry as the JSON files, then rebuilds the indexes.
+// A new database is simply stamped with the current version.
+func upgradeBolt(tx *bolt.Tx, path string) error {
+	meta := tx.Bucket(bucketMeta)
+	stored := meta.Get(metaVersion)
+	if stored == nil {
+		return meta.Put(metaVersion, []byte(strconv.Itoa(CurrentSchemaVersion)))
+	}
+	version, err := strconv.Atoi(string(stored))
+	if err != nil || version < 1 || version > CurrentSchemaVersion {
+		return &UnsupportedVersionError{Path: path, Schema: "chugware/database", Version: version}
+	}
+	if version == CurrentSchemaVersion {
+		return nil
+	}
+
+	for _, table := range []struct {
+		schema string
+		bucket []byte
+	}{{SchemaParticipants, bucketParticipants}, {SchemaTeams, bucketTeams}, {SchemaResults, bucketResults}} {
+		b := tx.Bucket(table.bucket)
+		var keys [][]byte
+		var records []map[string]any
+		err := b.ForEach(func(k, v []byte) error {
+			var r map[string]any
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			keys = append(keys, append([]byte(nil), k...))
+			records = append(records, r)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, m := range migrationsFor(table.schema, version) {
+			if records, err = m.Apply(path, records); err != nil {
+				return fmt.Errorf("error upgrading %s from version %d: %w", path, m.From, err)
+			}
+		}
+		for i, r := range records {
+			v, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(keys[i], v); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Rebuild the result indexes from scratch
+	for _, name := range [][]byte{bucketResultsByDiscipline, bucketResultsByParticipant} {
+		if err := tx.DeleteBucket(name); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(name); err != nil {
+			return err
+		}
+	}
+	err = tx.Bucket(bucketResults).ForEach(func(k, v []byte) error {
+		var r models.Result
+		if err := json.Unmarshal(v, &r); err != nil {
+			return err
+		}
+		if err := tx.Bucket(bucketResultsByDiscipline).Put(indexKey(r.Discipline, k), nil); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketResultsByParticipant).Put(indexKey(r.ParticipantID, k), nil)
+	})
+	if err != nil {
+		return err
+	}
+	return meta.Put(metaVersion, []byte(strconv.Itoa(CurrentSchemaVersion)))
+}
+
+// positionKey encodes a 1-based row position as a sortable key.
+func positionKey(pos int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(pos))
+	return key
+}
+
+// indexKey builds "<value>\x00<row key>".
+func indexKey(value string, rowKey []byte) []byte {
+	k := make([]byte, 0, len(value)+1+len(rowKey))
+	k = append(k, value...)
+	k = append(k, 0)
+	return append(k, rowKey...)
+}
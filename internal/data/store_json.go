@@ -0,0 +1,133 @@
+package data
+
+import (
+	"fmt"
+
+	"chugware/internal/models"
+)
+
+// JSONStore is the original on-disk layout: participants.json, teams.json and
+// results.json, each a versioned envelope around a JSON array of records (see
+// schema.go). Every save rewrites the whole file (atomically, see
+// utils.SaveJSONFile).
+type JSONStore struct {
+	participantFile string
+	teamFile        string
+	resultFile      string
+}
+
+// NewJSONStore returns a store over the given files. Either path may be empty
+// if the caller only needs the other list. Teams are kept next to the
+// participant file.
+func NewJSONStore(participantFile, resultFile string) *JSONStore {
+	s := &JSONStore{participantFile: participantFile, resultFile: resultFile}
+	if participantFile != "" {
+		s.teamFile = TeamPathFor(participantFile)
+	}
+	return s
+}
+
+// LoadParticipants reads the participant file, upgrading older versions.
+func (s *JSONStore) LoadParticipants() ([]models.Participant, error) {
+	if s.participantFile == "" {
+		return nil, fmt.Errorf("no participant file set")
+	}
+	file, err := decodeDataFile(s.participantFile, SchemaParticipants)
+	if err != nil {
+		return nil, err
+	}
+	participants := make([]models.Participant, 0, len(file.Records))
+	if err := decodeRecords(file.Records, &participants); err != nil {
+		return nil, fmt.Errorf("error decoding participants in %s: %w", s.participantFile, err)
+	}
+	return participants, nil
+}
+
+// SaveParticipants rewrites the participant file at the current version.
+func (s *JSONStore) SaveParticipants(participants []models.Participant) error {
+	if s.participantFile == "" {
+		return fmt.Errorf("no file path set")
+	}
+	if participants == nil {
+		participants = []models.Participant{}
+	}
+	return encodeDataFile(s.participantFile, SchemaParticipants, participants)
+}
+
+// LoadTeams reads the team file. Contests from before teams existed have no
+// team file and simply have no teams.
+func (s *JSONStore) LoadTeams() ([]models.Team, error) {
+	if s.teamFile == "" {
+		return nil, fmt.Errorf("no team file set")
+	}
+	file, err := decodeDataFile(s.teamFile, SchemaTeams)
+	if err != nil {
+		return nil, err
+	}
+	teams := make([]models.Team, 0, len(file.Records))
+	if err := decodeRecords(file.Records, &teams); err != nil {
+		return nil, fmt.Errorf("error decoding teams in %s: %w", s.teamFile, err)
+	}
+	return teams, nil
+}
+
+// SaveTeams rewrites the team file at the current version.
+func (s *JSONStore) SaveTeams(teams []models.Team) error {
+	if s.teamFile == "" {
+		return fmt.Errorf("no file path set")
+	}
+	if teams == nil {
+		teams = []models.Team{}
+	}
+	return encodeDataFile(s.teamFile, SchemaTeams, teams)
+}
+
+// LoadResults reads the result file, upgrading older versions.
+func (s *JSONStore) LoadResults() ([]models.Result, error) {
+	if s.resultFile == "" {
+		return nil, fmt.Errorf("no result file set")
+	}
+	file, err := decodeDataFile(s.resultFile, SchemaResults)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]models.Result, 0, len(file.Records))
+	if err := decodeRecords(file.Records, &results); err != nil {
+		return nil, fmt.Errorf("error decoding results in %s: %w", s.resultFile, err)
+	}
+	return results, nil
+}
+
+// SaveResults rewrites the result file at the current version.
+func (s *JSONStore) SaveResults(results []models.Result) error {
+	if s.resultFile == "" {
+		return fmt.Errorf("no file path set")
+	}
+	if results == nil {
+		results = []models.Result{}
+	}
+	return encodeDataFile(s.resultFile, SchemaResults, results)
+}
+
+// ResultsByDiscipline loads the result file and filters it; JSON has no index.
+func (s *JSONStore) ResultsByDiscipline(discipline string) ([]models.Result, error) {
+	results, err := s.LoadResults()
+	if err != nil {
+		return nil, err
+	}
+	return filterResults(results, func(r models.Result) bool { return r.Discipline == discipline }), nil
+}
+
+// ResultsByParticipant loads the result file and filters it; JSON has no index.
+func (s *JSONStore) ResultsByParticipant(participantID string) ([]models.Result, error) {
+	results, err := s.LoadResults()
+	if err != nil {
+		return nil, err
+	}
+	return filterResults(results, func(r models.Result) bool { return r.ParticipantID == participantID }), nil
+}
+
+// Close is a no-op; files are not held open between calls.
+func (s *JSONStore) Close() error {
+	return nil
+}
@@ -0,0 +1,269 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+// storeFactories returns one constructor per Store implementation so the
+// contract tests below run against each of them.
+func storeFactories() map[string]func(t *testing.T) Store {
+	return map[string]func(t *testing.T) Store{
+		"json": func(t *testing.T) Store {
+			dir := t.TempDir()
+			return NewJSONStore(filepath.Join(dir, "participants.json"), filepath.Join(dir, "results.json"))
+		},
+		"bolt": func(t *testing.T) Store {
+			s, err := OpenBoltStore(filepath.Join(t.TempDir(), "contest.db"))
+			require.NoError(t, err)
+			t.Cleanup(func() { s.Close() })
+			return s
+		},
+	}
+}
+
+func sampleResults() []models.Result {
+	return []models.Result{
+		{ID: "a1", ParticipantID: "pa", Name: "Alice", Discipline: models.DisciplineBottle, Time: "00:00:05.0000", Status: models.StatusPass},
+		{ID: "b1", ParticipantID: "pb", Name: "Bob", Discipline: models.DisciplineBottle, Time: "00:00:06.0000", Status: models.StatusPass},
+		{ID: "a2", ParticipantID: "pa", Name: "Alice", Discipline: models.DisciplineHalfTankard, Time: "00:00:08.0000", Status: models.StatusPass},
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Store contract
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestStore_ParticipantsRoundTrip(t *testing.T) {
+	for name, newStore := range storeFactories() {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t)
+			empty, err := s.LoadParticipants()
+			require.NoError(t, err)
+			assert.Empty(t, empty)
+
+			in := []models.Participant{newParticipant("Alice"), newParticipant("Bob")}
+			require.NoError(t, s.SaveParticipants(in))
+
+			out, err := s.LoadParticipants()
+			require.NoError(t, err)
+			assert.Equal(t, in, out)
+		})
+	}
+}
+
+func TestStore_TeamsRoundTrip(t *testing.T) {
+	for name, newStore := range storeFactories() {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t)
+			empty, err := s.LoadTeams()
+			require.NoError(t, err)
+			assert.Empty(t, empty, "a contest without a team file has no teams")
+
+			in := []models.Team{
+				{ID: "t1", Name: "Red", Program: "F", Members: []string{"pb", "pa"}},
+				{ID: "t2", Name: "Blue", Program: "E", Members: []string{}},
+			}
+			require.NoError(t, s.SaveTeams(in))
+
+			out, err := s.LoadTeams()
+			require.NoError(t, err)
+			assert.Equal(t, in, out)
+		})
+	}
+}
+
+func TestStore_ResultsRoundTripKeepsOrder(t *testing.T) {
+	for name, newStore := range storeFactories() {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t)
+			in := sampleResults()
+			in[1].Voided = true
+			in[1].KeepOriginal()
+			in[1].EditReason = "false start"
+			require.NoError(t, s.SaveResults(in))
+
+			out, err := s.LoadResults()
+			require.NoError(t, err)
+			assert.Equal(t, in, out)
+		})
+	}
+}
+
+func TestStore_SaveResultsShrinks(t *testing.T) {
+	for name, newStore := range storeFactories() {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t)
+			require.NoError(t, s.SaveResults(sampleResults()))
+			require.NoError(t, s.SaveResults(sampleResults()[:1]))
+
+			out, err := s.LoadResults()
+			require.NoError(t, err)
+			require.Len(t, out, 1)
+			assert.Equal(t, "a1", out[0].ID)
+		})
+	}
+}
+
+func TestStore_ResultsByDisciplineAndParticipant(t *testing.T) {
+	for name, newStore := range storeFactories() {
+		t.Run(name, func(t *testing.T) {
+			s := newStore(t)
+			require.NoError(t, s.SaveResults(sampleResults()))
+
+			bottle, err := s.ResultsByDiscipline(models.DisciplineBottle)
+			require.NoError(t, err)
+			assert.Len(t, bottle, 2)
+
+			alice, err := s.ResultsByParticipant("pa")
+			require.NoError(t, err)
+			require.Len(t, alice, 2)
+			assert.Equal(t, "a1", alice[0].ID)
+			assert.Equal(t, "a2", alice[1].ID)
+		})
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// BoltStore specifics
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestBoltStore_IndexFollowsChangedRows(t *testing.T) {
+	s := storeFactories()["bolt"](t)
+	results := sampleResults()
+	require.NoError(t, s.SaveResults(results))
+
+	// Bob's row is corrected to the other discipline, Alice's last row dropped
+	results[1].Discipline = models.DisciplineHalfTankard
+	require.NoError(t, s.SaveResults(results[:2]))
+
+	bottle, err := s.ResultsByDiscipline(models.DisciplineBottle)
+	require.NoError(t, err)
+	require.Len(t, bottle, 1)
+	assert.Equal(t, "a1", bottle[0].ID)
+
+	half, err := s.ResultsByDiscipline(models.DisciplineHalfTankard)
+	require.NoError(t, err)
+	require.Len(t, half, 1)
+	assert.Equal(t, "b1", half[0].ID)
+}
+
+func TestBoltStore_SharedHandleAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contest.db")
+	first, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	second, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "the same file must share one handle")
+
+	require.NoError(t, first.SaveResults(sampleResults()))
+	require.NoError(t, first.Close())
+
+	reopened, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	defer reopened.Close()
+	out, err := reopened.LoadResults()
+	require.NoError(t, err)
+	assert.Len(t, out, 3)
+}
+
+func TestManagers_UseDatabaseForDBPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contest.db")
+	t.Cleanup(func() {
+		if s, err := OpenBoltStore(path); err == nil {
+			s.Close()
+		}
+	})
+
+	pm := NewParticipantManager()
+	pm.SetFilePath(path)
+	require.NoError(t, pm.AddParticipant(newParticipant("Alice")))
+	require.NoError(t, pm.SaveParticipants())
+
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.SaveResults())
+
+	pm2 := NewParticipantManager()
+	require.NoError(t, pm2.LoadParticipants(path))
+	assert.Len(t, pm2.GetParticipants(), 1)
+
+	s, err := OpenBoltStore(path)
+	require.NoError(t, err)
+	stored, err := s.LoadResults()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "00:00:05.0000", stored[0].Time)
+}
+
+func TestIsDatabasePath(t *testing.T) {
+	assert.True(t, IsDatabasePath("contest/contest.db"))
+	assert.True(t, IsDatabasePath("CONTEST.DB"))
+	assert.False(t, IsDatabasePath("contest/results.json"))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// MigrateContest
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestMigrateContest_JSONToDatabaseAndBack(t *testing.T) {
+	dir := t.TempDir()
+	pFile, rFile := ContestStorePaths(dir, BackendJSON)
+	require.NoError(t, NewJSONStore(pFile, rFile).SaveParticipants([]models.Participant{newParticipant("Alice")}))
+	require.NoError(t, NewJSONStore(pFile, rFile).SaveTeams([]models.Team{{ID: "t1", Name: "Red"}}))
+
+	rm := NewResultManager()
+	rm.SetFilePath(rFile)
+	require.NoError(t, rm.AddResult(models.Result{Name: "Alice", Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+	// results.json is never saved: the journal must be used as the source
+
+	report, err := MigrateContest(dir, BackendDatabase, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Participants)
+	assert.Equal(t, 1, report.Teams)
+	assert.Equal(t, 1, report.Results)
+
+	dbFile, _ := ContestStorePaths(dir, BackendDatabase)
+	db, err := OpenBoltStore(dbFile)
+	require.NoError(t, err)
+	stored, err := db.LoadResults()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, rm.GetResults()[0].ID, stored[0].ID)
+	require.NoError(t, db.Close())
+
+	// A second run refuses to overwrite, unless forced
+	_, err = MigrateContest(dir, BackendDatabase, false)
+	assert.Error(t, err)
+	_, err = MigrateContest(dir, BackendDatabase, true)
+	assert.NoError(t, err)
+
+	// And back again into a fresh folder layout
+	require.NoError(t, NewJSONStore(pFile, rFile).SaveParticipants(nil))
+	report, err = MigrateContest(dir, BackendJSON, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Participants)
+	participants, err := NewJSONStore(pFile, rFile).LoadParticipants()
+	require.NoError(t, err)
+	assert.Len(t, participants, 1)
+}
+
+func TestMigrateContest_NoSourceData(t *testing.T) {
+	_, err := MigrateContest(t.TempDir(), BackendDatabase, false)
+	assert.Error(t, err)
+}
+
+func TestMigrateContest_UnknownBackend(t *testing.T) {
+	_, err := MigrateContest(t.TempDir(), "sqlite", false)
+	assert.Error(t, err)
+}
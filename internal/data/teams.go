@@ -0,0 +1,320 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// TeamManager handles the teams of the team disciplines and their rosters.
+// Like ParticipantManager it is safe for concurrent use.
+type TeamManager struct {
+	mu       sync.Mutex
+	teams    []models.Team
+	filePath string
+	onSave   func()
+	// setupLocked reports whether the contest's setup is locked (see
+	// ContestRules.Locked); it is called with tm.mu held. Nil means never.
+	setupLocked func() bool
+}
+
+// NewTeamManager creates a new team manager
+func NewTeamManager() *TeamManager {
+	return &TeamManager{
+		teams: make([]models.Team, 0),
+	}
+}
+
+// LoadTeams loads teams from the store behind filePath: a JSON team file (see
+// TeamPathFor), or an embedded database if the path ends in .db. A missing
+// team file means the contest has no teams yet.
+func (tm *TeamManager) LoadTeams(filePath string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.filePath = filePath
+
+	store, err := teamStoreFor(filePath)
+	if err != nil {
+		return fmt.Errorf("error loading teams: %w", err)
+	}
+	teams, err := store.LoadTeams()
+	if err != nil {
+		return fmt.Errorf("error loading teams: %w", err)
+	}
+
+	tm.teams = teams
+	return nil
+}
+
+// SaveTeams saves teams to the store behind the file path
+func (tm *TeamManager) SaveTeams() error {
+	tm.mu.Lock()
+	err := tm.save()
+	onSave := tm.onSave
+	tm.mu.Unlock()
+
+	if err == nil && onSave != nil {
+		onSave()
+	}
+	return err
+}
+
+// save writes the teams; the caller holds tm.mu.
+func (tm *TeamManager) save() error {
+	if tm.filePath == "" {
+		return fmt.Errorf("no file path set")
+	}
+
+	store, err := teamStoreFor(tm.filePath)
+	if err != nil {
+		return err
+	}
+	return store.SaveTeams(tm.teams)
+}
+
+// SetFilePath sets the file path without loading from disk
+func (tm *TeamManager) SetFilePath(filePath string) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.filePath = filePath
+}
+
+// AddTeam adds a new team, generating its ID if it has none. Team names must
+// be unique within a contest. No team can be added while the setup is locked.
+func (tm *TeamManager) AddTeam(team models.Team) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if err := tm.checkSetup(); err != nil {
+		return err
+	}
+	if err := tm.validateTeam(team); err != nil {
+		return err
+	}
+	if team.ID == "" {
+		team.ID = utils.NewID()
+	}
+	if tm.index(team.ID) >= 0 {
+		return fmt.Errorf("team with ID '%s' already exists", team.ID)
+	}
+
+	tm.teams = append(tm.teams, cloneTeam(team))
+	return nil
+}
+
+// UpdateTeam replaces the team with the same ID, e.g. to change its roster or
+// correct its name. Results follow a rename via ResultManager.RenameTeam.
+// While the setup is locked the roster cannot be changed.
+func (tm *TeamManager) UpdateTeam(team models.Team) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	i := tm.index(team.ID)
+	if i < 0 {
+		return fmt.Errorf("team '%s' not found", team.ID)
+	}
+	if !sameMembers(tm.teams[i].Members, team.Members) {
+		if err := tm.checkSetup(); err != nil {
+			return &SetupLockedError{What: "the roster of " + tm.teams[i].Name}
+		}
+	}
+	if err := tm.validateTeam(team); err != nil {
+		return err
+	}
+	tm.teams[i] = cloneTeam(team)
+	return nil
+}
+
+// RemoveTeam removes the team with the given ID. Its recorded results are
+// kept. No team can be removed while the setup is locked.
+func (tm *TeamManager) RemoveTeam(id string) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if err := tm.checkSetup(); err != nil {
+		return err
+	}
+	i := tm.index(id)
+	if i < 0 {
+		return fmt.Errorf("team '%s' not found", id)
+	}
+	tm.teams = append(tm.teams[:i], tm.teams[i+1:]...)
+	return nil
+}
+
+// checkSetup returns a *SetupLockedError if the team list may not be changed.
+// The caller holds tm.mu.
+func (tm *TeamManager) checkSetup() error {
+	if tm.setupLocked != nil && tm.setupLocked() {
+		return &SetupLockedError{What: "the team list"}
+	}
+	return nil
+}
+
+// sameMembers reports whether two rosters list the same members in the same
+// running order.
+func sameMembers(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// DropMember takes a participant off every roster, e.g. after the participant
+// was deleted. It reports whether any roster changed.
+func (tm *TeamManager) DropMember(participantID string) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	changed := false
+	for i, t := range tm.teams {
+		members := t.Members[:0:0]
+		for _, m := range t.Members {
+			if m != participantID {
+				members = append(members, m)
+			}
+		}
+		if len(members) != len(t.Members) {
+			tm.teams[i].Members = members
+			changed = true
+		}
+	}
+	return changed
+}
+
+// GetTeam returns the team with the given ID.
+func (tm *TeamManager) GetTeam(id string) (models.Team, bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if i := tm.index(id); i >= 0 {
+		return cloneTeam(tm.teams[i]), true
+	}
+	return models.Team{}, false
+}
+
+// GetTeams returns a copy of all teams, rosters included.
+func (tm *TeamManager) GetTeams() []models.Team {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	cp := make([]models.Team, len(tm.teams))
+	for i, t := range tm.teams {
+		cp[i] = cloneTeam(t)
+	}
+	return cp
+}
+
+// validateTeam checks the fields of a team and that its name and roster are
+// free of duplicates. The caller holds tm.mu.
+func (tm *TeamManager) validateTeam(team models.Team) error {
+	if utils.IsNullString(team.Name) {
+		return fmt.Errorf("team name cannot be empty")
+	}
+	if !utils.IsStringValid(team.Name) || (team.Program != "" && !utils.IsStringValid(team.Program)) {
+		return fmt.Errorf("team data exceeds maximum length")
+	}
+	for _, t := range tm.teams {
+		if t.ID != team.ID && strings.EqualFold(strings.TrimSpace(t.Name), strings.TrimSpace(team.Name)) {
+			return fmt.Errorf("team '%s' already exists", team.Name)
+		}
+	}
+	seen := make(map[string]bool, len(team.Members))
+	for _, m := range team.Members {
+		if m == "" {
+			return fmt.Errorf("team '%s' has a roster entry without a participant", team.Name)
+		}
+		if seen[m] {
+			return fmt.Errorf("participant '%s' is on the roster of '%s' twice", m, team.Name)
+		}
+		seen[m] = true
+	}
+	return nil
+}
+
+// index returns the position of the team with the given ID, or -1.
+// The caller holds tm.mu.
+func (tm *TeamManager) index(id string) int {
+	if id == "" {
+		return -1
+	}
+	for i := range tm.teams {
+		if tm.teams[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// cloneTeam copies a team so its roster does not alias the manager's.
+func cloneTeam(t models.Team) models.Team {
+	t.Members = append([]string(nil), t.Members...)
+	return t
+}
+
+// RosterMembers returns a team's members in roster order. Members no longer
+// in participants are left out.
+func RosterMembers(team models.Team, participants []models.Participant) []models.Participant {
+	byID := make(map[string]models.Participant, len(participants))
+	for _, p := range participants {
+		byID[p.ID] = p
+	}
+	members := make([]models.Participant, 0, len(team.Members))
+	for _, id := range team.Members {
+		if p, ok := byID[id]; ok {
+			members = append(members, p)
+		}
+	}
+	return members
+}
+
+// RosterNames returns the names of a team's members in roster order. Members
+// no longer in participants are left out.
+func RosterNames(team models.Team, participants []models.Participant) []string {
+	members := RosterMembers(team, participants)
+	names := make([]string, len(members))
+	for i, p := range members {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// TeamsFromParticipants builds one team per distinct Participant.Team value,
+// with the participants of that team as its roster in list order. It is a
+// starting point for contests whose teams were only recorded as free text.
+// Names already taken by an existing team and the "N/A" placeholder are
+// skipped.
+func TeamsFromParticipants(participants []models.Participant, existing []models.Team) []models.Team {
+	taken := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		taken[strings.ToLower(strings.TrimSpace(t.Name))] = true
+	}
+
+	var teams []models.Team
+	byName := make(map[string]int)
+	for _, p := range participants {
+		name := strings.TrimSpace(p.Team)
+		key := strings.ToLower(name)
+		if name == "" || strings.EqualFold(name, "N/A") || taken[key] {
+			continue
+		}
+		i, ok := byName[key]
+		if !ok {
+			i = len(teams)
+			byName[key] = i
+			teams = append(teams, models.Team{Name: name, Program: p.Program})
+		}
+		teams[i].Members = append(teams[i].Members, p.ID)
+	}
+	return teams
+}
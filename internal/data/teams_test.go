@@ -0,0 +1,149 @@
+package data
+
+import (
+	"path/filepath"
+	"testing"
+
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// TeamManager
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestTeamManager_AddTeam(t *testing.T) {
+	tm := NewTeamManager()
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red", Members: []string{"p1", "p2"}}))
+
+	teams := tm.GetTeams()
+	require.Len(t, teams, 1)
+	assert.Len(t, teams[0].ID, 12)
+	assert.Equal(t, []string{"p1", "p2"}, teams[0].Members)
+}
+
+func TestTeamManager_AddTeam_Invalid(t *testing.T) {
+	tm := NewTeamManager()
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red"}))
+
+	assert.Error(t, tm.AddTeam(models.Team{Name: "  "}))
+	assert.Error(t, tm.AddTeam(models.Team{Name: "red"}), "team names are unique")
+	assert.Error(t, tm.AddTeam(models.Team{Name: "Blue", Members: []string{"p1", "p1"}}))
+	assert.Len(t, tm.GetTeams(), 1)
+}
+
+func TestTeamManager_GetTeams_ReturnsCopy(t *testing.T) {
+	tm := NewTeamManager()
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red", Members: []string{"p1"}}))
+
+	got := tm.GetTeams()
+	got[0].Members[0] = "mutated"
+	assert.Equal(t, "p1", tm.GetTeams()[0].Members[0])
+}
+
+func TestTeamManager_UpdateAndRemove(t *testing.T) {
+	tm := NewTeamManager()
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red", Members: []string{"p1"}}))
+	team := tm.GetTeams()[0]
+
+	team.Name = "Crimson"
+	team.Members = []string{"p2", "p1"}
+	require.NoError(t, tm.UpdateTeam(team))
+	got, ok := tm.GetTeam(team.ID)
+	require.True(t, ok)
+	assert.Equal(t, "Crimson", got.Name)
+	assert.Equal(t, []string{"p2", "p1"}, got.Members)
+
+	require.NoError(t, tm.RemoveTeam(team.ID))
+	assert.Empty(t, tm.GetTeams())
+	assert.Error(t, tm.RemoveTeam(team.ID))
+}
+
+func TestTeamManager_DropMember(t *testing.T) {
+	tm := NewTeamManager()
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red", Members: []string{"p1", "p2", "p3"}}))
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Blue", Members: []string{"p4"}}))
+
+	assert.True(t, tm.DropMember("p2"))
+	assert.Equal(t, []string{"p1", "p3"}, tm.GetTeams()[0].Members)
+	assert.False(t, tm.DropMember("p2"))
+}
+
+func TestTeamManager_SaveLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.json")
+	tm := NewTeamManager()
+	tm.SetFilePath(path)
+	require.NoError(t, tm.AddTeam(models.Team{Name: "Red", Program: "F", Members: []string{"p2", "p1"}}))
+	require.NoError(t, tm.SaveTeams())
+
+	tm2 := NewTeamManager()
+	require.NoError(t, tm2.LoadTeams(path))
+	assert.Equal(t, tm.GetTeams(), tm2.GetTeams())
+}
+
+func TestTeamManager_LoadMissingFile(t *testing.T) {
+	tm := NewTeamManager()
+	require.NoError(t, tm.LoadTeams(filepath.Join(t.TempDir(), "teams.json")))
+	assert.Empty(t, tm.GetTeams())
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// helpers
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestTeamPathFor(t *testing.T) {
+	assert.Equal(t, filepath.Join("contest", "teams.json"), TeamPathFor(filepath.Join("contest", "participants.json")))
+	assert.Equal(t, "contest.db", TeamPathFor("contest.db"))
+}
+
+func TestRosterNames(t *testing.T) {
+	participants := []models.Participant{{ID: "p1", Name: "Alice"}, {ID: "p2", Name: "Bob"}}
+	team := models.Team{Members: []string{"p2", "gone", "p1"}}
+	assert.Equal(t, []string{"Bob", "Alice"}, RosterNames(team, participants))
+}
+
+func TestTeamsFromParticipants(t *testing.T) {
+	participants := []models.Participant{
+		{ID: "p1", Name: "Alice", Program: "F", Team: "Red"},
+		{ID: "p2", Name: "Bob", Program: "E", Team: "Blue"},
+		{ID: "p3", Name: "Carol", Program: "F", Team: "red"},
+		{ID: "p4", Name: "Dave", Team: "N/A"},
+		{ID: "p5", Name: "Eve", Team: "Green"},
+	}
+	teams := TeamsFromParticipants(participants, []models.Team{{Name: "Green"}})
+	require.Len(t, teams, 2)
+	assert.Equal(t, "Red", teams[0].Name)
+	assert.Equal(t, "F", teams[0].Program)
+	assert.Equal(t, []string{"p1", "p3"}, teams[0].Members)
+	assert.Equal(t, "Blue", teams[1].Name)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// team results
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestResultManager_TeamResults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+	rm := NewResultManager()
+	rm.SetFilePath(path)
+	require.NoError(t, rm.AddResult(models.Result{TeamID: "t1", Name: "Red", Discipline: models.DisciplineTeamClash, BaseTime: "00:00:30.0000", Status: models.StatusPass}))
+	require.NoError(t, rm.AddResult(models.Result{Name: "Red", Discipline: models.DisciplineBottle, BaseTime: "00:00:05.0000", Status: models.StatusPass}))
+
+	team := rm.GetResults()[0]
+	assert.Empty(t, team.ParticipantID, "team results are not linked to a participant")
+	assert.Len(t, rm.GetResultsByTeam("t1"), 1)
+	assert.Len(t, rm.GetResultsByParticipant(LegacyParticipantID("Red")), 1)
+
+	// Renaming the team leaves the participant who happens to share its name alone
+	require.NoError(t, rm.RenameTeam("t1", "Crimson"))
+	require.NoError(t, rm.SaveResults())
+	rm2 := NewResultManager()
+	require.NoError(t, rm2.LoadResults(path))
+	results := rm2.GetResults()
+	assert.Equal(t, "Crimson", results[0].Name)
+	assert.Equal(t, "t1", results[0].TeamID)
+	assert.Equal(t, "Red", results[1].Name)
+}
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"strconv"
+
+	"chugware/internal/models"
+)
+
+// TriesEntitled returns the number of tries p was registered with for the
+// discipline def. Disciplines in the "322" code take their digit of it; the
+// others take p's own count for the discipline, if any. A missing count falls
+// back to the discipline's default. ok is false for team disciplines, which
+// have no try counts.
+func TriesEntitled(p models.Participant, def models.DisciplineDef) (tries int, ok bool) {
+	if def.Team {
+		return 0, false
+	}
+	var field string
+	switch def.CodeDigit {
+	case 1:
+		field = p.Bottle
+	case 2:
+		field = p.HalfTankard
+	case 3:
+		field = p.FullTankard
+	default:
+		if n, ok := p.Tries[def.Name]; ok {
+			return n, true
+		}
+		return def.DefaultTries, true
+	}
+	if field == "" {
+		return def.DefaultTries, true
+	}
+	tries, _ = strconv.Atoi(field)
+	return tries, true
+}
+
+// AttemptsUsed counts the attempts in results that used one of p's tries in
+// discipline. Every result recorded for p counts, whatever its status, except
+// voided ones: a voided attempt hands its try back, so the re-run that
+// replaces it is the one that counts. An amended result stays one attempt.
+func AttemptsUsed(results []models.Result, p models.Participant, discipline string) int {
+	used := 0
+	for _, r := range results {
+		if r.Discipline == discipline && !r.Voided && r.BelongsTo(p) {
+			used++
+		}
+	}
+	return used
+}
+
+// RemainingTries returns how many tries p has left in the discipline def: the
+// tries they are entitled to minus the attempts recorded in results, never
+// below 0. Disciplines without try counts have none left.
+func RemainingTries(p models.Participant, def models.DisciplineDef, results []models.Result) int {
+	entitled, ok := TriesEntitled(p, def)
+	if !ok {
+		return 0
+	}
+	if remaining := entitled - AttemptsUsed(results, p, def.Name); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// RemainingTries returns how many tries p has left in the discipline def,
+// computed from the attempts recorded so far.
+func (rm *ResultManager) RemainingTries(p models.Participant, def models.DisciplineDef) int {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	return RemainingTries(p, def, rm.results)
+}
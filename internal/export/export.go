@@ -0,0 +1,292 @@
+// Package export writes contest results as spreadsheets that open directly in
+// Excel or LibreOffice: XLSX (Office Open XML) and ODS (OpenDocument). Both
+// formats are written with the standard library only.
+package export
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// Export formats, named after their file extensions.
+const (
+	FormatXLSX = "xlsx"
+	FormatODS  = "ods"
+)
+
+// Cell is one spreadsheet cell, either text or a number.
+type Cell struct {
+	Text     string
+	Number   float64
+	IsNumber bool
+}
+
+// Text returns a text cell.
+func Text(s string) Cell {
+	return Cell{Text: s}
+}
+
+// Number returns a numeric cell.
+func Number(n float64) Cell {
+	return Cell{Number: n, IsNumber: true}
+}
+
+// Sheet is a named table. The first row is the header.
+type Sheet struct {
+	Name string
+	Rows [][]Cell
+}
+
+// Workbook is a list of sheets in tab order.
+type Workbook struct {
+	Sheets []Sheet
+}
+
+// Contest is what a contest export is built from. Voided results are left out.
+// Without Disciplines the built-in disciplines are used.
+type Contest struct {
+	Name         string
+	Date         string
+	Participants []models.Participant
+	Teams        []models.Team
+	Results      []models.Result
+	Disciplines  *data.DisciplineRegistry
+}
+
+// folderNameRe matches contest folders named <Name>_<YYYY-MM-DD>_<Official|Unofficial>
+var folderNameRe = regexp.MustCompile(`^(.+)_(\d{4}-\d{2}-\d{2})_(Official|Unofficial)$`)
+
+// ContestFromFolder returns the name and date of a contest from its folder
+// name. Folders not named by the Contest Wizard keep their name and no date.
+func ContestFromFolder(folder string) (name, date string) {
+	base := filepath.Base(folder)
+	m := folderNameRe.FindStringSubmatch(base)
+	if m == nil {
+		return base, ""
+	}
+	return strings.ReplaceAll(m[1], "_", " "), m[2]
+}
+
+// LoadContest reads the contest in folder (the folder made by the Contest
+// Wizard, holding contest/) from whichever store it uses.
+func LoadContest(folder string) (Contest, error) {
+	c := Contest{}
+	c.Name, c.Date = ContestFromFolder(folder)
+
+	store, err := data.OpenContestStore(filepath.Join(folder, config.ContestDirectory))
+	if err != nil {
+		return c, err
+	}
+	defer store.Close()
+
+	if c.Participants, err = store.LoadParticipants(); err != nil {
+		return c, fmt.Errorf("participants: %w", err)
+	}
+	if c.Teams, err = store.LoadTeams(); err != nil {
+		return c, fmt.Errorf("teams: %w", err)
+	}
+	if c.Results, err = store.LoadResults(); err != nil {
+		return c, fmt.Errorf("results: %w", err)
+	}
+	if c.Disciplines, err = data.LoadDisciplines(filepath.Join(folder, config.ContestDirectory, config.DisciplineFileName)); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// ResultColumns is the header of every discipline sheet.
+var ResultColumns = []string{"Rank", "Name", "Program", "Team", "Base", "Penalty", "Total", "Status", "Comment", "Penalties"}
+
+// ContestWorkbook builds the export of a contest: a summary sheet followed by
+// one sheet per discipline that has results, in the order of the contest's
+// disciplines. Each sheet follows the standings (see data.Standings): every
+// competitor's counting attempt with its rank, followed by their other
+// attempts without one.
+func ContestWorkbook(c Contest) Workbook {
+	registry := c.Disciplines
+	if registry == nil {
+		registry = data.DefaultDisciplineRegistry()
+	}
+
+	byDiscipline := make(map[string][]models.Result)
+	for _, r := range c.Results {
+		if !r.Voided {
+			byDiscipline[r.Discipline] = append(byDiscipline[r.Discipline], r)
+		}
+	}
+
+	disciplines := registry.Names()
+	var other []string
+	for d := range byDiscipline {
+		if _, ok := registry.Get(d); !ok {
+			other = append(other, d)
+		}
+	}
+	sort.Strings(other)
+	disciplines = append(disciplines, other...)
+
+	summary := Sheet{Name: "Summary"}
+	sheets := []Sheet{}
+	var total, passed, disqualified, failed int
+	var perDiscipline [][]Cell
+	for _, d := range disciplines {
+		results := byDiscipline[d]
+		if len(results) == 0 {
+			continue
+		}
+		def, ok := registry.Get(d)
+		if !ok {
+			def = models.DisciplineDef{Name: d}
+		}
+
+		sheet := Sheet{Name: sheetName(d), Rows: [][]Cell{textRow(ResultColumns...)}}
+		var dPassed int
+		winner, winnerTime := "", ""
+		for _, r := range rows(def, results) {
+			rank := Text("")
+			if r.rank > 0 {
+				rank = Number(float64(r.rank))
+				if r.rank == 1 {
+					winner, winnerTime = r.Name, r.Time
+				}
+			}
+			if r.Status == models.StatusPass {
+				dPassed++
+			}
+			program, team := c.programAndTeam(r.Result)
+			sheet.Rows = append(sheet.Rows, []Cell{
+				rank,
+				Text(r.Name),
+				Text(program),
+				Text(team),
+				Text(r.BaseTime),
+				Text(r.AdditionalTime),
+				Text(r.Time),
+				Text(r.Status),
+				Text(r.Comment),
+				Text(data.PenaltySummary(r.Penalties)),
+			})
+
+			total++
+			switch r.Status {
+			case models.StatusPass:
+				passed++
+			case models.StatusDisqualified:
+				disqualified++
+			case models.StatusFail:
+				failed++
+			}
+		}
+		sheets = append(sheets, sheet)
+		perDiscipline = append(perDiscipline, []Cell{
+			Text(d), Number(float64(len(results))), Number(float64(dPassed)), Text(winner), Text(winnerTime),
+		})
+	}
+
+	summary.Rows = [][]Cell{
+		textRow("Contest", c.Name),
+		textRow("Date", c.Date),
+		{Text("Participants"), Number(float64(len(c.Participants)))},
+		{Text("Teams"), Number(float64(len(c.Teams)))},
+		{Text("Results"), Number(float64(total))},
+		{Text("Passed"), Number(float64(passed))},
+		{Text("Disqualified"), Number(float64(disqualified))},
+		{Text("Failed"), Number(float64(failed))},
+		{},
+		textRow("Discipline", "Results", "Passed", "Winner", "Winning Time"),
+	}
+	summary.Rows = append(summary.Rows, perDiscipline...)
+
+	return Workbook{Sheets: append([]Sheet{summary}, sheets...)}
+}
+
+// rankedRow is a sheet row: a result and its rank, 0 for none.
+type rankedRow struct {
+	models.Result
+	rank int
+}
+
+// rows lays out the standings of a discipline: each competitor's counting
+// attempt, ranked, followed by their other attempts.
+func rows(def models.DisciplineDef, results []models.Result) []rankedRow {
+	var out []rankedRow
+	for _, st := range data.Standings(def, results) {
+		out = append(out, rankedRow{Result: st.Result, rank: st.Rank})
+		for _, o := range st.Others {
+			out = append(out, rankedRow{Result: o})
+		}
+	}
+	return out
+}
+
+// programAndTeam returns the program and team columns of a result. For a team
+// result the team column lists the roster in running order.
+func (c Contest) programAndTeam(r models.Result) (string, string) {
+	if r.IsTeamResult() {
+		for _, t := range c.Teams {
+			if t.ID == r.TeamID {
+				return t.Program, strings.Join(data.RosterNames(t, c.Participants), ", ")
+			}
+		}
+		return "", ""
+	}
+	for _, p := range c.Participants {
+		if r.BelongsTo(p) {
+			return p.Program, p.Team
+		}
+	}
+	return "", ""
+}
+
+// sheetName makes name valid as a sheet name in both formats: at most 31
+// characters and none of []:*?/\.
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func textRow(values ...string) []Cell {
+	row := make([]Cell, len(values))
+	for i, v := range values {
+		row[i] = Text(v)
+	}
+	return row
+}
+
+// Encode returns the workbook in format.
+func (wb Workbook) Encode(format string) ([]byte, error) {
+	switch format {
+	case FormatXLSX:
+		return wb.XLSX()
+	case FormatODS:
+		return wb.ODS()
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// WriteFile writes the workbook to filename in the format given by its
+// extension (.xlsx or .ods).
+func (wb Workbook) WriteFile(filename string) error {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	content, err := wb.Encode(format)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(filename, content, 0644)
+}
@@ -0,0 +1,239 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContest() Contest {
+	return Contest{
+		Name: "Sittning",
+		Date: "2026-05-01",
+		Participants: []models.Participant{
+			{ID: "p1", Name: "Alice", Program: "D", Team: "Red"},
+			{ID: "p2", Name: "Bob", Program: "E", Team: "Blue"},
+			{ID: "p3", Name: "Åsa & Co", Program: "F", Team: "Red"},
+		},
+		Teams: []models.Team{
+			{ID: "t1", Name: "Red", Program: "D", Members: []string{"p3", "p1"}},
+		},
+		Results: []models.Result{
+			{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, Time: "00:00:09.0000", BaseTime: "00:00:09.0000", Status: models.StatusPass},
+			{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineBottle, Time: "00:00:20.0000", Status: models.StatusDisqualified, Comment: "spill"},
+			{ParticipantID: "p3", Name: "Åsa & Co", Discipline: models.DisciplineBottle, Time: "00:00:07.5000", AdditionalTime: "00:00:01.0000", Status: models.StatusPass},
+			{ParticipantID: "p2", Name: "Bob", Discipline: models.DisciplineBottle, Time: "00:00:01.0000", Status: models.StatusPass, Voided: true},
+			{TeamID: "t1", Name: "Red", Discipline: models.DisciplineBierStaphette, Time: "00:00:30.0000", Status: models.StatusPass},
+		},
+	}
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// ContestWorkbook
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestContestWorkbook_SheetsPerDiscipline(t *testing.T) {
+	wb := ContestWorkbook(testContest())
+
+	var names []string
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Summary", models.DisciplineBottle, models.DisciplineBierStaphette}, names)
+
+	bottle := wb.Sheets[1]
+	assert.Equal(t, textRow(ResultColumns...), bottle.Rows[0])
+	require.Len(t, bottle.Rows, 4, "header plus three results; the voided one is left out")
+	assert.Equal(t, []Cell{
+		Number(1), Text("Åsa & Co"), Text("F"), Text("Red"), Text(""), Text("00:00:01.0000"), Text("00:00:07.5000"), Text(models.StatusPass), Text(""), Text(""),
+	}, bottle.Rows[1])
+	assert.Equal(t, Number(2), bottle.Rows[2][0])
+	assert.Equal(t, Text(""), bottle.Rows[3][0], "results that did not pass have no rank")
+	assert.Equal(t, Text("spill"), bottle.Rows[3][8])
+
+	relay := wb.Sheets[2]
+	assert.Equal(t, Text("D"), relay.Rows[1][2])
+	assert.Equal(t, Text("Åsa & Co, Alice"), relay.Rows[1][3], "team results list the roster")
+}
+
+func TestContestWorkbook_ContestDisciplines(t *testing.T) {
+	c := testContest()
+	var err error
+	c.Disciplines, err = data.NewDisciplineRegistry([]models.DisciplineDef{
+		{Name: models.DisciplineBierStaphette, Team: true, Relay: true},
+		{Name: models.DisciplineBottle, CodeDigit: 1, Ranking: models.RankByBase},
+	})
+	require.NoError(t, err)
+	c.Results[2].Time, c.Results[2].BaseTime = "00:00:09.5000", "00:00:06.5000"
+	c.Results = append(c.Results, models.Result{ParticipantID: "p1", Name: "Alice", Discipline: "Shot", Time: "00:00:02.0000", Status: models.StatusPass})
+
+	wb := ContestWorkbook(c)
+	var names []string
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Summary", models.DisciplineBierStaphette, models.DisciplineBottle, "Shot"}, names,
+		"the contest's order, then disciplines it does not define")
+
+	bottle := wb.Sheets[2]
+	assert.Equal(t, Text("Åsa & Co"), bottle.Rows[1][1], "ranked by base time, not the slower total")
+	assert.Equal(t, Text("Alice"), bottle.Rows[2][1])
+}
+
+func TestContestWorkbook_OtherAttempts(t *testing.T) {
+	c := testContest()
+	c.Results = append(c.Results, models.Result{ParticipantID: "p1", Name: "Alice", Discipline: models.DisciplineBottle, Time: "00:00:08.0000", Status: models.StatusPass})
+
+	bottle := ContestWorkbook(c).Sheets[1]
+	require.Len(t, bottle.Rows, 5)
+	assert.Equal(t, []Cell{Number(2), Text("Alice")}, bottle.Rows[2][:2], "Alice's best attempt counts")
+	assert.Equal(t, Text("00:00:08.0000"), bottle.Rows[2][6])
+	assert.Equal(t, []Cell{Text(""), Text("Alice")}, bottle.Rows[3][:2], "her other attempt follows without a rank")
+	assert.Equal(t, Text("00:00:09.0000"), bottle.Rows[3][6])
+}
+
+func TestContestWorkbook_Penalties(t *testing.T) {
+	c := testContest()
+	c.Results[2].Penalties = []models.Penalty{{Name: "Spill", Time: "00:00:01.0000"}, {Name: "Foam left"}}
+
+	bottle := ContestWorkbook(c).Sheets[1]
+	assert.Equal(t, Text("Spill +00:00:01.0000, Foam left"), bottle.Rows[1][9], "the penalties behind the penalty time")
+}
+
+func TestContestWorkbook_Summary(t *testing.T) {
+	summary := ContestWorkbook(testContest()).Sheets[0]
+
+	assert.Equal(t, textRow("Contest", "Sittning"), summary.Rows[0])
+	assert.Contains(t, summary.Rows, []Cell{Text("Results"), Number(4)})
+	assert.Contains(t, summary.Rows, []Cell{Text("Passed"), Number(3)})
+	assert.Contains(t, summary.Rows, []Cell{Text("Disqualified"), Number(1)})
+	assert.Contains(t, summary.Rows, []Cell{
+		Text(models.DisciplineBottle), Number(3), Number(2), Text("Åsa & Co"), Text("00:00:07.5000"),
+	})
+}
+
+func TestContestFromFolder(t *testing.T) {
+	name, date := ContestFromFolder(filepath.Join("ChugWare", "Spring_Chug_2026-05-01_Official"))
+	assert.Equal(t, "Spring Chug", name)
+	assert.Equal(t, "2026-05-01", date)
+
+	name, date = ContestFromFolder("practice")
+	assert.Equal(t, "practice", name)
+	assert.Empty(t, date)
+}
+
+func TestLoadContest(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "Spring_Chug_2026-05-01_Official")
+	want := testContest()
+	store := data.NewJSONStore(data.ContestStorePaths(filepath.Join(folder, config.ContestDirectory), data.BackendJSON))
+	require.NoError(t, store.SaveParticipants(want.Participants))
+	require.NoError(t, store.SaveTeams(want.Teams))
+	require.NoError(t, store.SaveResults(want.Results))
+
+	c, err := LoadContest(folder)
+	require.NoError(t, err)
+	assert.Equal(t, "Spring Chug", c.Name)
+	assert.Equal(t, want.Participants, c.Participants)
+	assert.Equal(t, want.Teams, c.Teams)
+	assert.Len(t, c.Results, len(want.Results))
+}
+
+func TestSheetName(t *testing.T) {
+	assert.Equal(t, "a_b_c", sheetName("a/b?c"))
+	assert.Len(t, sheetName(strings.Repeat("x", 40)), 31)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "BA", columnName(52))
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// XLSX / ODS
+// ─────────────────────────────────────────────────────────────────────────────
+
+// unzip returns the entries of an archive by name, and their order.
+func unzip(t *testing.T, content []byte) (map[string]string, []*zip.File) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		files[f.Name] = string(b)
+
+		// Every part must be well-formed XML
+		if strings.HasSuffix(f.Name, ".xml") || strings.HasSuffix(f.Name, ".rels") {
+			dec := xml.NewDecoder(bytes.NewReader(b))
+			for {
+				_, err := dec.Token()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err, f.Name)
+			}
+		}
+	}
+	return files, zr.File
+}
+
+func TestWorkbook_XLSX(t *testing.T) {
+	content, err := ContestWorkbook(testContest()).XLSX()
+	require.NoError(t, err)
+
+	files, _ := unzip(t, content)
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Bier Staphette" sheetId="3" r:id="rId3"/>`)
+	assert.Contains(t, files["xl/worksheets/sheet2.xml"], `<c r="B2" t="inlineStr"><is><t xml:space="preserve">Åsa &amp; Co</t></is></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet2.xml"], `<c r="A2"><v>1</v></c>`)
+}
+
+func TestWorkbook_ODS(t *testing.T) {
+	content, err := ContestWorkbook(testContest()).ODS()
+	require.NoError(t, err)
+
+	files, entries := unzip(t, content)
+	assert.Equal(t, "mimetype", entries[0].Name)
+	assert.Equal(t, zip.Store, entries[0].Method)
+	assert.Equal(t, odsMimeType, files["mimetype"])
+	assert.Contains(t, files, "META-INF/manifest.xml")
+	assert.Contains(t, files["content.xml"], `<table:table table:name="Bottle">`)
+	assert.Contains(t, files["content.xml"], `<table:table-cell office:value-type="string"><text:p>Åsa &amp; Co</text:p></table:table-cell>`)
+	assert.Contains(t, files["content.xml"], `<table:table-cell office:value-type="float" office:value="1"><text:p>1</text:p></table:table-cell>`)
+}
+
+func TestWorkbook_WriteFile(t *testing.T) {
+	dir := t.TempDir()
+	wb := ContestWorkbook(testContest())
+
+	require.NoError(t, wb.WriteFile(filepath.Join(dir, "results.xlsx")))
+	require.NoError(t, wb.WriteFile(filepath.Join(dir, "results.ODS")))
+	for _, name := range []string{"results.xlsx", "results.ODS"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Positive(t, info.Size())
+	}
+
+	assert.Error(t, wb.WriteFile(filepath.Join(dir, "results.csv")))
+}
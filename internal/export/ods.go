@@ -0,0 +1,91 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// odsMimeType must be the first, uncompressed entry of an ODS file.
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>
+<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>
+</manifest:manifest>`
+
+// ODS returns the workbook as an OpenDocument spreadsheet.
+func (wb Workbook) ODS() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name, content string
+		method        uint16
+	}{
+		{"mimetype", odsMimeType, zip.Store},
+		{"META-INF/manifest.xml", odsManifest, zip.Deflate},
+		{"content.xml", odsContent(wb), zip.Deflate},
+	}
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: f.method})
+		if err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", f.name, err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error writing spreadsheet: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// odsContent renders every sheet into content.xml. The first row of each
+// sheet is bold.
+func odsContent(wb Workbook) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<office:document-content` +
+		` xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+		` xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"` +
+		` xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"` +
+		` xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"` +
+		` xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"` +
+		` office:version="1.2">`)
+	b.WriteString(`<office:automatic-styles>` +
+		`<style:style style:name="header" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>` +
+		`</office:automatic-styles>`)
+	b.WriteString(`<office:body><office:spreadsheet>`)
+	for _, sheet := range wb.Sheets {
+		fmt.Fprintf(&b, `<table:table table:name="%s">`, escapeXML(sheet.Name))
+		for r, row := range sheet.Rows {
+			style := ""
+			if r == 0 {
+				style = ` table:style-name="header"`
+			}
+			b.WriteString(`<table:table-row>`)
+			for _, cell := range row {
+				switch {
+				case cell.IsNumber:
+					n := formatNumber(cell.Number)
+					fmt.Fprintf(&b, `<table:table-cell%s office:value-type="float" office:value="%s"><text:p>%s</text:p></table:table-cell>`, style, n, n)
+				case cell.Text != "":
+					fmt.Fprintf(&b, `<table:table-cell%s office:value-type="string"><text:p>%s</text:p></table:table-cell>`, style, escapeXML(cell.Text))
+				default:
+					b.WriteString(`<table:table-cell/>`)
+				}
+			}
+			if len(row) == 0 {
+				b.WriteString(`<table:table-cell/>`)
+			}
+			b.WriteString(`</table:table-row>`)
+		}
+		b.WriteString(`</table:table>`)
+	}
+	b.WriteString(`</office:spreadsheet></office:body></office:document-content>`)
+	return b.String()
+}
@@ -3,24 +3,119 @@ package models
 import "time"
 
 // Participant represents a contest participant
+// ID is generated when the participant is registered and never changes, so
+// the name can be corrected without losing the participant's results.
+// Bottle, HalfTankard and FullTankard hold the tries the participant is
+// entitled to; the tries left are counted from their recorded attempts.
+// Categories names the categories the participant also competes in, each
+// with leaderboards of its own.
 type Participant struct {
+	ID          string `json:"id"`
 	Name        string `json:"name"`
 	Program     string `json:"program"`
 	Team        string `json:"team"`
 	Bottle      string `json:"bottle"`
 	HalfTankard string `json:"half_tankard"`
 	FullTankard string `json:"full_tankard"`
+
+	// Tries for disciplines outside the "322" code, by discipline name
+	Tries map[string]int `json:"tries,omitempty"`
+
+	Categories []string `json:"categories,omitempty"`
+}
+
+// Category is a group of participants with leaderboards of their own, such
+// as women, alumni or first-years. Diplomas tells whether its top three in
+// each discipline get diplomas.
+type Category struct {
+	Name     string `json:"name"`
+	Diplomas bool   `json:"diplomas,omitempty"`
 }
 
-// Result represents a contest result for a participant
+// Team represents a team entered in the team disciplines (Bier Staphette,
+// Mega Medley, Team Clash). Members lists participant IDs in running order.
+type Team struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Program string   `json:"program"`
+	Members []string `json:"members"`
+}
+
+// Split is one leg of a relay: the member who ran it and their leg time.
+type Split struct {
+	ParticipantID string `json:"participant_id"`
+	Name          string `json:"name"`
+	Time          string `json:"time"`
+}
+
+// Result represents a contest result for a participant or, in a team
+// discipline, for a team.
+// ParticipantID refers to Participant.ID and TeamID to Team.ID; only one of
+// them is set. Name is a copy of the participant's or team's name kept for
+// display and follows renames. A relay result lists its legs in running order
+// in Splits; their times add up to Time.
 type Result struct {
-	Name           string `json:"name"`
-	Discipline     string `json:"discipline"`
-	Time           string `json:"time"`
-	BaseTime       string `json:"base_time"`
-	AdditionalTime string `json:"additional_time"`
-	Status         string `json:"status"`
-	Comment        string `json:"comment"`
+	ID             string  `json:"id"`
+	ParticipantID  string  `json:"participant_id"`
+	TeamID         string  `json:"team_id,omitempty"`
+	Name           string  `json:"name"`
+	Discipline     string  `json:"discipline"`
+	Time           string  `json:"time"`
+	BaseTime       string  `json:"base_time"`
+	AdditionalTime string  `json:"additional_time"`
+	Status         string  `json:"status"`
+	Comment        string  `json:"comment"`
+	Splits         []Split `json:"splits,omitempty"`
+	// Penalties itemizes the penalties picked from the discipline's catalog;
+	// their times are included in AdditionalTime.
+	Penalties []Penalty `json:"penalties,omitempty"`
+
+	// Corrections made after the fact. The Original* fields keep the values
+	// as first recorded; they stay empty until the result is amended or voided.
+	Voided                 bool      `json:"voided,omitempty"`
+	EditReason             string    `json:"edit_reason,omitempty"`
+	OriginalTime           string    `json:"original_time,omitempty"`
+	OriginalBaseTime       string    `json:"original_base_time,omitempty"`
+	OriginalAdditionalTime string    `json:"original_additional_time,omitempty"`
+	OriginalStatus         string    `json:"original_status,omitempty"`
+	OriginalComment        string    `json:"original_comment,omitempty"`
+	OriginalPenalties      []Penalty `json:"original_penalties,omitempty"`
+}
+
+// BelongsTo reports whether the result was recorded for p. Results without a
+// participant reference are matched by name.
+func (r Result) BelongsTo(p Participant) bool {
+	if r.IsTeamResult() {
+		return false
+	}
+	if r.ParticipantID != "" && p.ID != "" {
+		return r.ParticipantID == p.ID
+	}
+	return r.Name == p.Name
+}
+
+// IsTeamResult reports whether the result was recorded for a team.
+func (r Result) IsTeamResult() bool {
+	return r.TeamID != ""
+}
+
+// IsCorrected reports whether the result has been amended or voided.
+func (r Result) IsCorrected() bool {
+	return r.OriginalStatus != ""
+}
+
+// KeepOriginal copies the current values into the Original* fields unless an
+// earlier correction already did so.
+func (r *Result) KeepOriginal() {
+	if r.IsCorrected() {
+		return
+	}
+	r.OriginalTime = r.Time
+	r.OriginalBaseTime = r.BaseTime
+	r.OriginalAdditionalTime = r.AdditionalTime
+	r.OriginalStatus = r.Status
+	r.OriginalComment = r.Comment
+	r.OriginalPenalties = r.Penalties
 }
 
 // Contest represents a contest configuration
@@ -52,9 +147,14 @@ type ContestSettings struct {
 	// External clock / serial device
 	ExternalClockPort string `json:"external_clock_port"`
 	ExternalClockBaud int    `json:"external_clock_baud"`
+
+	// Audit trail identity recorded in the result journal
+	StationID    string `json:"station_id"`
+	OperatorName string `json:"operator_name"`
 }
 
-// Discipline types
+// Names of the built-in disciplines, used when a contest has no discipline
+// definitions file of its own
 const (
 	DisciplineBottle        = "Bottle"
 	DisciplineHalfTankard   = "Half Tankard"
@@ -64,6 +164,143 @@ const (
 	DisciplineTeamClash     = "Team Clash"
 )
 
+// DisciplineDef defines one discipline of a contest.
+// Individual disciplines take their tries from one digit of the participant's
+// "322" code (CodeDigit, 1-based) or, for disciplines outside the code, from
+// Participant.Tries and otherwise DefaultTries. Team disciplines have no
+// tries; a relay is timed leg by leg in roster order. Attempts decides which
+// of a competitor's attempts counts for the standings, and TieBreak how
+// competitors with equal times are ordered.
+type DisciplineDef struct {
+	Name         string      `json:"name"`
+	Team         bool        `json:"team,omitempty"`
+	Relay        bool        `json:"relay,omitempty"`
+	DefaultTries int         `json:"default_tries,omitempty"`
+	CodeDigit    int         `json:"code_digit,omitempty"`
+	Penalty      PenaltyRule `json:"penalty"`
+	TimeLimit    string      `json:"time_limit,omitempty"` // slower passed results fail
+	Ranking      string      `json:"ranking,omitempty"`    // RankByTotal (default) or RankByBase
+	Attempts     string      `json:"attempts,omitempty"`   // CountBest (default), CountLast or CountAverage
+	AverageOf    int         `json:"average_of,omitempty"` // attempts averaged with CountAverage
+	TieBreak     []string    `json:"tie_break,omitempty"`  // TieBreak* rules in order; an unbroken tie is shared
+	Points       []int       `json:"points,omitempty"`     // championship points by place; default 10-8-6-5-4-3-2-1
+}
+
+// PenaltyRule describes how penalty (additional) time is given in a
+// discipline. With Prompt the penalty is asked for in a dialog after every
+// run, as the spill check of Bottle; results disqualified there get DQReason
+// as their comment. More penalty time than Max disqualifies a result. The
+// Catalog lists the penalties a judge can pick in that dialog.
+type PenaltyRule struct {
+	Prompt   bool          `json:"prompt,omitempty"`
+	DQReason string        `json:"dq_reason,omitempty"`
+	Max      string        `json:"max,omitempty"`
+	Catalog  []PenaltyItem `json:"catalog,omitempty"`
+}
+
+// PenaltyItem is a penalty of a discipline's catalog, e.g. a spill. Each time
+// it is given it adds Time to the result; given DQAfter times (1: at once) it
+// disqualifies the result instead.
+type PenaltyItem struct {
+	Name    string `json:"name"`
+	Time    string `json:"time,omitempty"`
+	DQAfter int    `json:"dq_after,omitempty"`
+}
+
+// Penalty is one penalty given on a result, with the time it added.
+type Penalty struct {
+	Name string `json:"name"`
+	Time string `json:"time,omitempty"`
+}
+
+// Ranking policies: the time passed results are ranked by
+const (
+	RankByTotal = "total" // base time plus penalty
+	RankByBase  = "base"  // base time only; penalties do not count
+)
+
+// Attempt policies: which attempts of a competitor count for the standings
+const (
+	CountBest    = "best"    // the fastest passed attempt
+	CountLast    = "last"    // the latest attempt, passed or not
+	CountAverage = "average" // the mean of the AverageOf fastest passed attempts
+)
+
+// Tie-break rules: how competitors with equal ranking times are ordered
+const (
+	TieBreakPenalty = "penalty" // less penalty time first
+	TieBreakSecond  = "second"  // faster second-best passed attempt first
+	TieBreakEarlier = "earlier" // the attempt recorded first, first
+	TieBreakShared  = "shared"  // the competitors share the placing
+)
+
+// Bracket is the knockout draw of a head-to-head team discipline such as
+// Team Clash. Seeds lists the team IDs by seed, first seed first. Each match
+// sends its winner, and in double elimination its loser, on to a later match
+// until the final decides the champion.
+type Bracket struct {
+	Discipline string   `json:"discipline"`
+	Format     string   `json:"format"` // BracketSingle or BracketDouble
+	Seeds      []string `json:"seeds"`
+	Matches    []Match  `json:"matches"`
+}
+
+// Match is one heat of a bracket between the teams in slots A and B. An
+// empty slot is still to be decided; BracketBye fills a slot no team will
+// take. WinnerTo and LoserTo name the matches the winner and loser go on to,
+// in the slot given by WinnerSlot and LoserSlot (0 for A, 1 for B).
+type Match struct {
+	ID       string `json:"id"`      // e.g. "W1.2": winners bracket, round 1, match 2
+	Section  string `json:"section"` // BracketWinners, BracketLosers or BracketFinal
+	Round    int    `json:"round"`
+	A        string `json:"a"`
+	B        string `json:"b"`
+	Winner   string `json:"winner,omitempty"`
+	Decision string `json:"decision,omitempty"` // DecisionTime, DecisionJudge or DecisionBye
+	TimeA    string `json:"time_a,omitempty"`
+	TimeB    string `json:"time_b,omitempty"`
+
+	WinnerTo   string `json:"winner_to,omitempty"`
+	WinnerSlot int    `json:"winner_slot,omitempty"`
+	LoserTo    string `json:"loser_to,omitempty"`
+	LoserSlot  int    `json:"loser_slot,omitempty"`
+}
+
+// Bracket formats
+const (
+	BracketSingle = "single" // a team is out after its first loss
+	BracketDouble = "double" // a team is out after its second loss
+)
+
+// Bracket sections
+const (
+	BracketWinners = "winners"
+	BracketLosers  = "losers"
+	BracketFinal   = "final" // the grand final of double elimination
+)
+
+// BracketBye fills a bracket slot that no team takes; the other team goes
+// through without a match.
+const BracketBye = "bye"
+
+// Match decisions
+const (
+	DecisionTime  = "time"  // the faster team won
+	DecisionJudge = "judge" // the judges picked the winner
+	DecisionBye   = "bye"   // the opponent was a bye
+)
+
+// RunningOrder is the order the participants or teams of a discipline are
+// called up in. It is drawn at random from Seed, so the same seed and field
+// draw the same order again, and may then be rearranged by hand. Skipped
+// holds the IDs left out of the queue until the skipped list is cleared.
+type RunningOrder struct {
+	Discipline string   `json:"discipline"`
+	Seed       int64    `json:"seed"`
+	Order      []string `json:"order,omitempty"` // participant or team IDs, first to run first
+	Skipped    []string `json:"skipped,omitempty"`
+}
+
 // Status types
 const (
 	StatusPass         = "Pass"
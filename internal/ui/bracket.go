@@ -0,0 +1,423 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/models"
+)
+
+// Bracket format choices, by their label in the format select
+var bracketFormats = map[string]string{
+	"Single elimination": models.BracketSingle,
+	"Double elimination": models.BracketDouble,
+}
+
+// seedByTeamOrder is the seeding choice that keeps the order of the team
+// list; the others seed by the results of a team discipline.
+const seedByTeamOrder = "Team order"
+
+// BracketManager draws the knockout brackets of the team disciplines and
+// records the winners of their matches, by time or by the judges. It works
+// on the teams and results of the Chug Manager it was opened from.
+type BracketManager struct {
+	app    fyne.App
+	window fyne.Window
+
+	teamMgr     *data.TeamManager
+	resultMgr   *data.ResultManager
+	disciplines *data.DisciplineRegistry
+
+	// UI Components - Draw
+	disciplineSelect *widget.Select
+	formatSelect     *widget.Select
+	seedSelect       *widget.Select
+	generateBtn      *widget.Button
+
+	// UI Components - Bracket
+	bracketArea *fyne.Container
+	statusLabel *widget.Label
+
+	// UI Components - Match entry
+	matchSelect *widget.Select
+	timeAEntry  *widget.Entry
+	timeBEntry  *widget.Entry
+	byTimeBtn   *widget.Button
+	aWinsBtn    *widget.Button
+	bWinsBtn    *widget.Button
+
+	// Data
+	path     string
+	brackets []models.Bracket
+	ready    []models.Match // the matches in matchSelect
+}
+
+// NewBracketManager creates a new bracket window over the given managers,
+// showing the bracket of discipline if it is a team discipline.
+func NewBracketManager(app fyne.App, teamMgr *data.TeamManager, resultMgr *data.ResultManager, disciplines *data.DisciplineRegistry, discipline string) *BracketManager {
+	bm := &BracketManager{
+		app:         app,
+		window:      app.NewWindow("Bracket - Knockout Matches"),
+		teamMgr:     teamMgr,
+		resultMgr:   resultMgr,
+		disciplines: disciplines,
+		path:        data.BracketPathFor(config.Settings.ParticipantFile),
+	}
+
+	bm.setupUI()
+	bm.loadBrackets(discipline)
+	return bm
+}
+
+// setupUI initializes the bracket UI
+func (bm *BracketManager) setupUI() {
+	bm.window.Resize(fyne.NewSize(1100, 700))
+	bm.window.CenterOnScreen()
+
+	var teamDisciplines []string
+	for _, def := range bm.disciplines.Defs() {
+		if def.Team {
+			teamDisciplines = append(teamDisciplines, def.Name)
+		}
+	}
+	bm.disciplineSelect = widget.NewSelect(teamDisciplines, func(string) { bm.showBracket() })
+	bm.disciplineSelect.PlaceHolder = "Select a team discipline"
+	bm.formatSelect = widget.NewSelect([]string{"Single elimination", "Double elimination"}, nil)
+	bm.formatSelect.SetSelected("Single elimination")
+	bm.seedSelect = widget.NewSelect(append([]string{seedByTeamOrder}, teamDisciplines...), nil)
+	bm.seedSelect.SetSelected(seedByTeamOrder)
+	bm.generateBtn = widget.NewButton("Generate Bracket", bm.generateBracket)
+
+	bm.bracketArea = container.NewStack()
+	bm.statusLabel = widget.NewLabel("")
+
+	bm.matchSelect = widget.NewSelect(nil, func(string) { bm.updateMatchButtons() })
+	bm.matchSelect.PlaceHolder = "Select a match"
+	bm.timeAEntry = widget.NewEntry()
+	bm.timeAEntry.SetPlaceHolder("Time of A (e.g. 12.5)")
+	bm.timeBEntry = widget.NewEntry()
+	bm.timeBEntry.SetPlaceHolder("Time of B")
+	bm.byTimeBtn = widget.NewButton("Record by Time", bm.recordByTime)
+	bm.aWinsBtn = widget.NewButton("A Wins (Judges)", func() { bm.recordByJudges(0) })
+	bm.bWinsBtn = widget.NewButton("B Wins (Judges)", func() { bm.recordByJudges(1) })
+
+	draw := widget.NewCard("Draw", "",
+		container.NewVBox(
+			widget.NewFormItem("Discipline", bm.disciplineSelect).Widget,
+			widget.NewFormItem("Format", bm.formatSelect).Widget,
+			widget.NewFormItem("Seed by", bm.seedSelect).Widget,
+			bm.generateBtn,
+		),
+	)
+	entry := widget.NewCard("Record Match", "",
+		container.NewVBox(
+			bm.matchSelect,
+			widget.NewFormItem("Time A", bm.timeAEntry).Widget,
+			widget.NewFormItem("Time B", bm.timeBEntry).Widget,
+			bm.byTimeBtn,
+			widget.NewSeparator(),
+			container.NewHBox(bm.aWinsBtn, bm.bWinsBtn),
+		),
+	)
+
+	left := container.NewVBox(draw, entry, bm.statusLabel)
+	split := container.NewHSplit(container.NewScroll(left), bm.bracketArea)
+	split.SetOffset(0.3)
+	bm.window.SetContent(split)
+}
+
+// loadBrackets reads the bracket file of the contest and shows the bracket
+// of discipline, or of the first team discipline.
+func (bm *BracketManager) loadBrackets(discipline string) {
+	brackets, err := data.LoadBrackets(bm.path)
+	if err != nil {
+		dialog.ShowError(err, bm.window)
+	}
+	bm.brackets = brackets
+
+	if len(bm.disciplineSelect.Options) == 0 {
+		bm.statusLabel.SetText("The contest has no team disciplines.")
+		bm.generateBtn.Disable()
+		bm.updateMatchButtons()
+		return
+	}
+	if !bm.disciplines.IsTeam(discipline) {
+		discipline = bm.disciplineSelect.Options[0]
+	}
+	bm.disciplineSelect.SetSelected(discipline) // calls showBracket
+}
+
+// current returns the bracket of the selected discipline, or nil.
+func (bm *BracketManager) current() *models.Bracket {
+	if i := data.FindBracket(bm.brackets, bm.disciplineSelect.Selected); i >= 0 {
+		return &bm.brackets[i]
+	}
+	return nil
+}
+
+// showBracket draws the bracket of the selected discipline and lists its
+// matches that are ready to run.
+func (bm *BracketManager) showBracket() {
+	b := bm.current()
+	bm.ready = nil
+	var options []string
+	if b == nil {
+		bm.bracketArea.Objects = []fyne.CanvasObject{widget.NewLabel("No bracket drawn yet for this discipline.")}
+		bm.statusLabel.SetText("")
+	} else {
+		name := bracketTeamName(bm.teamMgr.GetTeams())
+		bm.bracketArea.Objects = []fyne.CanvasObject{newBracketView(*b, name)}
+		for _, m := range b.Matches {
+			if data.MatchReady(m) {
+				bm.ready = append(bm.ready, m)
+				options = append(options, fmt.Sprintf("%s: %s vs %s", m.ID, name(m.A), name(m.B)))
+			}
+		}
+		bm.statusLabel.SetText(fmt.Sprintf("%d teams, %s elimination.", len(b.Seeds), b.Format))
+	}
+	bm.bracketArea.Refresh()
+
+	bm.matchSelect.Options = options
+	bm.matchSelect.ClearSelected()
+	if len(options) > 0 {
+		bm.matchSelect.SetSelected(options[0])
+	}
+	bm.updateMatchButtons()
+}
+
+// selectedMatch returns the match picked in matchSelect.
+func (bm *BracketManager) selectedMatch() (models.Match, bool) {
+	i := bm.matchSelect.SelectedIndex()
+	if i < 0 || i >= len(bm.ready) {
+		return models.Match{}, false
+	}
+	return bm.ready[i], true
+}
+
+// updateMatchButtons enables the match entry while a match is selected.
+func (bm *BracketManager) updateMatchButtons() {
+	for _, btn := range []*widget.Button{bm.byTimeBtn, bm.aWinsBtn, bm.bWinsBtn} {
+		if _, ok := bm.selectedMatch(); ok {
+			btn.Enable()
+		} else {
+			btn.Disable()
+		}
+	}
+}
+
+// generateBracket draws a new bracket for the selected discipline from the
+// teams, seeded as chosen, asking first if it replaces one.
+func (bm *BracketManager) generateBracket() {
+	discipline := bm.disciplineSelect.Selected
+	if discipline == "" {
+		dialog.ShowError(fmt.Errorf("select a team discipline first"), bm.window)
+		return
+	}
+
+	var def models.DisciplineDef
+	var results []models.Result
+	if seedBy := bm.seedSelect.Selected; seedBy != seedByTeamOrder {
+		def, _ = bm.disciplines.Get(seedBy)
+		for _, r := range bm.resultMgr.GetActiveResults() {
+			if r.Discipline == seedBy {
+				results = append(results, r)
+			}
+		}
+	}
+	seeds := data.SeedTeams(bm.teamMgr.GetTeams(), def, results)
+	b, err := data.NewBracket(discipline, bracketFormats[bm.formatSelect.Selected], seeds)
+	if err != nil {
+		dialog.ShowError(err, bm.window)
+		return
+	}
+
+	replace := func() {
+		brackets := append([]models.Bracket(nil), bm.brackets...)
+		if i := data.FindBracket(brackets, discipline); i >= 0 {
+			brackets[i] = b
+		} else {
+			brackets = append(brackets, b)
+		}
+		bm.save(brackets)
+	}
+	if bm.current() == nil {
+		replace()
+		return
+	}
+	dialog.ShowConfirm("Replace Bracket",
+		fmt.Sprintf("%s already has a bracket. Draw a new one and lose its matches?", discipline),
+		func(ok bool) {
+			if ok {
+				replace()
+			}
+		}, bm.window)
+}
+
+// recordByTime decides the selected match by the times entered.
+func (bm *BracketManager) recordByTime() {
+	m, ok := bm.selectedMatch()
+	if !ok {
+		return
+	}
+	bm.update(func(b *models.Bracket) error {
+		return data.RecordMatchByTime(b, m.ID, bm.timeAEntry.Text, bm.timeBEntry.Text)
+	})
+}
+
+// recordByJudges lets the team in the given slot of the selected match win
+// by decision of the judges.
+func (bm *BracketManager) recordByJudges(slot int) {
+	m, ok := bm.selectedMatch()
+	if !ok {
+		return
+	}
+	winner := m.A
+	if slot == 1 {
+		winner = m.B
+	}
+	name := bracketTeamName(bm.teamMgr.GetTeams())
+	dialog.ShowConfirm("Judges' Decision", fmt.Sprintf("Let %s win match %s?", name(winner), m.ID), func(ok bool) {
+		if !ok {
+			return
+		}
+		bm.update(func(b *models.Bracket) error {
+			return data.RecordMatch(b, m.ID, winner, models.DecisionJudge, "", "")
+		})
+	}, bm.window)
+}
+
+// update applies record to a copy of the selected bracket and saves it if
+// that succeeds.
+func (bm *BracketManager) update(record func(*models.Bracket) error) {
+	i := data.FindBracket(bm.brackets, bm.disciplineSelect.Selected)
+	if i < 0 {
+		return
+	}
+	brackets := append([]models.Bracket(nil), bm.brackets...)
+	b := brackets[i]
+	b.Matches = append([]models.Match(nil), b.Matches...)
+	if err := record(&b); err != nil {
+		dialog.ShowError(err, bm.window)
+		return
+	}
+	brackets[i] = b
+	if bm.save(brackets) {
+		bm.timeAEntry.SetText("")
+		bm.timeBEntry.SetText("")
+	}
+}
+
+// save writes brackets to the bracket file and shows them.
+func (bm *BracketManager) save(brackets []models.Bracket) bool {
+	if err := data.SaveBrackets(bm.path, brackets); err != nil {
+		dialog.ShowError(err, bm.window)
+		return false
+	}
+	bm.brackets = brackets
+	bm.showBracket()
+	return true
+}
+
+// Show displays the bracket window
+func (bm *BracketManager) Show() {
+	bm.window.Show()
+}
+
+// bracketTeamName returns a function naming the teams of a bracket.
+func bracketTeamName(teams []models.Team) func(string) string {
+	names := make(map[string]string, len(teams))
+	for _, t := range teams {
+		names[t.ID] = t.Name
+	}
+	return func(id string) string {
+		switch id {
+		case "":
+			return "TBD"
+		case models.BracketBye:
+			return "(bye)"
+		}
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return id
+	}
+}
+
+// bracketStatus tells how far a bracket is, e.g. "Champion: Red".
+func bracketStatus(b models.Bracket, name func(string) string) string {
+	if champion := data.Champion(b); champion != "" {
+		return "🏆 Champion: " + name(champion)
+	}
+	ready := 0
+	for _, m := range b.Matches {
+		if data.MatchReady(m) {
+			ready++
+		}
+	}
+	return fmt.Sprintf("%d match(es) ready to run.", ready)
+}
+
+// newBracketView shows a bracket as one column per round, winners bracket
+// first; the winner of every decided match is in bold.
+func newBracketView(b models.Bracket, name func(string) string) fyne.CanvasObject {
+	var columns []fyne.CanvasObject
+	for _, round := range data.BracketRounds(b) {
+		column := container.NewVBox(widget.NewLabelWithStyle(round.Name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}))
+		for _, m := range round.Matches {
+			column.Add(newMatchCard(m, name))
+		}
+		columns = append(columns, column)
+	}
+	view := container.NewVBox(
+		widget.NewLabel(bracketStatus(b, name)),
+		container.NewHBox(columns...),
+	)
+	return container.NewScroll(view)
+}
+
+// newMatchCard shows the teams of a match with their times and how it was
+// decided.
+func newMatchCard(m models.Match, name func(string) string) fyne.CanvasObject {
+	slot := func(team, t string) *widget.Label {
+		text := name(team)
+		if t != "" {
+			text += "  " + t
+		}
+		return widget.NewLabelWithStyle(text, fyne.TextAlignLeading, fyne.TextStyle{Bold: team != "" && team == m.Winner})
+	}
+	subtitle := m.ID
+	switch m.Decision {
+	case models.DecisionJudge:
+		subtitle += " – judges' decision"
+	case models.DecisionBye:
+		subtitle += " – bye"
+	}
+	return widget.NewCard("", subtitle, container.NewVBox(slot(m.A, m.TimeA), slot(m.B, m.TimeB)))
+}
+
+// bracketMatchLine describes a match in one line for the contest report,
+// e.g. "W1.1: Red 00:00:12.0000 vs Blue 00:00:13.0000 - Red wins".
+func bracketMatchLine(m models.Match, name func(string) string) string {
+	slot := func(team, t string) string {
+		if t != "" {
+			return name(team) + " " + t
+		}
+		return name(team)
+	}
+	line := fmt.Sprintf("%s: %s vs %s", m.ID, slot(m.A, m.TimeA), slot(m.B, m.TimeB))
+	switch m.Decision {
+	case "":
+		return line
+	case models.DecisionJudge:
+		return line + " - " + name(m.Winner) + " wins by the judges' decision"
+	case models.DecisionBye:
+		return line + " - " + name(m.Winner) + " goes through"
+	}
+	return line + " - " + name(m.Winner) + " wins"
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -26,10 +25,15 @@ type ChugManager struct {
 	app    fyne.App
 	window fyne.Window
 
-	// Data managers
+	// Data managers, shared with the other windows through the session
+	session        *contestSession
 	participantMgr *data.ParticipantManager
+	teamMgr        *data.TeamManager
 	resultMgr      *data.ResultManager
 
+	// Disciplines of the contest, from its discipline file
+	disciplines *data.DisciplineRegistry
+
 	// Timer state
 	timerState    models.TimerState
 	timerWidget   *canvas.Text
@@ -39,6 +43,9 @@ type ChugManager struct {
 	// UI Components - Contest Selection
 	disciplineSelect  *widget.Select
 	timePerEventEntry *widget.Entry
+	bracketBtn        *widget.Button
+	lanesBtn          *widget.Button
+	runningOrderBtn   *widget.Button
 
 	// UI Components - Participant Lists
 	availableList       *widget.List
@@ -50,12 +57,14 @@ type ChugManager struct {
 	currentProgramLabel *widget.Label
 	currentTeamLabel    *widget.Label
 	currentTriesLabel   *widget.Label
+	splitsLabel         *widget.Label
 
 	// UI Components - Timer Controls
 	readyCheckBtn *widget.Button
 	startBtn      *widget.Button
 	stopBtn       *widget.Button
 	resetBtn      *widget.Button
+	splitBtn      *widget.Button
 
 	// UI Components - Results Entry
 	realTimeEntry       *widget.Entry
@@ -75,6 +84,8 @@ type ChugManager struct {
 	clearSkippedBtn *widget.Button
 	loadChuggerBtn  *widget.Button
 	loadFromListBtn *widget.Button
+	undoBtn         *widget.Button
+	redoBtn         *widget.Button
 
 	// Result entry status
 	statusSelect *widget.Select
@@ -90,11 +101,30 @@ type ChugManager struct {
 
 	availableParticipants []models.Participant
 	allParticipants       []models.Participant
-	skippedParticipants   []models.Participant
-	contestQueue          []models.Participant
 	currentChugger        *models.Participant
 	currentResult         *models.Result
 
+	// Team disciplines run teams instead of participants
+	availableTeams []models.Team
+	currentTeam    *models.Team
+
+	// Drawn running orders and skipped lists of the disciplines, as stored
+	// in the contest folder
+	runningOrders []models.RunningOrder
+
+	// Rules of the contest's mode, official or unofficial
+	rules data.ContestRules
+
+	// Relay mode: time since the start at each changeover, one per leg run
+	relayMarks []string
+
+	// Recorded results that U undoes and R redoes, together with the queue
+	history *data.History
+
+	// Records of the other contests under the ChugWare folder, to announce
+	// the records a pass breaks
+	records *data.RecordBook
+
 	// Track selected items in lists
 	availableListSelected widget.ListItemID
 	// Track selected items in lists
@@ -118,7 +148,12 @@ func NewChugManager(app fyne.App) *ChugManager {
 // initializeManagers sets up data managers
 func (cm *ChugManager) initializeManagers() {
 	cm.participantMgr = data.NewParticipantManager()
+	cm.teamMgr = data.NewTeamManager()
 	cm.resultMgr = data.NewResultManager()
+	cm.disciplines = data.DefaultDisciplineRegistry()
+	cm.rules = data.OfficialRules()
+	cm.history = data.NewHistory(data.DefaultHistoryLimit)
+	cm.session = newContestSession(cm.window, cm.onDataChanged)
 }
 
 // setupUI initializes the chug manager UI
@@ -141,7 +176,8 @@ func (cm *ChugManager) setupUI() {
 	// Initialize timer display
 	cm.updateTimerDisplay()
 
-	// Keyboard shortcuts: S = Start, P = Stop (pause).
+	// Keyboard shortcuts: S = Start, P = Stop (pause), L = relay split,
+	// U = undo and R = redo the last recorded result.
 	// Ignored when a text entry field has focus so normal typing is unaffected.
 	cm.window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
 		if _, focused := cm.window.Canvas().Focused().(*widget.Entry); focused {
@@ -156,27 +192,76 @@ func (cm *ChugManager) setupUI() {
 			if !cm.stopBtn.Disabled() {
 				cm.stopTimerFunc()
 			}
+		case fyne.KeyL:
+			if !cm.splitBtn.Disabled() {
+				cm.splitLeg()
+			}
+		case fyne.KeyU:
+			if !cm.undoBtn.Disabled() {
+				cm.undoLast()
+			}
+		case fyne.KeyR:
+			if !cm.redoBtn.Disabled() {
+				cm.redoLast()
+			}
 		}
 	})
 }
 
 // createContestComponents creates contest selection components
 func (cm *ChugManager) createContestComponents() {
-	disciplines := []string{
-		models.DisciplineBottle,
-		models.DisciplineHalfTankard,
-		models.DisciplineFullTankard,
-		models.DisciplineBierStaphette,
-		models.DisciplineMegaMedley,
-		models.DisciplineTeamClash,
-	}
-
-	cm.disciplineSelect = widget.NewSelect(disciplines, cm.onDisciplineSelected)
+	cm.disciplineSelect = widget.NewSelect(cm.disciplines.Names(), cm.onDisciplineSelected)
 	// Note: Don't set selected here to avoid crash - will be set after UI setup
 
 	cm.timePerEventEntry = widget.NewEntry()
 	cm.timePerEventEntry.SetText("5")
 	cm.timePerEventEntry.SetPlaceHolder("Time per event (minutes)")
+
+	cm.lanesBtn = widget.NewButton("Multi-Lane Heat", cm.openLanes)
+	cm.runningOrderBtn = widget.NewButton("Running Order", cm.openRunningOrder)
+	cm.bracketBtn = widget.NewButton("Bracket", cm.openBracket)
+}
+
+// openLanes opens a heat of several lanes side by side in the selected
+// discipline. Relays are timed leg by leg and run one team at a time.
+func (cm *ChugManager) openLanes() {
+	def, ok := cm.disciplines.Get(cm.disciplineSelect.Selected)
+	switch {
+	case !ok:
+		dialog.ShowError(fmt.Errorf("select a discipline first"), cm.window)
+		return
+	case def.Relay:
+		dialog.ShowError(fmt.Errorf("%s is a relay: run it one team at a time", def.Name), cm.window)
+		return
+	case cm.timerState.Running:
+		dialog.ShowError(fmt.Errorf("stop the timer before opening a heat"), cm.window)
+		return
+	}
+	newLaneHeat(cm).Show()
+}
+
+// openRunningOrder opens the running order of the selected discipline, to
+// draw, rearrange and export it.
+func (cm *ChugManager) openRunningOrder() {
+	if config.Settings.ParticipantFile == "" {
+		dialog.ShowError(fmt.Errorf("no participant file configured"), cm.window)
+		return
+	}
+	if _, ok := cm.disciplines.Get(cm.disciplineSelect.Selected); !ok {
+		dialog.ShowError(fmt.Errorf("select a discipline first"), cm.window)
+		return
+	}
+	newRunningOrderEditor(cm, cm.disciplineSelect.Selected).Show()
+}
+
+// openBracket opens the knockout brackets of the team disciplines, on the
+// teams and results of this window.
+func (cm *ChugManager) openBracket() {
+	if config.Settings.ParticipantFile == "" {
+		dialog.ShowError(fmt.Errorf("no participant file configured"), cm.window)
+		return
+	}
+	NewBracketManager(cm.app, cm.teamMgr, cm.resultMgr, cm.disciplines, cm.disciplineSelect.Selected).Show()
 }
 
 // createTimerComponents creates timer-related components
@@ -190,39 +275,53 @@ func (cm *ChugManager) createTimerComponents() {
 	cm.startBtn = widget.NewButton("Start", cm.startTimer)
 	cm.stopBtn = widget.NewButton("Stop", cm.stopTimerFunc)
 	cm.resetBtn = widget.NewButton("Reset", cm.onResetButtonClicked)
+	cm.splitBtn = widget.NewButton("Split (L)", cm.splitLeg)
 
 	cm.useExternalClockBtn = widget.NewButton("Use External Clock", cm.toggleExternalClock)
 
 	// Initially disable some buttons
 	cm.startBtn.Disable()
 	cm.stopBtn.Disable()
+	cm.splitBtn.Disable()
 }
 
 // createListComponents creates the participant list components
 func (cm *ChugManager) createListComponents() {
 	// Available participants list (filtered by discipline)
 	cm.availableList = widget.NewList(
-		func() int { return len(cm.availableParticipants) },
+		func() int {
+			if cm.isTeamDiscipline() {
+				return len(cm.availableTeams)
+			}
+			return len(cm.availableParticipants)
+		},
 		func() fyne.CanvasObject {
 			return container.NewHBox(
 				widget.NewLabel("Name"),
 				widget.NewLabel("Program"),
 				widget.NewLabel("Team"),
-				widget.NewLabel("B"),
-				widget.NewLabel("H"),
-				widget.NewLabel("F"),
+				widget.NewLabel("Tries"),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if cm.isTeamDiscipline() {
+				if id >= 0 && id < len(cm.availableTeams) {
+					t := cm.availableTeams[id]
+					containers := item.(*fyne.Container)
+					containers.Objects[0].(*widget.Label).SetText(t.Name)
+					containers.Objects[1].(*widget.Label).SetText(t.Program)
+					containers.Objects[2].(*widget.Label).SetText(strings.Join(data.RosterNames(t, cm.allParticipants), ", "))
+					containers.Objects[3].(*widget.Label).SetText("")
+				}
+				return
+			}
 			if id >= 0 && id < len(cm.availableParticipants) {
 				p := cm.availableParticipants[id]
 				containers := item.(*fyne.Container)
 				containers.Objects[0].(*widget.Label).SetText(p.Name)
 				containers.Objects[1].(*widget.Label).SetText(p.Program)
 				containers.Objects[2].(*widget.Label).SetText(p.Team)
-				containers.Objects[3].(*widget.Label).SetText(p.Bottle)
-				containers.Objects[4].(*widget.Label).SetText(p.HalfTankard)
-				containers.Objects[5].(*widget.Label).SetText(p.FullTankard)
+				containers.Objects[3].(*widget.Label).SetText(cm.triesSummary(p))
 			}
 		},
 	)
@@ -241,9 +340,7 @@ func (cm *ChugManager) createListComponents() {
 				widget.NewLabel("Name"),
 				widget.NewLabel("Program"),
 				widget.NewLabel("Team"),
-				widget.NewLabel("B"),
-				widget.NewLabel("H"),
-				widget.NewLabel("F"),
+				widget.NewLabel("Tries"),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
@@ -253,9 +350,7 @@ func (cm *ChugManager) createListComponents() {
 				containers.Objects[0].(*widget.Label).SetText(p.Name)
 				containers.Objects[1].(*widget.Label).SetText(p.Program)
 				containers.Objects[2].(*widget.Label).SetText(p.Team)
-				containers.Objects[3].(*widget.Label).SetText(p.Bottle)
-				containers.Objects[4].(*widget.Label).SetText(p.HalfTankard)
-				containers.Objects[5].(*widget.Label).SetText(p.FullTankard)
+				containers.Objects[3].(*widget.Label).SetText(cm.triesSummary(p))
 			}
 		},
 	)
@@ -272,11 +367,13 @@ func (cm *ChugManager) createListComponents() {
 	cm.currentProgramLabel = widget.NewLabel("")
 	cm.currentTeamLabel = widget.NewLabel("")
 	cm.currentTriesLabel = widget.NewLabel("")
+	cm.splitsLabel = widget.NewLabel("")
 
 	cm.currentChuggerCard = widget.NewCard("Current Chugger", "",
 		container.NewVBox(
 			cm.currentNameLabel,
 			cm.currentTriesLabel,
+			cm.splitsLabel,
 		),
 	)
 }
@@ -303,22 +400,23 @@ func (cm *ChugManager) createResultComponents() {
 
 	// Status action buttons
 	cm.passBtn = widget.NewButton("Mark as Pass", func() {
-		if cm.disciplineSelect.Selected == models.DisciplineBottle {
-			// Bottle: show the additional-time dialog; it handles save + moveToNext
-			cm.showBottleResultDialog(models.StatusPass)
+		if def, _ := cm.disciplines.Get(cm.disciplineSelect.Selected); def.Penalty.Prompt || len(def.Penalty.Catalog) > 0 {
+			// Disciplines with a penalty check (Bottle's spill check) or a
+			// penalty catalog ask for the additional time; the dialog handles
+			// save + moveToNext
+			cm.showPenaltyDialog(models.StatusPass)
 		} else {
 			if err := cm.validateAndSaveResult(models.StatusPass); err != nil {
 				dialog.ShowError(err, cm.window)
 				return
 			}
 			dialog.ShowInformation("Result Saved", "Participant marked as passed", cm.window)
-			cm.moveToNextParticipant()
 		}
 	})
 
 	cm.dqMeasureBtn = widget.NewButton("Disqualify + Measure Time", func() {
 		// Show the additional-time dialog; result will be saved as Disqualified
-		cm.showBottleResultDialog(models.StatusDisqualified)
+		cm.showPenaltyDialog(models.StatusDisqualified)
 	})
 
 	// Initially disable status buttons
@@ -397,10 +495,14 @@ func (cm *ChugManager) createActionComponents() {
 	cm.clearSkippedBtn = widget.NewButton("Clear Skipped", cm.clearSkippedParticipants)
 	cm.loadChuggerBtn = widget.NewButton("Load Next Chugger", cm.loadNextChugger)
 	cm.loadFromListBtn = widget.NewButton("Load From List", cm.loadFromList)
+	cm.undoBtn = widget.NewButton("Undo (U)", cm.undoLast)
+	cm.redoBtn = widget.NewButton("Redo (R)", cm.redoLast)
 
 	// Initially disable some buttons
 	cm.approveBtn.Disable()
 	cm.disqualifyBtn.Disable()
+	cm.undoBtn.Disable()
+	cm.redoBtn.Disable()
 	cm.loadFromListBtn.Disable() // Will be enabled based on list selection
 }
 
@@ -411,6 +513,7 @@ func (cm *ChugManager) createLayout() fyne.CanvasObject {
 		container.NewVBox(
 			widget.NewFormItem("Discipline", cm.disciplineSelect).Widget,
 			widget.NewFormItem("Time per Event", cm.timePerEventEntry).Widget,
+			container.NewHBox(cm.runningOrderBtn, cm.lanesBtn, cm.bracketBtn),
 		),
 	)
 
@@ -423,6 +526,7 @@ func (cm *ChugManager) createLayout() fyne.CanvasObject {
 				cm.startBtn,
 				cm.stopBtn,
 				cm.resetBtn,
+				cm.splitBtn,
 			),
 			cm.useExternalClockBtn,
 		),
@@ -463,6 +567,8 @@ func (cm *ChugManager) createLayout() fyne.CanvasObject {
 			container.NewHBox(
 				cm.approveBtn,
 				cm.disqualifyBtn,
+				cm.undoBtn,
+				cm.redoBtn,
 			),
 		),
 	)
@@ -490,13 +596,13 @@ func (cm *ChugManager) createLayout() fyne.CanvasObject {
 
 // Timer methods
 func (cm *ChugManager) readyCheck() {
-	if cm.currentChugger == nil {
+	if !cm.hasCompetitor() {
 		dialog.ShowError(fmt.Errorf("no participant loaded"), cm.window)
 		return
 	}
 
 	dialog.ShowInformation("Ready Check",
-		fmt.Sprintf("Ready to start timer for %s?", cm.currentChugger.Name),
+		fmt.Sprintf("Ready to start timer for %s?", cm.competitorName()),
 		cm.window)
 
 	cm.startBtn.Enable()
@@ -528,7 +634,10 @@ func (cm *ChugManager) startTimer() {
 		return
 	}
 
-	// Internal timer
+	// Internal timer; relay changeovers are marked with Split
+	if cm.isRelay() {
+		cm.splitBtn.Enable()
+	}
 	cm.ticker = time.NewTicker(10 * time.Millisecond)
 	go cm.runTimer()
 }
@@ -547,6 +656,10 @@ func (cm *ChugManager) runExternalClockReceiver() {
 			cm.lastExternalTime = t
 			cm.timerWidget.Text = t
 			cm.timerWidget.Refresh()
+			// In a relay every time the clock sends marks a changeover
+			if cm.isRelay() {
+				cm.captureSplit(t)
+			}
 		}
 	}
 }
@@ -576,12 +689,23 @@ func (cm *ChugManager) stopTimerFunc() {
 
 	cm.startBtn.Enable()
 	cm.stopBtn.Disable()
+	cm.splitBtn.Disable()
+
+	// Stopping a relay ends the leg being run
+	if cm.isRelay() && len(cm.relayMarks) > 0 && len(cm.relayMarks) < len(cm.relayMembers()) {
+		stopTime := cm.formatDuration(cm.timerState.Duration)
+		if cm.useExternalClock {
+			stopTime = cm.lastExternalTime
+		}
+		if stopTime != "" {
+			cm.relayMarks = append(cm.relayMarks, stopTime)
+			cm.updateSplitsDisplay()
+		}
+	}
 
 	// Auto-fill time fields
 	if cm.useExternalClock && cm.lastExternalTime != "" {
 		cm.baseTimeEntry.SetText(cm.lastExternalTime)
-		// Use a non-zero sentinel so tries decrement logic triggers
-		cm.timerState.Duration = 1
 	} else if cm.timerState.Duration > 0 {
 		timeStr := cm.formatDuration(cm.timerState.Duration)
 		// Base time is the timer value
@@ -589,24 +713,9 @@ func (cm *ChugManager) stopTimerFunc() {
 		// Additional time is manual input only — never updated automatically
 		// Time field is left empty so Enter Result Manually calculates base+additional
 	}
-
-	// Decrement tries for this discipline every time the timer stops (one stop = one try used).
-	if cm.currentChugger != nil && cm.timerState.Duration > 0 {
-		discipline := cm.disciplineSelect.Selected
-		_ = cm.participantMgr.DecrementTries(cm.currentChugger.Name, discipline)
-		if err := cm.participantMgr.SaveParticipants(); err != nil {
-			dialog.ShowError(fmt.Errorf("error saving participant tries: %w", err), cm.window)
-		}
-		// Refresh cm.currentChugger with the post-decrement data from the manager.
-		// Do NOT reassign cm.allParticipants — that would mistakenly re-add
-		// the current chugger (who was removed when loaded).
-		for _, p := range cm.participantMgr.GetParticipants() {
-			if p.Name == cm.currentChugger.Name {
-				*cm.currentChugger = p
-				break
-			}
-		}
-		cm.updateCurrentChuggerDisplay()
+	// A finished relay's base time is its last changeover, so the legs add up
+	if cm.isRelay() && len(cm.relayMarks) > 0 && len(cm.relayMarks) == len(cm.relayMembers()) {
+		cm.baseTimeEntry.SetText(utils.ParseAndPadTimeString(cm.relayMarks[len(cm.relayMarks)-1]))
 	}
 
 	// Enable buttons — for all disciplines we wait for the user to pick an action.
@@ -623,9 +732,12 @@ func (cm *ChugManager) resetTimer() {
 	if cm.ticker != nil {
 		cm.ticker.Stop()
 	}
+	cm.relayMarks = nil
+	cm.updateSplitsDisplay()
 
 	cm.startBtn.Disable()
 	cm.stopBtn.Disable()
+	cm.splitBtn.Disable()
 	cm.approveBtn.Disable()
 	cm.disqualifyBtn.Disable()
 	cm.passBtn.Disable()
@@ -677,20 +789,24 @@ func (cm *ChugManager) formatDuration(d time.Duration) string {
 
 // Participant management methods
 func (cm *ChugManager) loadNextChugger() {
-	var nextParticipant *models.Participant
+	if cm.isTeamDiscipline() {
+		if len(cm.availableTeams) == 0 {
+			dialog.ShowError(fmt.Errorf("no teams available"), cm.window)
+			return
+		}
+		cm.loadTeam(cm.availableTeams[0])
+		cm.loadChuggerBtn.Disable()
+		return
+	}
 
-	// Check if there are participants in queue
-	if len(cm.contestQueue) > 0 {
-		nextParticipant = &cm.contestQueue[0]
-		cm.contestQueue = cm.contestQueue[1:]
-	} else if len(cm.availableParticipants) > 0 {
-		nextParticipant = &cm.availableParticipants[0]
-	} else {
+	// The list is in running order, so the first one is up next
+	if len(cm.availableParticipants) == 0 {
 		dialog.ShowError(fmt.Errorf("no participants available"), cm.window)
 		return
 	}
+	next := cm.availableParticipants[0]
 
-	cm.currentChugger = nextParticipant
+	cm.currentChugger = &next
 	cm.updateCurrentChuggerDisplay()
 
 	// Clear result form and reset timer for new participant
@@ -702,7 +818,7 @@ func (cm *ChugManager) loadNextChugger() {
 }
 
 func (cm *ChugManager) enterResultManually() {
-	if cm.currentChugger == nil {
+	if !cm.hasCompetitor() {
 		dialog.ShowError(fmt.Errorf("no participant loaded"), cm.window)
 		return
 	}
@@ -713,44 +829,47 @@ func (cm *ChugManager) enterResultManually() {
 		selectedStatus = models.StatusDisqualified
 	}
 
+	name := cm.competitorName()
 	if err := cm.validateAndSaveResult(selectedStatus); err != nil {
 		dialog.ShowError(err, cm.window)
 		return
 	}
 
-	// Decrement tries only if the timer never ran for this participant.
-	// If the timer was stopped, tries were already decremented at that point.
-	if cm.timerState.Duration == 0 {
-		discipline := cm.disciplineSelect.Selected
-		_ = cm.participantMgr.DecrementTries(cm.currentChugger.Name, discipline)
-		if err := cm.participantMgr.SaveParticipants(); err != nil {
-			dialog.ShowError(fmt.Errorf("error saving participant tries: %w", err), cm.window)
-		}
-	}
-
 	label := "Result Saved"
-	msg := fmt.Sprintf("Result for %s saved as %s", cm.currentChugger.Name, selectedStatus)
+	msg := fmt.Sprintf("Result for %s saved as %s", name, selectedStatus)
 	dialog.ShowInformation(label, msg, cm.window)
-	cm.moveToNextParticipant()
 }
 
 func (cm *ChugManager) disqualifyParticipant() {
-	if cm.currentChugger == nil {
+	if !cm.hasCompetitor() {
 		dialog.ShowError(fmt.Errorf("no participant loaded"), cm.window)
 		return
 	}
 
+	name := cm.competitorName()
 	if err := cm.validateAndSaveResult(models.StatusDisqualified); err != nil {
 		dialog.ShowError(err, cm.window)
 		return
 	}
 
-	dialog.ShowInformation("Disqualified", fmt.Sprintf("%s disqualified", cm.currentChugger.Name), cm.window)
-	cm.moveToNextParticipant()
+	dialog.ShowInformation("Disqualified", fmt.Sprintf("%s disqualified", name), cm.window)
 }
 
 // loadFromList loads a participant from the "Participants in Discipline" list only.
 func (cm *ChugManager) loadFromList() {
+	if cm.isTeamDiscipline() {
+		if cm.availableListSelected < 0 || cm.availableListSelected >= len(cm.availableTeams) {
+			dialog.ShowError(fmt.Errorf("please select a team from the Participants in Discipline list"), cm.window)
+			return
+		}
+		cm.loadTeam(cm.availableTeams[cm.availableListSelected])
+		cm.loadChuggerBtn.Disable()
+		cm.loadFromListBtn.Disable()
+		cm.availableList.Unselect(-1)
+		cm.availableListSelected = -1
+		return
+	}
+
 	var nextParticipant *models.Participant
 
 	// Only source from the discipline-filtered list
@@ -782,17 +901,28 @@ func (cm *ChugManager) loadFromList() {
 }
 
 func (cm *ChugManager) skipParticipant() {
-	if cm.currentChugger == nil {
+	if !cm.hasCompetitor() {
 		dialog.ShowError(fmt.Errorf("no participant loaded"), cm.window)
 		return
 	}
 
-	// Add to skipped list so they are excluded from future auto-loads
-	cm.skippedParticipants = append(cm.skippedParticipants, *cm.currentChugger)
+	// Add to the discipline's skipped list so they are excluded from future
+	// auto-loads, also after a restart
+	o := cm.runningOrder(cm.disciplineSelect.Selected)
+	if cm.currentTeam != nil {
+		o.Skipped = append(o.Skipped, cm.currentTeam.ID)
+	} else {
+		o.Skipped = append(o.Skipped, cm.currentChugger.ID)
+	}
+	if err := cm.setRunningOrder(o); err != nil {
+		dialog.ShowError(err, cm.window)
+		return
+	}
 
-	dialog.ShowInformation("Participant Skipped", fmt.Sprintf("Skipped: %s", cm.currentChugger.Name), cm.window)
+	dialog.ShowInformation("Participant Skipped", fmt.Sprintf("Skipped: %s", cm.competitorName()), cm.window)
 
 	cm.currentChugger = nil
+	cm.currentTeam = nil
 	cm.updateCurrentChuggerDisplay()
 	cm.clearResultForm()
 	cm.resetTimer()
@@ -804,28 +934,37 @@ func (cm *ChugManager) skipParticipant() {
 	cm.loadChuggerBtn.Enable()
 }
 
+// clearSkippedParticipants clears the skipped list of the selected
+// discipline.
 func (cm *ChugManager) clearSkippedParticipants() {
-	if len(cm.skippedParticipants) == 0 {
+	o := cm.runningOrder(cm.disciplineSelect.Selected)
+	if len(o.Skipped) == 0 {
 		dialog.ShowInformation("No Skipped Participants", "There are no skipped participants to clear", cm.window)
 		return
 	}
 
-	cm.skippedParticipants = nil
+	o.Skipped = nil
+	if err := cm.setRunningOrder(o); err != nil {
+		dialog.ShowError(err, cm.window)
+		return
+	}
 	// Rebuild available list so previously-skipped participants are loadable again
 	cm.loadAvailableParticipants()
 	dialog.ShowInformation("Cleared", "Skipped list has been cleared", cm.window)
 }
 
-// showBottleResultDialog opens the additional-time entry window.
+// showPenaltyDialog opens the additional-time entry window.
 // requestedStatus is the intended final status (StatusPass or StatusDisqualified).
-// When StatusDisqualified the "Save" button always stores the result as DQ.
-func (cm *ChugManager) showBottleResultDialog(requestedStatus string) {
-	participant := cm.currentChugger
-	if participant == nil {
+// When StatusDisqualified the "Save" button always stores the result as DQ,
+// commented with the discipline's disqualification reason.
+func (cm *ChugManager) showPenaltyDialog(requestedStatus string) {
+	if !cm.hasCompetitor() {
 		return
 	}
+	result := cm.newResult()
 
 	discipline := cm.disciplineSelect.Selected
+	def, _ := cm.disciplines.Get(discipline)
 
 	title := "Result Entry"
 	if requestedStatus == models.StatusDisqualified {
@@ -838,6 +977,35 @@ func (cm *ChugManager) showBottleResultDialog(requestedStatus string) {
 	additionalTimeEntry := widget.NewEntry()
 	additionalTimeEntry.SetPlaceHolder("Additional Time (e.g. 0:03.500)")
 
+	// Penalties picked from the catalog add up in the additional time
+	var penalties []models.Penalty
+	penaltyLabel := widget.NewLabel("")
+	penaltyLabel.Wrapping = fyne.TextWrapWord
+	showPenalties := func() {
+		text := data.PenaltySummary(penalties)
+		if item, ok := data.EscalatedPenalty(def, penalties); ok {
+			text += fmt.Sprintf("  –  disqualifies (%s)", item.Name)
+		}
+		penaltyLabel.SetText(text)
+	}
+	var penaltyButtons []fyne.CanvasObject
+	for _, item := range def.Penalty.Catalog {
+		item := item
+		penaltyButtons = append(penaltyButtons, widget.NewButton(penaltyButtonLabel(item), func() {
+			var additional string
+			penalties, additional = data.GivePenalty(item, penalties, additionalTimeEntry.Text)
+			additionalTimeEntry.SetText(additional)
+			showPenalties()
+		}))
+	}
+	if len(penaltyButtons) > 0 {
+		penaltyButtons = append(penaltyButtons, widget.NewButton("Clear Penalties", func() {
+			penalties = nil
+			additionalTimeEntry.SetText("")
+			showPenalties()
+		}))
+	}
+
 	saveResultFunc := func(additionalTime string, forceStatus string) {
 		baseTime := strings.TrimSpace(cm.baseTimeEntry.Text)
 		additionalTime = strings.TrimSpace(additionalTime)
@@ -861,30 +1029,32 @@ func (cm *ChugManager) showBottleResultDialog(requestedStatus string) {
 
 		comment := ""
 		if finalStatus == models.StatusDisqualified {
-			comment = "Overflow"
-		}
-
-		result := models.Result{
-			Name:           participant.Name,
-			Discipline:     discipline,
-			Time:           utils.ParseAndPadTimeString(totalTimeStr),
-			BaseTime:       utils.ParseAndPadTimeString(baseTime),
-			AdditionalTime: additionalTime,
-			Status:         finalStatus,
-			Comment:        comment,
+			comment = def.Penalty.DQReason
+			if item, ok := data.EscalatedPenalty(def, penalties); ok {
+				comment = item.Name
+			}
 		}
 
-		if err := cm.resultMgr.AddResult(result); err != nil {
+		splits, err := cm.relaySplits(finalStatus)
+		if err != nil {
 			dialog.ShowError(err, cm.window)
 			return
 		}
-		if err := cm.resultMgr.SaveResults(); err != nil {
+
+		result.Discipline = discipline
+		result.Time = utils.ParseAndPadTimeString(totalTimeStr)
+		result.BaseTime = utils.ParseAndPadTimeString(baseTime)
+		result.AdditionalTime = additionalTime
+		result.Status = finalStatus
+		result.Comment = comment
+		result.Splits = splits
+		result.Penalties = penalties
+
+		if err := cm.recordResult(result); err != nil {
 			dialog.ShowError(err, cm.window)
 			return
 		}
-
 		resultWindow.Close()
-		cm.moveToNextParticipant()
 	}
 
 	saveLabel := "Save (Pass)"
@@ -896,34 +1066,63 @@ func (cm *ChugManager) showBottleResultDialog(requestedStatus string) {
 		saveResultFunc(additionalTimeEntry.Text, requestedStatus)
 	})
 
-	// Clean Bottle button only makes sense for a pass attempt
-	cleanBottleBtn := widget.NewButton("Clean Bottle (no penalty)", func() {
+	// Clean button only makes sense for a pass attempt
+	cleanBtn := widget.NewButton(fmt.Sprintf("Clean %s (no penalty)", discipline), func() {
+		penalties = nil
 		saveResultFunc("0", models.StatusPass)
 	})
 
-	disqualifyOverflowBtn := widget.NewButton("Disqualify (Overflow)", func() {
+	disqualifyLabel := "Disqualify"
+	if def.Penalty.DQReason != "" {
+		disqualifyLabel = fmt.Sprintf("Disqualify (%s)", def.Penalty.DQReason)
+	}
+	disqualifyBtn := widget.NewButton(disqualifyLabel, func() {
 		saveResultFunc(additionalTimeEntry.Text, models.StatusDisqualified)
 	})
 
 	content := container.NewVBox(
-		widget.NewLabel(fmt.Sprintf("Participant: %s  |  Discipline: %s", participant.Name, discipline)),
+		widget.NewLabel(fmt.Sprintf("Participant: %s  |  Discipline: %s", result.Name, discipline)),
 		widget.NewLabel(fmt.Sprintf("Base Time: %s", cm.baseTimeEntry.Text)),
-		widget.NewForm(
-			widget.NewFormItem("Additional Time", additionalTimeEntry),
-		),
-		container.NewHBox(
-			saveBtn,
-			cleanBottleBtn,
-			disqualifyOverflowBtn,
-		),
 	)
+	if len(penaltyButtons) > 0 {
+		content.Add(widget.NewLabel("Penalties:"))
+		content.Add(container.NewGridWithColumns(3, penaltyButtons...))
+		content.Add(penaltyLabel)
+		resultWindow.Resize(fyne.NewSize(480, 380))
+	}
+	content.Add(widget.NewForm(
+		widget.NewFormItem("Additional Time", additionalTimeEntry),
+	))
+	content.Add(container.NewHBox(
+		saveBtn,
+		cleanBtn,
+		disqualifyBtn,
+	))
 
 	resultWindow.SetContent(container.NewPadded(content))
 	resultWindow.Show()
 }
 
+// penaltyButtonLabel names a catalog penalty with what it costs, e.g.
+// "Spill +2" or "Early start (DQ)".
+func penaltyButtonLabel(item models.PenaltyItem) string {
+	label := item.Name
+	if item.Time != "" {
+		label += " +" + item.Time
+	}
+	switch {
+	case item.DQAfter == 1:
+		label += " (DQ)"
+	case item.DQAfter > 1:
+		label += fmt.Sprintf(" (DQ at %d)", item.DQAfter)
+	}
+	return label
+}
+
+// validateAndSaveResult builds a result from the entry form and records it,
+// which moves on to the next participant.
 func (cm *ChugManager) validateAndSaveResult(status string) error {
-	if cm.currentChugger == nil {
+	if !cm.hasCompetitor() {
 		return fmt.Errorf("no participant loaded")
 	}
 
@@ -986,28 +1185,22 @@ func (cm *ChugManager) validateAndSaveResult(status string) error {
 		return fmt.Errorf("time is required: fill in Time or Base Time + Additional Time")
 	}
 
-	// Create result
-	result := models.Result{
-		Name:           cm.currentChugger.Name,
-		Discipline:     discipline,
-		Time:           utils.ParseAndPadTimeString(finalTime),
-		BaseTime:       utils.ParseAndPadTimeString(baseTimeStr),
-		AdditionalTime: additionalTimeStr,
-		Status:         status,
-		Comment:        strings.TrimSpace(cm.commentEntry.Text),
+	splits, err := cm.relaySplits(status)
+	if err != nil {
+		return err
 	}
 
-	// Add to result manager
-	if err := cm.resultMgr.AddResult(result); err != nil {
-		return fmt.Errorf("error adding result: %w", err)
-	}
-
-	// Save results
-	if err := cm.resultMgr.SaveResults(); err != nil {
-		return fmt.Errorf("error saving results: %w", err)
-	}
-
-	return nil
+	// Create result
+	result := cm.newResult()
+	result.Discipline = discipline
+	result.Time = utils.ParseAndPadTimeString(finalTime)
+	result.BaseTime = utils.ParseAndPadTimeString(baseTimeStr)
+	result.AdditionalTime = additionalTimeStr
+	result.Status = status
+	result.Comment = strings.TrimSpace(cm.commentEntry.Text)
+	result.Splits = splits
+
+	return cm.recordResult(result)
 }
 
 // calculateAndSetTotalTime adds base time and additional time to get total time
@@ -1035,24 +1228,257 @@ func (cm *ChugManager) calculateAndSetTotalTime() {
 
 func (cm *ChugManager) moveToNextParticipant() {
 	cm.currentChugger = nil
+	cm.currentTeam = nil
 	cm.updateCurrentChuggerDisplay()
 	cm.clearResultForm()
 	cm.resetTimer()
 
 	// Always rebuild lists from the manager so try-count changes are reflected.
 	// Participants stay in the list until their tries reach 0.
+	cm.reloadParticipantLists()
+
+	// Re-enable the load button so user can load next participant
+	cm.loadChuggerBtn.Enable()
+}
+
+// reloadParticipantLists rebuilds both participant lists from the manager.
+func (cm *ChugManager) reloadParticipantLists() {
 	cm.allParticipants = cm.participantMgr.GetParticipants()
 	sort.Slice(cm.allParticipants, func(i, j int) bool {
 		return cm.allParticipants[i].Name < cm.allParticipants[j].Name
 	})
 	cm.loadAvailableParticipants()
+}
 
-	// Re-enable the load button so user can load next participant
-	cm.loadChuggerBtn.Enable()
+// chugState is what an undo puts back along with a result: the discipline,
+// who was in front of the timer, the timer and the entry form.
+type chugState struct {
+	discipline     string
+	chugger        *models.Participant
+	team           *models.Team
+	timer          models.TimerState
+	relayMarks     []string
+	baseTime       string
+	additionalTime string
+	realTime       string
+	lockedTime     string
+	comment        string
+	status         string
+}
+
+// captureState takes a copy of the state that recordResult may change.
+func (cm *ChugManager) captureState() chugState {
+	s := chugState{
+		discipline:     cm.disciplineSelect.Selected,
+		timer:          cm.timerState,
+		relayMarks:     append([]string(nil), cm.relayMarks...),
+		baseTime:       cm.baseTimeEntry.Text,
+		additionalTime: cm.additionalTimeEntry.Text,
+		realTime:       cm.realTimeEntry.Text,
+		lockedTime:     cm.lockedTimeValue,
+		comment:        cm.commentEntry.Text,
+		status:         cm.statusSelect.Selected,
+	}
+	if cm.currentChugger != nil {
+		p := *cm.currentChugger
+		s.chugger = &p
+	}
+	if cm.currentTeam != nil {
+		t := *cm.currentTeam
+		s.team = &t
+	}
+	return s
+}
+
+// restoreState loads the participant or team of s again with the entry form
+// as it was, ready for the attempt to be recorded again. The caller refreshes
+// the lists once the result has been taken back.
+func (cm *ChugManager) restoreState(s chugState) {
+	if cm.disciplineSelect.Selected != s.discipline {
+		cm.disciplineSelect.SetSelected(s.discipline)
+	}
+	cm.resetTimer()
+
+	cm.currentChugger = s.chugger
+	cm.currentTeam = s.team
+	cm.timerState = s.timer
+	cm.timerState.Running = false
+	cm.relayMarks = append([]string(nil), s.relayMarks...)
+
+	cm.baseTimeEntry.SetText(s.baseTime)
+	cm.additionalTimeEntry.SetText(s.additionalTime)
+	cm.realTimeEntry.SetText(s.realTime)
+	cm.lockedTimeValue = s.lockedTime
+	cm.commentEntry.SetText(s.comment)
+	cm.statusSelect.SetSelected(s.status)
+	cm.updateTimerDisplay()
+
+	cm.approveBtn.Enable()
+	cm.disqualifyBtn.Enable()
+	cm.passBtn.Enable()
+	cm.dqMeasureBtn.Enable()
+	cm.loadChuggerBtn.Disable()
+	cm.loadFromListBtn.Disable()
+}
+
+// recordResult saves result and moves on to the next participant as a single
+// step that can be undone: the result, the try it used and the queue position
+// go back together. The time limit and penalty maximum of the discipline are
+// applied first.
+func (cm *ChugManager) recordResult(result models.Result) error {
+	if def, ok := cm.disciplines.Get(result.Discipline); ok {
+		data.ApplyDisciplineRules(def, &result)
+	}
+	broken := cm.recordsBroken(result)[0]
+	before := cm.captureState()
+	err := cm.history.Do(&data.Steps{Commands: []data.Command{
+		&data.RecordResult{Results: cm.resultMgr, Result: result},
+		&data.FuncCommand{
+			Name:     "move to the next participant",
+			DoFunc:   func() error { cm.moveToNextParticipant(); return nil },
+			UndoFunc: func() error { cm.restoreState(before); return nil },
+		},
+	}})
+	cm.updateHistoryButtons()
+	if err == nil && len(broken) > 0 {
+		cm.announceRecords(result.Name, broken)
+	}
+	return err
+}
+
+// recordHeat saves the results of a multi-lane heat in one step that can be
+// undone as a whole, then announces the records they broke. The time limit
+// and penalty maximum of the discipline are applied first.
+func (cm *ChugManager) recordHeat(results []models.Result) error {
+	for i := range results {
+		if def, ok := cm.disciplines.Get(results[i].Discipline); ok {
+			data.ApplyDisciplineRules(def, &results[i])
+		}
+	}
+	finished := heatFinishOrder(results)
+	broken := cm.recordsBroken(finished...)
+	err := cm.history.Do(&data.Steps{Commands: []data.Command{
+		&data.RecordResults{Results: cm.resultMgr, Heat: results},
+		&data.FuncCommand{
+			Name:     "update the lists",
+			DoFunc:   func() error { cm.reloadParticipantLists(); return nil },
+			UndoFunc: func() error { return nil }, // undoLast reloads the lists
+		},
+	}})
+	cm.updateHistoryButtons()
+	if err != nil {
+		return err
+	}
+	for i, records := range broken {
+		if len(records) > 0 {
+			cm.announceRecords(finished[i].Name, records)
+		}
+	}
+	return nil
+}
+
+// heatFinishOrder returns the results of a heat in the order the lanes
+// finished, fastest first, which is the order they can set records in.
+func heatFinishOrder(results []models.Result) []models.Result {
+	ordered := append([]models.Result(nil), results...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ti, tj := utils.ParseTimeForComparison(ordered[i].Time), utils.ParseTimeForComparison(ordered[j].Time)
+		return ti >= 0 && (tj < 0 || ti < tj)
+	})
+	return ordered
+}
+
+// recordsBroken returns the records each of results breaks, measured against
+// the other contests, the results of this contest saved so far and the ones
+// before it in results. An unofficial contest breaks none.
+func (cm *ChugManager) recordsBroken(results ...models.Result) [][]data.Record {
+	broken := make([][]data.Record, len(results))
+	if cm.records == nil || !cm.rules.Counted {
+		return broken
+	}
+	contest := filepath.Base(config.Settings.FolderPathContestNameAndDate)
+	teams := cm.teamMgr.GetTeams()
+	book := cm.records.Clone()
+	book.AddContest(data.SeriesContest{
+		Folder:       contest,
+		Disciplines:  cm.disciplines,
+		Participants: cm.allParticipants,
+		Teams:        teams,
+		Results:      cm.resultMgr.GetResults(),
+	})
+	for i, result := range results {
+		def, ok := cm.disciplines.Get(result.Discipline)
+		if !ok {
+			continue
+		}
+		program, team := data.RecordHolders(cm.allParticipants, teams, result)
+		broken[i] = book.Add(contest, def, result, program, team)
+	}
+	return broken
+}
+
+// announceRecords tells the operator which records a saved result broke.
+func (cm *ChugManager) announceRecords(name string, records []data.Record) {
+	lines := []string{fmt.Sprintf("%s set a new record!", name), ""}
+	for _, r := range records {
+		lines = append(lines, fmt.Sprintf("%s %s: %s (was %s)", r.Discipline, r.Label(), r.Time, r.Previous))
+	}
+	dialog.ShowInformation("🏆 NEW RECORD", strings.Join(lines, "\n"), cm.window)
+}
+
+// undoLast takes back the last recorded result and loads its participant or
+// team again.
+func (cm *ChugManager) undoLast() {
+	if cm.timerState.Running {
+		dialog.ShowError(fmt.Errorf("stop the timer before undoing"), cm.window)
+		return
+	}
+	_, err := cm.history.Undo()
+	cm.updateHistoryButtons()
+	// The result is gone now, so the lists show the try as given back
+	cm.reloadParticipantLists()
+	cm.updateCurrentChuggerDisplay()
+	if err != nil {
+		dialog.ShowError(err, cm.window)
+	}
+}
+
+// redoLast records the last undone result again.
+func (cm *ChugManager) redoLast() {
+	if cm.timerState.Running {
+		dialog.ShowError(fmt.Errorf("stop the timer before redoing"), cm.window)
+		return
+	}
+	_, err := cm.history.Redo()
+	cm.updateHistoryButtons()
+	if err != nil {
+		dialog.ShowError(err, cm.window)
+	}
+}
+
+// updateHistoryButtons enables Undo and Redo when there is something to undo
+// or redo.
+func (cm *ChugManager) updateHistoryButtons() {
+	if cm.history.CanUndo() {
+		cm.undoBtn.Enable()
+	} else {
+		cm.undoBtn.Disable()
+	}
+	if cm.history.CanRedo() {
+		cm.redoBtn.Enable()
+	} else {
+		cm.redoBtn.Disable()
+	}
 }
 
 // Event handlers
 func (cm *ChugManager) onDisciplineSelected(discipline string) {
+	// A participant cannot run a team discipline and vice versa
+	if cm.disciplines.IsTeam(discipline) {
+		cm.currentChugger = nil
+	} else {
+		cm.currentTeam = nil
+	}
 	cm.loadAvailableParticipants()
 	cm.updateCurrentChuggerDisplay()
 }
@@ -1079,6 +1505,14 @@ func (cm *ChugManager) refreshLists() {
 	cm.allParticipantsList.Refresh()
 }
 func (cm *ChugManager) updateCurrentChuggerDisplay() {
+	cm.updateSplitsDisplay()
+	if cm.currentTeam != nil {
+		roster := data.RosterNames(*cm.currentTeam, cm.allParticipants)
+		cm.currentNameLabel.SetText(fmt.Sprintf("%s | %s", cm.currentTeam.Name, cm.currentTeam.Program))
+		cm.currentChuggerCard.SetTitle("Current Team: " + cm.currentTeam.Name)
+		cm.currentTriesLabel.SetText("Roster: " + strings.Join(roster, ", "))
+		return
+	}
 	if cm.currentChugger == nil {
 		cm.currentNameLabel.SetText("No participant loaded")
 		cm.currentProgramLabel.SetText("")
@@ -1091,20 +1525,15 @@ func (cm *ChugManager) updateCurrentChuggerDisplay() {
 		cm.currentNameLabel.SetText(combinedText)
 		cm.currentChuggerCard.SetTitle("Current Chugger: " + cm.currentChugger.Name)
 
-		// Show remaining tries for the selected discipline.
-		// Use the up-to-date in-memory participant record (post-decrement).
+		// Show remaining tries for the selected discipline, counted from the
+		// attempts recorded so far
 		discipline := cm.disciplineSelect.Selected
-		triesStr := ""
-		switch discipline {
-		case models.DisciplineBottle:
-			triesStr = cm.currentChugger.Bottle
-		case models.DisciplineHalfTankard:
-			triesStr = cm.currentChugger.HalfTankard
-		case models.DisciplineFullTankard:
-			triesStr = cm.currentChugger.FullTankard
-		}
-		if triesStr != "" {
-			cm.currentTriesLabel.SetText(fmt.Sprintf("Tries remaining (%s): %s", discipline, triesStr))
+		def, known := cm.disciplines.Get(discipline)
+		if _, ok := data.TriesEntitled(*cm.currentChugger, def); known && ok && !cm.rules.LimitTries {
+			cm.currentTriesLabel.SetText(fmt.Sprintf("Attempts made (%s): %d, tries unlimited",
+				discipline, data.AttemptsUsed(cm.resultMgr.GetResults(), *cm.currentChugger, discipline)))
+		} else if known && ok {
+			cm.currentTriesLabel.SetText(fmt.Sprintf("Tries remaining (%s): %d", discipline, cm.remainingTries(*cm.currentChugger, discipline)))
 		} else {
 			cm.currentTriesLabel.SetText("")
 		}
@@ -1117,14 +1546,41 @@ func (cm *ChugManager) loadData() {
 		return
 	}
 
+	// Steps recorded against the previous data cannot be undone any more
+	cm.history.Clear()
+	cm.updateHistoryButtons()
+
+	cm.session.attach(func(s *data.Session) {
+		cm.participantMgr = s.Participants()
+		cm.teamMgr = s.Teams()
+		cm.resultMgr = s.Results()
+		cm.loadFiles()
+	})
+}
+
+// loadFiles (re)loads the shared managers from the configured files.
+func (cm *ChugManager) loadFiles() {
+	// Load the contest's disciplines; without a discipline file the built-in
+	// ones are run
+	disciplines, err := data.LoadDisciplines(data.DisciplinePathFor(config.Settings.ParticipantFile))
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error loading disciplines, using the built-in ones: %w", err), cm.window)
+		disciplines = data.DefaultDisciplineRegistry()
+	}
+	cm.disciplines = disciplines
+	showIgnoredSetupEdits(cm.window)
+	cm.disciplineSelect.SetOptions(disciplines.Names())
+	cm.rules = data.RulesFor(config.Settings.FolderPathContestNameAndDate)
+
 	// Load participants
 	if utils.DoesFileExist(config.Settings.ParticipantFile) {
 		if err := cm.participantMgr.LoadParticipants(config.Settings.ParticipantFile); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading participants: %v", err), cm.window)
+			showLoadError(fmt.Errorf("error loading participants: %w", err), cm.window, cm.loadData)
 		} else {
 			// Copy participant file into the contest folder if it lives elsewhere,
 			// then update config so saves always target the local copy.
-			if config.Settings.ResultFile != "" {
+			// A contest database already holds both lists and is never copied.
+			if config.Settings.ResultFile != "" && !data.IsDatabasePath(config.Settings.ParticipantFile) {
 				contestDir := filepath.Dir(config.Settings.ResultFile)
 				destPath := filepath.Join(contestDir, "participants.json")
 				srcAbs, _ := filepath.Abs(config.Settings.ParticipantFile)
@@ -1133,9 +1589,11 @@ func (cm *ChugManager) loadData() {
 					if err := utils.CopyFile(config.Settings.ParticipantFile, destPath); err != nil {
 						dialog.ShowError(fmt.Errorf("error copying participant file to contest folder: %w", err), cm.window)
 					} else {
+						// Switch to the session over the local copy
 						config.Settings.ParticipantFile = destPath
 						config.SaveConfig()
-						cm.participantMgr.SetFilePath(destPath)
+						cm.loadData()
+						return
 					}
 				}
 			}
@@ -1153,107 +1611,384 @@ func (cm *ChugManager) loadData() {
 		return
 	}
 
+	// Load teams for the team disciplines
+	if teamFile := data.TeamPathFor(config.Settings.ParticipantFile); utils.DoesFileExist(teamFile) {
+		if err := cm.teamMgr.LoadTeams(teamFile); err != nil {
+			showLoadError(fmt.Errorf("error loading teams: %w", err), cm.window, cm.loadData)
+		}
+	}
+
 	// Load results
 	if config.Settings.ResultFile != "" && utils.DoesFileExist(config.Settings.ResultFile) {
 		if err := cm.resultMgr.LoadResults(config.Settings.ResultFile); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading results: %w", err), cm.window)
+			showLoadError(fmt.Errorf("error loading results: %w", err), cm.window, cm.loadData)
 		}
 	} else if config.Settings.ResultFile != "" {
 		// Create empty results file if it doesn't exist
-		if err := utils.SaveListToJSONFile(config.Settings.ResultFile, []map[string]string{}); err != nil {
+		if err := cm.resultMgr.SaveResults(); err != nil {
 			dialog.ShowError(fmt.Errorf("error creating result file %s: %w", config.Settings.ResultFile, err), cm.window)
-		} else {
-			// Load the newly-created file so filePath is set on resultMgr
-			if err := cm.resultMgr.LoadResults(config.Settings.ResultFile); err != nil {
-				dialog.ShowError(fmt.Errorf("error initialising result manager: %w", err), cm.window)
-			}
 		}
 	}
 
-	// Set initial discipline selection after everything is loaded
-	cm.disciplineSelect.SetSelected(models.DisciplineBottle)
+	// Running orders and skipped lists; without them the lists run by name
+	orders, err := data.LoadRunningOrders(data.RunningOrderPathFor(config.Settings.ParticipantFile))
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error loading running orders: %w", err), cm.window)
+	}
+	cm.runningOrders = orders
+	cm.loadAvailableParticipants()
+
+	// Records of the other contests; the ones that cannot be read are left
+	// out of them
+	if config.Settings.FolderPath != "" {
+		records, err := data.LoadRecordBook(config.Settings.FolderPath, config.Settings.FolderPathContestNameAndDate)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("some contests are left out of the records: %w", err), cm.window)
+		}
+		cm.records = records
+	}
+
+	// Set initial discipline selection after everything is loaded, keeping
+	// the current one if the contest still runs it
+	if _, ok := cm.disciplines.Get(cm.disciplineSelect.Selected); !ok {
+		cm.disciplineSelect.SetSelected(cm.disciplines.Names()[0])
+	}
+}
+
+// onDataChanged rebuilds the participant lists after another window or
+// program changed the contest data, keeping the loaded chugger in step.
+func (cm *ChugManager) onDataChanged(c data.Change) {
+	if !c.Participants && !c.Teams && !c.Results {
+		return
+	}
+	cm.allParticipants = cm.participantMgr.GetParticipants()
+	sort.Slice(cm.allParticipants, func(i, j int) bool {
+		return cm.allParticipants[i].Name < cm.allParticipants[j].Name
+	})
+	if cm.currentChugger != nil {
+		for _, p := range cm.allParticipants {
+			if p.ID == cm.currentChugger.ID {
+				*cm.currentChugger = p
+				break
+			}
+		}
+	}
+	if cm.currentTeam != nil {
+		if t, ok := cm.teamMgr.GetTeam(cm.currentTeam.ID); ok {
+			*cm.currentTeam = t
+		}
+	}
+	cm.loadAvailableParticipants()
+	cm.updateCurrentChuggerDisplay()
 }
 
 func (cm *ChugManager) loadAvailableParticipants() {
 	discipline := cm.disciplineSelect.Selected
 
+	if cm.disciplines.IsTeam(discipline) {
+		cm.loadAvailableTeams()
+		return
+	}
+
 	// Create available participants list based on discipline
 	var participantsForDiscipline []models.Participant
 
 	// Start with all participants
 	allParticipants := cm.allParticipants
 
+	def, _ := cm.disciplines.Get(discipline)
+	results := cm.resultMgr.GetResults()
 	for _, p := range allParticipants {
-		// Simple filter logic - if it has a valid value for the discipline, include it
-		include := false
-
-		switch discipline {
-		case "Bottle":
-			if p.Bottle != "" && p.Bottle != "0" {
-				include = true
-			}
-		case "Half Tankard":
-			if p.HalfTankard != "" && p.HalfTankard != "0" {
-				include = true
-			}
-		case "Full Tankard":
-			if p.FullTankard != "" && p.FullTankard != "0" {
-				include = true
-			}
+		// Participants stay eligible until every try they are entitled to
+		// has been used by a recorded attempt, or for good in an unofficial
+		// contest
+		if cm.rules.MayRun(p, def, results) {
+			participantsForDiscipline = append(participantsForDiscipline, p)
 		}
+	}
 
-		if include {
-			// Check if already has a result for this discipline
-			hasResult := false
-			// This would require checking results, for now we just show all eligible
+	// Exclude skipped participants
+	order := cm.runningOrder(discipline)
+	filtered := participantsForDiscipline[:0]
+	for _, p := range participantsForDiscipline {
+		if !data.IsSkipped(order, p.ID) {
+			filtered = append(filtered, p)
+		}
+	}
+	participantsForDiscipline = filtered
+
+	// Put them in running order; without a draw they run by name
+	byID := make(map[string]models.Participant, len(participantsForDiscipline))
+	ids := make([]string, 0, len(participantsForDiscipline))
+	for _, p := range participantsForDiscipline {
+		byID[p.ID] = p
+		ids = append(ids, p.ID)
+	}
+	cm.availableParticipants = nil
+	for _, id := range data.ArrangeByRunningOrder(order, ids) {
+		cm.availableParticipants = append(cm.availableParticipants, byID[id])
+	}
 
-			if !hasResult {
-				participantsForDiscipline = append(participantsForDiscipline, p)
-			}
+	// Most remaining tries first, so everyone has had their first try before
+	// anyone takes a second, keeping the running order within each round.
+	// With unlimited tries the fewest attempts made come first instead
+	round := make(map[string]int, len(cm.availableParticipants))
+	for _, p := range cm.availableParticipants {
+		if cm.rules.LimitTries {
+			round[p.ID] = -data.RemainingTries(p, def, results)
+		} else {
+			round[p.ID] = data.AttemptsUsed(results, p, discipline)
 		}
 	}
+	sort.SliceStable(cm.availableParticipants, func(i, j int) bool {
+		return round[cm.availableParticipants[i].ID] < round[cm.availableParticipants[j].ID]
+	})
 
-	// Exclude skipped participants
-	if len(cm.skippedParticipants) > 0 {
-		skippedNames := make(map[string]struct{}, len(cm.skippedParticipants))
-		for _, sp := range cm.skippedParticipants {
-			skippedNames[sp.Name] = struct{}{}
+	cm.refreshLists()
+}
+
+// remainingTries returns how many tries p has left in discipline, computed
+// from the attempts recorded so far.
+func (cm *ChugManager) remainingTries(p models.Participant, discipline string) int {
+	def, ok := cm.disciplines.Get(discipline)
+	if !ok {
+		return 0
+	}
+	return cm.resultMgr.RemainingTries(p, def)
+}
+
+// triesSummary lists p's remaining tries in every individual discipline, by
+// the initials of the discipline names, e.g. "B 3  HT 2  FT 1". With
+// unlimited tries the disciplines p entered show "∞".
+func (cm *ChugManager) triesSummary(p models.Participant) string {
+	var parts []string
+	for _, def := range cm.disciplines.Defs() {
+		if def.Team {
+			continue
 		}
-		filtered := participantsForDiscipline[:0]
-		for _, p := range participantsForDiscipline {
-			if _, skipped := skippedNames[p.Name]; !skipped {
-				filtered = append(filtered, p)
-			}
+		initials := ""
+		for _, word := range strings.Fields(def.Name) {
+			initials += strings.ToUpper(string([]rune(word)[0]))
 		}
-		participantsForDiscipline = filtered
-	}
-
-	cm.availableParticipants = participantsForDiscipline
-
-	// Sort by most remaining tries descending so the next chugger loaded is always
-	// the one with the most tries, with name as a tiebreaker.
-	sort.Slice(cm.availableParticipants, func(i, j int) bool {
-		var ti, tj int
-		switch discipline {
-		case models.DisciplineBottle:
-			ti, _ = strconv.Atoi(cm.availableParticipants[i].Bottle)
-			tj, _ = strconv.Atoi(cm.availableParticipants[j].Bottle)
-		case models.DisciplineHalfTankard:
-			ti, _ = strconv.Atoi(cm.availableParticipants[i].HalfTankard)
-			tj, _ = strconv.Atoi(cm.availableParticipants[j].HalfTankard)
-		case models.DisciplineFullTankard:
-			ti, _ = strconv.Atoi(cm.availableParticipants[i].FullTankard)
-			tj, _ = strconv.Atoi(cm.availableParticipants[j].FullTankard)
+		if entitled, _ := data.TriesEntitled(p, def); !cm.rules.LimitTries && entitled > 0 {
+			parts = append(parts, initials+" ∞")
+			continue
 		}
-		if ti != tj {
-			return ti > tj // most tries first
+		parts = append(parts, fmt.Sprintf("%s %d", initials, cm.resultMgr.RemainingTries(p, def)))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// loadAvailableTeams lists the teams that have not been skipped in running
+// order, or by name without a draw.
+func (cm *ChugManager) loadAvailableTeams() {
+	order := cm.runningOrder(cm.disciplineSelect.Selected)
+	byID := make(map[string]models.Team)
+	var ids []string
+	for _, t := range cm.sortedTeams() {
+		if !data.IsSkipped(order, t.ID) {
+			byID[t.ID] = t
+			ids = append(ids, t.ID)
 		}
-		return cm.availableParticipants[i].Name < cm.availableParticipants[j].Name
-	})
+	}
+
+	cm.availableTeams = nil
+	for _, id := range data.ArrangeByRunningOrder(order, ids) {
+		cm.availableTeams = append(cm.availableTeams, byID[id])
+	}
 
 	cm.refreshLists()
 }
 
+// sortedTeams returns the teams by name.
+func (cm *ChugManager) sortedTeams() []models.Team {
+	teams := cm.teamMgr.GetTeams()
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+	return teams
+}
+
+// runningField returns the IDs of everyone who runs discipline, by name:
+// the participants entitled to a try in it, or all teams.
+func (cm *ChugManager) runningField(discipline string) []string {
+	def, ok := cm.disciplines.Get(discipline)
+	if !ok {
+		return nil
+	}
+	var ids []string
+	if def.Team {
+		for _, t := range cm.sortedTeams() {
+			ids = append(ids, t.ID)
+		}
+		return ids
+	}
+	for _, p := range cm.allParticipants {
+		if tries, _ := data.TriesEntitled(p, def); tries > 0 {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// runningOrder returns the running order of discipline; an empty one if it
+// has not been drawn and no one has been skipped.
+func (cm *ChugManager) runningOrder(discipline string) models.RunningOrder {
+	if i := data.FindRunningOrder(cm.runningOrders, discipline); i >= 0 {
+		return cm.runningOrders[i]
+	}
+	return models.RunningOrder{Discipline: discipline}
+}
+
+// setRunningOrder stores o as the running order of its discipline, saves
+// the running orders to the contest folder and puts the lists in the new
+// order.
+func (cm *ChugManager) setRunningOrder(o models.RunningOrder) error {
+	orders := append([]models.RunningOrder(nil), cm.runningOrders...)
+	if i := data.FindRunningOrder(orders, o.Discipline); i >= 0 {
+		orders[i] = o
+	} else {
+		orders = append(orders, o)
+	}
+	if err := data.SaveRunningOrders(data.RunningOrderPathFor(config.Settings.ParticipantFile), orders); err != nil {
+		return err
+	}
+	cm.runningOrders = orders
+	cm.loadAvailableParticipants()
+	return nil
+}
+
+// loadTeam makes team the current competitor.
+func (cm *ChugManager) loadTeam(team models.Team) {
+	cm.currentChugger = nil
+	cm.currentTeam = &team
+	cm.updateCurrentChuggerDisplay()
+
+	cm.clearResultForm()
+	cm.resetTimer()
+}
+
+// isTeamDiscipline reports whether the selected discipline is run by teams.
+func (cm *ChugManager) isTeamDiscipline() bool {
+	return cm.disciplineSelect != nil && cm.disciplines.IsTeam(cm.disciplineSelect.Selected)
+}
+
+// hasCompetitor reports whether a participant or team is loaded.
+func (cm *ChugManager) hasCompetitor() bool {
+	return cm.currentChugger != nil || cm.currentTeam != nil
+}
+
+// competitorName returns the name of the loaded participant or team.
+func (cm *ChugManager) competitorName() string {
+	if cm.currentTeam != nil {
+		return cm.currentTeam.Name
+	}
+	if cm.currentChugger != nil {
+		return cm.currentChugger.Name
+	}
+	return ""
+}
+
+// newResult starts a result for the loaded competitor: team results refer
+// to the team, all others to the participant.
+func (cm *ChugManager) newResult() models.Result {
+	if cm.currentTeam != nil {
+		return models.Result{TeamID: cm.currentTeam.ID, Name: cm.currentTeam.Name}
+	}
+	return models.Result{ParticipantID: cm.currentChugger.ID, Name: cm.currentChugger.Name}
+}
+
+// isRelay reports whether a relay is loaded: a team running a relay
+// discipline such as Bier Staphette, timed leg by leg in roster order.
+func (cm *ChugManager) isRelay() bool {
+	if cm.currentTeam == nil {
+		return false
+	}
+	def, _ := cm.disciplines.Get(cm.disciplineSelect.Selected)
+	return def.Relay
+}
+
+// relayMembers returns the runners of the loaded relay in leg order.
+func (cm *ChugManager) relayMembers() []models.Participant {
+	if cm.currentTeam == nil {
+		return nil
+	}
+	return data.RosterMembers(*cm.currentTeam, cm.allParticipants)
+}
+
+// splitLeg marks a changeover of the running relay at the internal timer's
+// current time. With the external clock the clock's own times are used.
+func (cm *ChugManager) splitLeg() {
+	if cm.useExternalClock {
+		return
+	}
+	cm.captureSplit(cm.formatDuration(cm.timerState.Duration))
+}
+
+// captureSplit records t, the time since the start, as the end of the
+// current relay leg. The timer stops once the last leg is in.
+func (cm *ChugManager) captureSplit(t string) {
+	if !cm.isRelay() || !cm.timerState.Running {
+		return
+	}
+	legs := len(cm.relayMembers())
+	if len(cm.relayMarks) >= legs {
+		return
+	}
+	cm.relayMarks = append(cm.relayMarks, t)
+	cm.updateSplitsDisplay()
+
+	if len(cm.relayMarks) == legs {
+		cm.stopTimerFunc()
+	}
+}
+
+// relaySplits returns the leg splits to store with a relay result. A relay
+// that was not timed leg by leg has none; one that was stopped before the
+// last leg can only be saved as disqualified, without splits.
+func (cm *ChugManager) relaySplits(status string) ([]models.Split, error) {
+	if !cm.isRelay() || len(cm.relayMarks) == 0 {
+		return nil, nil
+	}
+	members := cm.relayMembers()
+	if len(cm.relayMarks) < len(members) && status == models.StatusDisqualified {
+		return nil, nil
+	}
+	splits, err := data.RelaySplits(members, cm.relayMarks)
+	if err != nil {
+		return nil, fmt.Errorf("error in relay splits: %w", err)
+	}
+	return splits, nil
+}
+
+// updateSplitsDisplay lists the legs of the loaded relay with the splits
+// captured so far.
+func (cm *ChugManager) updateSplitsDisplay() {
+	if cm.splitsLabel == nil {
+		return
+	}
+	if !cm.isRelay() {
+		cm.splitsLabel.SetText("")
+		return
+	}
+
+	members := cm.relayMembers()
+	var done []models.Split
+	if n := len(cm.relayMarks); n > 0 && n <= len(members) {
+		done, _ = data.RelaySplits(members[:n], cm.relayMarks)
+	}
+
+	var lines []string
+	for i, p := range members {
+		if i < len(done) {
+			lines = append(lines, fmt.Sprintf("Leg %d – %s: %s", i+1, p.Name, done[i].Time))
+		} else {
+			lines = append(lines, fmt.Sprintf("Leg %d – %s", i+1, p.Name))
+		}
+	}
+	cm.splitsLabel.SetText(strings.Join(lines, "\n"))
+}
+
 // Show displays the chug manager window
 func (cm *ChugManager) Show() {
 	cm.window.Show()
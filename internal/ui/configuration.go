@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -11,6 +12,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"chugware/internal/config"
+	"chugware/internal/data"
 	"chugware/internal/models"
 )
 
@@ -59,6 +61,10 @@ type ConfigurationWindow struct {
 	externalPortEntry  *widget.Entry
 	externalBaudSelect *widget.Select
 
+	// Station identity (recorded in the result journal)
+	stationIDEntry    *widget.Entry
+	operatorNameEntry *widget.Entry
+
 	// Action buttons
 	saveBtn  *widget.Button
 	loadBtn  *widget.Button
@@ -93,6 +99,7 @@ func (cw *ConfigurationWindow) setupUI() {
 	cw.createTrialComponents()
 	cw.createAppComponents()
 	cw.createExternalEquipmentComponents()
+	cw.createStationComponents()
 	cw.createActionComponents()
 
 	// Create layout
@@ -211,6 +218,15 @@ func (cw *ConfigurationWindow) createExternalEquipmentComponents() {
 	cw.externalBaudSelect.SetSelected("9600")
 }
 
+// createStationComponents creates the station/operator identity fields
+func (cw *ConfigurationWindow) createStationComponents() {
+	cw.stationIDEntry = widget.NewEntry()
+	cw.stationIDEntry.SetPlaceHolder("Station identifier")
+
+	cw.operatorNameEntry = widget.NewEntry()
+	cw.operatorNameEntry.SetPlaceHolder("Operator name")
+}
+
 // createActionComponents creates action buttons
 func (cw *ConfigurationWindow) createActionComponents() {
 	cw.saveBtn = widget.NewButton("Save Configuration", cw.saveConfiguration)
@@ -272,8 +288,8 @@ func (cw *ConfigurationWindow) createLayout() *fyne.Container {
 			createSettingsField("Bier Staphette File", "Data file for Bier Staphette discipline.", cw.bierStaphetteFileEntry, nil),
 			createSettingsField("Mega Medley File", "Data file for Mega Medley discipline.", cw.megaMedleyFileEntry, nil),
 			createSettingsField("Team Clash File", "Data file for Team Clash discipline.", cw.teamClashFileEntry, nil),
-			createSettingsField("Faction File", "Data file for Faction info.", cw.factionFileEntry, nil),
-			createSettingsField("Leaderboard File", "Data file for storing leaderboard stats.", cw.leaderBoardFileEntry, nil),
+			createSettingsField("Faction File", "File the faction standings are written to; relative to the results folder.", cw.factionFileEntry, nil),
+			createSettingsField("Leaderboard File", "File the overall standings are written to; relative to the results folder.", cw.leaderBoardFileEntry, nil),
 			createSettingsField("Diplomas File", "Data file for diploma generation.", cw.diplomasFileEntry, nil),
 		),
 	)
@@ -327,6 +343,14 @@ func (cw *ConfigurationWindow) createLayout() *fyne.Container {
 	}
 	extEquipCard := widget.NewCard("External Equipment", "", extEquipContent)
 
+	// Station identity card
+	stationCard := widget.NewCard("Station & Operator", "",
+		container.NewVBox(
+			createSettingsField("Station ID", "Identifies this computer in the result journal. Defaults to the host name.", cw.stationIDEntry, nil),
+			createSettingsField("Operator", "Name of the person recording results at this station.", cw.operatorNameEntry, nil),
+		),
+	)
+
 	// Action buttons
 	actionContainer := container.NewHBox(
 		cw.saveBtn,
@@ -346,6 +370,7 @@ func (cw *ConfigurationWindow) createLayout() *fyne.Container {
 		trialCard,
 		appCard,
 		extEquipCard,
+		stationCard,
 		widget.NewSeparator(),
 		actionContainer,
 		statusContainer,
@@ -384,6 +409,14 @@ func (cw *ConfigurationWindow) browseFile(title string, entry *widget.Entry) {
 
 // Configuration operations
 func (cw *ConfigurationWindow) saveConfiguration() {
+	// An official contest with results keeps its participant and result
+	// files; files of another contest open that contest instead
+	if err := data.CheckContestFiles(config.Settings.ParticipantFile, config.Settings.ResultFile,
+		cw.participantFileEntry.Text, cw.resultFileEntry.Text); err != nil {
+		dialog.ShowError(err, cw.window)
+		return
+	}
+
 	// Update configuration with form values
 	config.Settings.FolderPath = cw.folderPathEntry.Text
 	config.Settings.ExternalClockPort = cw.externalPortEntry.Text
@@ -402,6 +435,8 @@ func (cw *ConfigurationWindow) saveConfiguration() {
 	config.Settings.FactionFile = cw.factionFileEntry.Text
 	config.Settings.LeaderBoardFile = cw.leaderBoardFileEntry.Text
 	config.Settings.DiplomasFile = cw.diplomasFileEntry.Text
+	config.Settings.StationID = strings.TrimSpace(cw.stationIDEntry.Text)
+	config.Settings.OperatorName = strings.TrimSpace(cw.operatorNameEntry.Text)
 
 	// Validate and update numeric settings
 	if err := cw.validateAndUpdateNumericSettings(); err != nil {
@@ -485,6 +520,8 @@ func (cw *ConfigurationWindow) loadCurrentSettings() {
 	cw.factionFileEntry.SetText(config.Settings.FactionFile)
 	cw.leaderBoardFileEntry.SetText(config.Settings.LeaderBoardFile)
 	cw.diplomasFileEntry.SetText(config.Settings.DiplomasFile)
+	cw.stationIDEntry.SetText(config.Settings.StationID)
+	cw.operatorNameEntry.SetText(config.Settings.OperatorName)
 
 	cw.statusLabel.SetText("Configuration loaded")
 }
@@ -515,6 +552,8 @@ func (cw *ConfigurationWindow) performReset() {
 	cw.factionFileEntry.SetText("")
 	cw.leaderBoardFileEntry.SetText("")
 	cw.diplomasFileEntry.SetText("")
+	cw.stationIDEntry.SetText("")
+	cw.operatorNameEntry.SetText("")
 
 	// Reset trial settings to defaults
 	cw.bottleTriesEntry.SetText(strconv.Itoa(config.BottleTries))
@@ -13,7 +13,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"chugware/internal/config"
-	"chugware/internal/utils"
+	"chugware/internal/data"
 )
 
 type ContestWizardWindow struct {
@@ -40,6 +40,15 @@ func (w *ContestWizardWindow) setupUI() {
 	dateEntry := widget.NewEntry()
 	dateEntry.SetPlaceHolder("Enter Contest Date (YYYY-MM-DD)")
 
+	// Official contests run by the strict rules and count towards records
+	// and season standings; unofficial ones relax the rules
+	modeRadio := widget.NewRadioGroup([]string{config.OfficialKey, config.UnOfficialKey}, nil)
+	modeRadio.Horizontal = true
+	modeRadio.Required = true
+	modeRadio.SetSelected(config.OfficialKey)
+	modeHelp := widget.NewLabel("Official: tries are limited and the setup is locked after the first result.\n" +
+		"Unofficial: unlimited tries, and the contest stays out of records and\nseason standings.")
+
 	// Set today's date as default
 	nameEntry.SetText("Contest")
 	dateEntry.SetText(time.Now().Format("2006-01-02"))
@@ -58,14 +67,14 @@ func (w *ContestWizardWindow) setupUI() {
 			return
 		}
 
-		if err := w.createContest(contestName, contestDate); err != nil {
+		if err := w.createContest(contestName, contestDate, modeRadio.Selected == config.OfficialKey); err != nil {
 			dialog.ShowError(fmt.Errorf("error creating contest: %w", err), w.window)
 			return
 		}
 
 		dialog.ShowInformation("Contest Created",
-			fmt.Sprintf("Contest '%s' scheduled for %s has been created.\n\nFiles created in: %s",
-				contestName, contestDate, config.Settings.FolderPathContestNameAndDate),
+			fmt.Sprintf("%s contest '%s' scheduled for %s has been created.\n\nFiles created in: %s",
+				modeRadio.Selected, contestName, contestDate, config.Settings.FolderPathContestNameAndDate),
 			w.window)
 	})
 
@@ -80,22 +89,23 @@ func (w *ContestWizardWindow) setupUI() {
 		nameEntry,
 		widget.NewLabel("Contest Date:"),
 		dateEntry,
+		widget.NewLabel("Contest Mode:"),
+		modeRadio,
+		modeHelp,
 		widget.NewSeparator(),
 		container.NewHBox(createButton, exitButton),
 	)
 
 	content := container.NewPadded(form)
 	w.window.SetContent(content)
-	w.window.Resize(fyne.NewSize(400, 300))
+	w.window.Resize(fyne.NewSize(500, 400))
 	w.window.CenterOnScreen()
 }
 
-func (w *ContestWizardWindow) createContest(contestName, contestDate string) error {
-	// Create contest folder name: Contest_Name_YYYY-MM-DD_Official
-	folderName := fmt.Sprintf("%s_%s_%s",
-		strings.ReplaceAll(contestName, " ", "_"),
-		contestDate,
-		config.OfficialKey)
+func (w *ContestWizardWindow) createContest(contestName, contestDate string, official bool) error {
+	// Create contest folder name: Contest_Name_YYYY-MM-DD_Official, or
+	// _Unofficial; the mode sets the rules the contest runs under
+	folderName := data.ContestFolderName(contestName, contestDate, official)
 
 	// Set up contest directory path
 	contestPath := filepath.Join(config.Settings.FolderPath, folderName)
@@ -126,16 +136,24 @@ func (w *ContestWizardWindow) createContest(contestName, contestDate string) err
 	participantFile := filepath.Join(contestPath, config.ContestDirectory, "participants.json")
 	resultFile := filepath.Join(contestPath, config.ContestDirectory, "results.json")
 
-	// Create empty participant file
-	if err := utils.SaveListToJSONFile(participantFile, []map[string]string{}); err != nil {
+	// Create empty, versioned participant, team and result files
+	store := data.NewJSONStore(participantFile, resultFile)
+	if err := store.SaveParticipants(nil); err != nil {
 		return fmt.Errorf("failed to create participants file: %w", err)
 	}
-
-	// Create empty results file
-	if err := utils.SaveListToJSONFile(resultFile, []map[string]string{}); err != nil {
+	if err := store.SaveTeams(nil); err != nil {
+		return fmt.Errorf("failed to create teams file: %w", err)
+	}
+	if err := store.SaveResults(nil); err != nil {
 		return fmt.Errorf("failed to create results file: %w", err)
 	}
 
+	// Start from the built-in disciplines; the club can edit the file to
+	// add its own
+	if err := data.SaveDisciplines(data.DisciplinePathFor(participantFile), data.DefaultDisciplineRegistry()); err != nil {
+		return fmt.Errorf("failed to create disciplines file: %w", err)
+	}
+
 	// Update configuration with new file paths
 	config.Settings.ParticipantFile = participantFile
 	config.Settings.ResultFile = resultFile
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 
 	"chugware/internal/config"
 	"chugware/internal/data"
+	"chugware/internal/export"
 	"chugware/internal/models"
 	"chugware/internal/utils"
 )
@@ -22,26 +24,34 @@ type FinishContest struct {
 	app    fyne.App
 	window fyne.Window
 
-	// Data managers
+	// Data managers, shared with the other windows through the session
+	session        *contestSession
 	participantMgr *data.ParticipantManager
+	teamMgr        *data.TeamManager
 	resultMgr      *data.ResultManager
 
-	// UI Components - Filtering
-	sortFilter *widget.RadioGroup
+	// Disciplines of the contest, from its discipline file
+	disciplines *data.DisciplineRegistry
 
-	// UI Components - Results Display
-	bottleList        *widget.List
-	halfTankardList   *widget.List
-	fullTankardList   *widget.List
-	bierStaphetteList *widget.List
-	megaMedleyList    *widget.List
-	teamClashList     *widget.List
-	summaryCard       *widget.Card
+	// UI Components - Filtering
+	sortFilter     *widget.RadioGroup
+	categorySelect *widget.Select
+
+	// UI Components - Results Display: one tab per discipline, then the
+	// fastest relay legs and the overall standings
+	disciplineTabs  *container.AppTabs
+	disciplineLists map[string]*widget.List
+	fastestLegsList *widget.List
+	overallList     *widget.List
+	factionList     *widget.List
+	summaryCard     *widget.Card
 
 	// UI Components - Actions
 	generateReportBtn   *widget.Button
 	exportResultsBtn    *widget.Button
 	generateDiplomasBtn *widget.Button
+	auditTrailBtn       *widget.Button
+	editResultsBtn      *widget.Button
 	refreshBtn          *widget.Button
 	saveBtn             *widget.Button
 
@@ -53,17 +63,35 @@ type FinishContest struct {
 	contestDateLabel       *widget.Label
 
 	// Data
-	allResults           []models.Result
-	filteredResults      []models.Result
-	bottleResults        []models.Result
-	halfTankardResults   []models.Result
-	fullTankardResults   []models.Result
-	bierStaphetteResults []models.Result
-	megaMedleyResults    []models.Result
-	teamClashResults     []models.Result
-	participants         []models.Participant
+	allResults          []models.Result
+	filteredResults     []models.Result
+	disciplineStandings map[string][]data.Standing
+	categoryStandings   map[string]map[string][]data.Standing // by category, then discipline
+	fastestLegs         []data.Leg
+	championship        data.Championship
+	participants        []models.Participant
+	teams               []models.Team
+
+	// otherRecords holds the records of the other contests under the
+	// ChugWare folder; records adds this contest to them
+	otherRecords *data.RecordBook
+	records      *data.RecordBook
+
+	// Knockout brackets of the team disciplines
+	brackets []models.Bracket
+
+	// Categories with leaderboards of their own: the configured ones, as
+	// read from the category file, and those the participants are in
+	configuredCategories []models.Category
+	categories           []models.Category
+
+	// Rules of the contest's mode, official or unofficial
+	rules data.ContestRules
 }
 
+// allCategories is the category choice that shows every participant
+const allCategories = "All participants"
+
 // LeaderboardEntry represents a leaderboard entry
 type LeaderboardEntry struct {
 	Rank       int
@@ -90,7 +118,13 @@ func NewFinishContest(app fyne.App) *FinishContest {
 // initializeManagers sets up data managers
 func (fc *FinishContest) initializeManagers() {
 	fc.participantMgr = data.NewParticipantManager()
+	fc.teamMgr = data.NewTeamManager()
 	fc.resultMgr = data.NewResultManager()
+	fc.disciplines = data.DefaultDisciplineRegistry()
+	fc.otherRecords = data.NewRecordBook()
+	fc.records = data.NewRecordBook()
+	fc.rules = data.OfficialRules()
+	fc.session = newContestSession(fc.window, fc.onDataChanged)
 }
 
 // setupUI initializes the contest finalization UI
@@ -102,6 +136,8 @@ func (fc *FinishContest) setupUI() {
 	// Initialize components
 	fc.createFilterComponents()
 	fc.createDisplayComponents()
+	fc.createFastestLegsList()
+	fc.createStandingsLists()
 	fc.createSummaryComponents()
 	fc.createActionComponents()
 
@@ -122,210 +158,180 @@ func (fc *FinishContest) createFilterComponents() {
 		"💀 Hall of Shame",
 	}
 	fc.sortFilter = widget.NewRadioGroup(sortOptions, fc.onStatusFilterChanged)
+
+	fc.categorySelect = widget.NewSelect([]string{allCategories}, func(string) {
+		fc.refreshDisciplineLists()
+	})
+	fc.categorySelect.SetSelected(allCategories)
 }
 
 // createDisplayComponents creates result display components
 func (fc *FinishContest) createDisplayComponents() {
-	// Bottle results list
-	fc.bottleList = widget.NewList(
-		func() int { return len(fc.bottleResults) },
-		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Team", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-			)
-		},
-		func(id widget.ListItemID, item fyne.CanvasObject) {
-			if id >= 0 && id < len(fc.bottleResults) {
-				r := fc.bottleResults[id]
-				containers := item.(*fyne.Container)
+	fc.disciplineTabs = container.NewAppTabs()
+	fc.disciplineTabs.SetTabLocation(container.TabLocationTop)
+}
 
-				containers.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d", id+1))
-				containers.Objects[1].(*widget.Label).SetText(r.Name)
-				// Find team mapping from participant list if possible, or leave empty if not in Result
-				team := ""
-				for _, p := range fc.participants {
-					if p.Name == r.Name {
-						team = p.Team
-						break
-					}
-				}
-				containers.Objects[2].(*widget.Label).SetText(team)
-				containers.Objects[3].(*widget.Label).SetText(r.Time)
-				containers.Objects[4].(*widget.Label).SetText(r.Status)
-			}
-		},
+// rebuildDisciplineTabs makes one results tab per discipline of the contest,
+// each followed by its bracket if it has one, then the fastest relay legs if
+// the contest has a relay.
+func (fc *FinishContest) rebuildDisciplineTabs() {
+	fc.disciplineLists = make(map[string]*widget.List)
+	var tabs []*container.TabItem
+	for _, def := range fc.disciplines.Defs() {
+		list := fc.newResultList(def)
+		fc.disciplineLists[def.Name] = list
+		tabs = append(tabs, container.NewTabItem(def.Name, list))
+		if i := data.FindBracket(fc.brackets, def.Name); i >= 0 {
+			view := newBracketView(fc.brackets[i], bracketTeamName(fc.teams))
+			tabs = append(tabs, container.NewTabItem(def.Name+" Bracket", view))
+		}
+	}
+	if len(fc.disciplines.Relays()) > 0 {
+		tabs = append(tabs, container.NewTabItem("Fastest Legs", fc.fastestLegsList))
+	}
+	tabs = append(tabs,
+		container.NewTabItem("Overall", fc.overallList),
+		container.NewTabItem("Factions", fc.factionList),
 	)
+	fc.disciplineTabs.SetItems(tabs)
+}
 
-	// Half-Tankard results list
-	fc.halfTankardList = widget.NewList(
-		func() int { return len(fc.halfTankardResults) },
+// newResultList creates the standings list of a discipline: one row per
+// participant or team with the attempt that counts, followed by their other
+// attempts. Team disciplines show the roster where individual ones show the
+// participant's team.
+func (fc *FinishContest) newResultList(def models.DisciplineDef) *widget.List {
+	teamHeader := "Team"
+	if def.Team {
+		teamHeader = "Roster"
+	}
+	return widget.NewList(
+		func() int { return len(fc.shownStandings(def)) },
 		func() fyne.CanvasObject {
 			return container.NewHBox(
 				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Team", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle(teamHeader, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabel("Other Attempts"),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
-			if id >= 0 && id < len(fc.halfTankardResults) {
-				r := fc.halfTankardResults[id]
+			standings := fc.shownStandings(def)
+			if id >= 0 && id < len(standings) {
+				st := standings[id]
+				r := st.Result
 				containers := item.(*fyne.Container)
 
-				containers.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d", id+1))
+				containers.Objects[0].(*widget.Label).SetText(st.Place())
 				containers.Objects[1].(*widget.Label).SetText(r.Name)
-				team := ""
-				for _, p := range fc.participants {
-					if p.Name == r.Name {
-						team = p.Team
-						break
-					}
-				}
-				containers.Objects[2].(*widget.Label).SetText(team)
+				// Team results list the roster, the others the participant's team
+				containers.Objects[2].(*widget.Label).SetText(fc.rosterOf(r))
 				containers.Objects[3].(*widget.Label).SetText(r.Time)
-				containers.Objects[4].(*widget.Label).SetText(r.Status)
-			}
-		},
-	)
-
-	// Full-Tankard results list
-	fc.fullTankardList = widget.NewList(
-		func() int { return len(fc.fullTankardResults) },
-		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Team", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-			)
-		},
-		func(id widget.ListItemID, item fyne.CanvasObject) {
-			if id >= 0 && id < len(fc.fullTankardResults) {
-				r := fc.fullTankardResults[id]
-				containers := item.(*fyne.Container)
-
-				containers.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d", id+1))
-				containers.Objects[1].(*widget.Label).SetText(r.Name)
-				team := ""
-				for _, p := range fc.participants {
-					if p.Name == r.Name {
-						team = p.Team
-						break
-					}
+				status := r.Status
+				if st.Note != "" {
+					status += " (" + st.Note + ")"
 				}
-				containers.Objects[2].(*widget.Label).SetText(team)
-				containers.Objects[3].(*widget.Label).SetText(r.Time)
-				containers.Objects[4].(*widget.Label).SetText(r.Status)
-			}
-		},
-	)
-
-	// Bier Staphette results list
-	fc.bierStaphetteList = widget.NewList(
-		func() int { return len(fc.bierStaphetteResults) },
-		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Team", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-			)
-		},
-		func(id widget.ListItemID, item fyne.CanvasObject) {
-			if id >= 0 && id < len(fc.bierStaphetteResults) {
-				r := fc.bierStaphetteResults[id]
-				containers := item.(*fyne.Container)
-
-				containers.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d", id+1))
-				containers.Objects[1].(*widget.Label).SetText(r.Name)
-				team := ""
-				for _, p := range fc.participants {
-					if p.Name == r.Name {
-						team = p.Team
-						break
-					}
+				if rec := fc.recordSummary(r); rec != "" {
+					status += " – " + rec
 				}
-				containers.Objects[2].(*widget.Label).SetText(team)
-				containers.Objects[3].(*widget.Label).SetText(r.Time)
-				containers.Objects[4].(*widget.Label).SetText(r.Status)
+				containers.Objects[4].(*widget.Label).SetText(status)
+				containers.Objects[5].(*widget.Label).SetText(otherAttempts(st))
 			}
 		},
 	)
+}
 
-	// Mega Medley results list
-	fc.megaMedleyList = widget.NewList(
-		func() int { return len(fc.megaMedleyResults) },
+// otherAttempts lists the attempts of a standing that do not count, e.g.
+// "00:00:09.1000 (Pass), NaN (Disqualified)".
+func otherAttempts(st data.Standing) string {
+	parts := make([]string, len(st.Others))
+	for i, r := range st.Others {
+		parts[i] = fmt.Sprintf("%s (%s)", r.Time, r.Status)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// createFastestLegsList creates the list of the fastest relay legs across
+// all teams.
+func (fc *FinishContest) createFastestLegsList() {
+	fc.fastestLegsList = widget.NewList(
+		func() int { return len(fc.fastestLegs) },
 		func() fyne.CanvasObject {
 			return container.NewHBox(
 				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Team", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Leg", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
-			if id >= 0 && id < len(fc.megaMedleyResults) {
-				r := fc.megaMedleyResults[id]
+			if id >= 0 && id < len(fc.fastestLegs) {
+				l := fc.fastestLegs[id]
 				containers := item.(*fyne.Container)
 
 				containers.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d", id+1))
-				containers.Objects[1].(*widget.Label).SetText(r.Name)
-				team := ""
-				for _, p := range fc.participants {
-					if p.Name == r.Name {
-						team = p.Team
-						break
-					}
-				}
-				containers.Objects[2].(*widget.Label).SetText(team)
-				containers.Objects[3].(*widget.Label).SetText(r.Time)
-				containers.Objects[4].(*widget.Label).SetText(r.Status)
+				containers.Objects[1].(*widget.Label).SetText(l.Name)
+				containers.Objects[2].(*widget.Label).SetText(l.Team)
+				containers.Objects[3].(*widget.Label).SetText(fmt.Sprintf("%d", l.Leg))
+				containers.Objects[4].(*widget.Label).SetText(l.Time)
 			}
 		},
 	)
+}
+
+// createStandingsLists creates the overall standings lists: participants and
+// factions by championship points.
+func (fc *FinishContest) createStandingsLists() {
+	fc.overallList = fc.newPointsList("Program", func() []data.PointsStanding { return fc.championship.Individuals })
+	fc.factionList = fc.newPointsList("", func() []data.PointsStanding { return fc.championship.Factions })
+}
 
-	// Team Clash results list
-	fc.teamClashList = widget.NewList(
-		func() int { return len(fc.teamClashResults) },
+// newPointsList creates a list of points standings with their points per
+// discipline. programHeader names the program column; without one the
+// column is left out.
+func (fc *FinishContest) newPointsList(programHeader string, standings func() []data.PointsStanding) *widget.List {
+	return widget.NewList(
+		func() int { return len(standings()) },
 		func() fyne.CanvasObject {
 			return container.NewHBox(
 				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Team", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-				widget.NewLabelWithStyle("Status", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle(programHeader, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Points", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("By Discipline", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			)
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
-			if id >= 0 && id < len(fc.teamClashResults) {
-				r := fc.teamClashResults[id]
+			list := standings()
+			if id >= 0 && id < len(list) {
+				ps := list[id]
 				containers := item.(*fyne.Container)
 
-				containers.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d", id+1))
-				containers.Objects[1].(*widget.Label).SetText(r.Name)
-				team := ""
-				for _, p := range fc.participants {
-					if p.Name == r.Name {
-						team = p.Team
-						break
-					}
-				}
-				containers.Objects[2].(*widget.Label).SetText(team)
-				containers.Objects[3].(*widget.Label).SetText(r.Time)
-				containers.Objects[4].(*widget.Label).SetText(r.Status)
+				containers.Objects[0].(*widget.Label).SetText(ps.Place)
+				containers.Objects[1].(*widget.Label).SetText(ps.Name)
+				containers.Objects[2].(*widget.Label).SetText(ps.Program)
+				containers.Objects[3].(*widget.Label).SetText(fmt.Sprintf("%d", ps.Points))
+				containers.Objects[4].(*widget.Label).SetText(fc.pointsBreakdown(ps))
 			}
 		},
 	)
 }
 
+// pointsBreakdown lists the points of a standing per discipline in the
+// contest's order, e.g. "Bottle 8, Half Tankard 10".
+func (fc *FinishContest) pointsBreakdown(ps data.PointsStanding) string {
+	var parts []string
+	for _, name := range fc.disciplines.Names() {
+		if points, ok := ps.Disciplines[name]; ok {
+			parts = append(parts, fmt.Sprintf("%s %d", name, points))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // createSummaryComponents creates summary display components
 func (fc *FinishContest) createSummaryComponents() {
 	fc.totalParticipantsLabel = widget.NewLabel("0")
@@ -363,6 +369,8 @@ func (fc *FinishContest) createActionComponents() {
 	fc.generateReportBtn = widget.NewButton("Generate Report", fc.generateReport)
 	fc.exportResultsBtn = widget.NewButton("Export Results", fc.exportResults)
 	fc.generateDiplomasBtn = widget.NewButton("Generate Diplomas", fc.generateDiplomas)
+	fc.auditTrailBtn = widget.NewButton("Audit Trail", fc.showAuditTrail)
+	fc.editResultsBtn = widget.NewButton("Edit Results", fc.showResultEditor)
 	fc.refreshBtn = widget.NewButton("Refresh Data", fc.refreshData)
 	fc.saveBtn = widget.NewButton("Save Final Results", fc.saveFinalResults)
 }
@@ -373,6 +381,7 @@ func (fc *FinishContest) createLayout() fyne.CanvasObject {
 	filterCard := widget.NewCard("Scoreboard Mode", "",
 		container.NewVBox(
 			fc.sortFilter,
+			widget.NewFormItem("Category", fc.categorySelect).Widget,
 		),
 	)
 
@@ -381,6 +390,8 @@ func (fc *FinishContest) createLayout() fyne.CanvasObject {
 		fc.generateReportBtn,
 		fc.exportResultsBtn,
 		fc.generateDiplomasBtn,
+		fc.auditTrailBtn,
+		fc.editResultsBtn,
 		widget.NewSeparator(),
 		fc.refreshBtn,
 		fc.saveBtn,
@@ -394,18 +405,10 @@ func (fc *FinishContest) createLayout() fyne.CanvasObject {
 	)
 
 	// Discipline tabs — each list gets the full panel height so it renders correctly
-	disciplineTabs := container.NewAppTabs(
-		container.NewTabItem("Bottle", fc.bottleList),
-		container.NewTabItem("Half-Tankard", fc.halfTankardList),
-		container.NewTabItem("Full-Tankard", fc.fullTankardList),
-		container.NewTabItem("Bier Staphette", fc.bierStaphetteList),
-		container.NewTabItem("Mega Medley", fc.megaMedleyList),
-		container.NewTabItem("Team Clash", fc.teamClashList),
-	)
-	disciplineTabs.SetTabLocation(container.TabLocationTop)
+	fc.rebuildDisciplineTabs()
 
 	// Main layout – left panel wrapped in scroll so it is reachable on small screens
-	mainLayout := container.NewHSplit(container.NewScroll(leftPanel), disciplineTabs)
+	mainLayout := container.NewHSplit(container.NewScroll(leftPanel), fc.disciplineTabs)
 	mainLayout.SetOffset(0.20)
 	return mainLayout
 }
@@ -417,24 +420,86 @@ func (fc *FinishContest) onStatusFilterChanged(selected string) {
 
 // Data operations
 func (fc *FinishContest) loadData() {
+	fc.session.attach(func(s *data.Session) {
+		fc.participantMgr = s.Participants()
+		fc.teamMgr = s.Teams()
+		fc.resultMgr = s.Results()
+		fc.loadFiles()
+	})
+}
+
+// loadFiles (re)loads the shared managers from the configured files.
+func (fc *FinishContest) loadFiles() {
+	// Load the contest's disciplines and make a tab for each
+	disciplines, err := data.LoadDisciplines(data.DisciplinePathFor(config.Settings.ParticipantFile))
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error loading disciplines, using the built-in ones: %w", err), fc.window)
+		disciplines = data.DefaultDisciplineRegistry()
+	}
+	fc.disciplines = disciplines
+	showIgnoredSetupEdits(fc.window)
+	fc.rules = data.RulesFor(config.Settings.FolderPathContestNameAndDate)
+
 	// Load participants
 	if config.Settings.ParticipantFile != "" && utils.DoesFileExist(config.Settings.ParticipantFile) {
 		if err := fc.participantMgr.LoadParticipants(config.Settings.ParticipantFile); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading participants: %w", err), fc.window)
+			showLoadError(fmt.Errorf("error loading participants: %w", err), fc.window, fc.loadData)
 		} else {
 			fc.participants = fc.participantMgr.GetParticipants()
 		}
 	}
 
+	// Load the categories with their diploma eligibility
+	fc.configuredCategories = nil
+	if config.Settings.ParticipantFile != "" {
+		categories, err := data.LoadCategories(data.CategoryPathFor(config.Settings.ParticipantFile))
+		if err != nil {
+			dialog.ShowError(err, fc.window)
+		}
+		fc.configuredCategories = categories
+	}
+	fc.updateCategories()
+
+	// Load teams for the rosters of the team disciplines
+	if teamFile := data.TeamPathFor(config.Settings.ParticipantFile); config.Settings.ParticipantFile != "" && utils.DoesFileExist(teamFile) {
+		if err := fc.teamMgr.LoadTeams(teamFile); err != nil {
+			showLoadError(fmt.Errorf("error loading teams: %w", err), fc.window, fc.loadData)
+		} else {
+			fc.teams = fc.teamMgr.GetTeams()
+		}
+	}
+
+	// Load the brackets, then make the tabs, which show them with the team
+	// names
+	fc.brackets = nil
+	if config.Settings.ParticipantFile != "" {
+		brackets, err := data.LoadBrackets(data.BracketPathFor(config.Settings.ParticipantFile))
+		if err != nil {
+			dialog.ShowError(err, fc.window)
+		}
+		fc.brackets = brackets
+	}
+	fc.rebuildDisciplineTabs()
+
 	// Load results
 	if config.Settings.ResultFile != "" && utils.DoesFileExist(config.Settings.ResultFile) {
 		if err := fc.resultMgr.LoadResults(config.Settings.ResultFile); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading results: %w", err), fc.window)
+			showLoadError(fmt.Errorf("error loading results: %w", err), fc.window, fc.loadData)
 		} else {
-			fc.allResults = fc.resultMgr.GetResults()
+			fc.allResults = fc.resultMgr.GetActiveResults()
 		}
 	}
 
+	// Records of the other contests, to flag the ones this contest broke
+	fc.otherRecords = data.NewRecordBook()
+	if config.Settings.FolderPath != "" {
+		records, err := data.LoadRecordBook(config.Settings.FolderPath, config.Settings.FolderPathContestNameAndDate)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("some contests are left out of the records: %w", err), fc.window)
+		}
+		fc.otherRecords = records
+	}
+
 	fc.updateSummary()
 	fc.applyFilters()
 	fc.populateDisciplineResults()
@@ -443,6 +508,25 @@ func (fc *FinishContest) loadData() {
 	fc.sortFilter.SetSelected("🏆 Fastest First")
 }
 
+// onDataChanged redraws the results after another window or program changed
+// the contest data.
+func (fc *FinishContest) onDataChanged(c data.Change) {
+	if c.Participants {
+		fc.participants = fc.participantMgr.GetParticipants()
+		fc.updateCategories()
+	}
+	if c.Teams {
+		fc.teams = fc.teamMgr.GetTeams()
+		fc.rebuildDisciplineTabs() // the brackets show the team names
+	}
+	if c.Results {
+		fc.allResults = fc.resultMgr.GetActiveResults()
+	}
+	fc.updateSummary()
+	fc.applyFilters()
+	fc.populateDisciplineResults()
+}
+
 func (fc *FinishContest) refreshData() {
 	fc.loadData()
 	dialog.ShowInformation("Data Refreshed", "Contest data has been reloaded from files", fc.window)
@@ -475,56 +559,121 @@ func (fc *FinishContest) applyFilters() {
 }
 
 func (fc *FinishContest) populateDisciplineResults() {
-	// Clear all discipline-specific results
-	fc.bottleResults = nil
-	fc.halfTankardResults = nil
-	fc.fullTankardResults = nil
-	fc.bierStaphetteResults = nil
-	fc.megaMedleyResults = nil
-	fc.teamClashResults = nil
-
-	// Distribute filtered results to discipline lists
+	fc.updateRecords()
+
+	// Distribute filtered results to disciplines
+	byDiscipline := make(map[string][]models.Result)
 	for _, result := range fc.filteredResults {
-		switch result.Discipline {
-		case models.DisciplineBottle:
-			fc.bottleResults = append(fc.bottleResults, result)
-		case models.DisciplineHalfTankard:
-			fc.halfTankardResults = append(fc.halfTankardResults, result)
-		case models.DisciplineFullTankard:
-			fc.fullTankardResults = append(fc.fullTankardResults, result)
-		case models.DisciplineBierStaphette:
-			fc.bierStaphetteResults = append(fc.bierStaphetteResults, result)
-		case models.DisciplineMegaMedley:
-			fc.megaMedleyResults = append(fc.megaMedleyResults, result)
-		case models.DisciplineTeamClash:
-			fc.teamClashResults = append(fc.teamClashResults, result)
-		}
-	}
-
-	// Sort each discipline's results by current mode
+		byDiscipline[result.Discipline] = append(byDiscipline[result.Discipline], result)
+	}
+
+	// Collapse each competitor's attempts to the one that counts, then sort
+	// by current mode
 	mode := fc.sortFilter.Selected
-	fc.bottleResults = sortByMode(fc.bottleResults, mode)
-	fc.halfTankardResults = sortByMode(fc.halfTankardResults, mode)
-	fc.fullTankardResults = sortByMode(fc.fullTankardResults, mode)
-	fc.bierStaphetteResults = sortByMode(fc.bierStaphetteResults, mode)
-	fc.megaMedleyResults = sortByMode(fc.megaMedleyResults, mode)
-	fc.teamClashResults = sortByMode(fc.teamClashResults, mode)
+	fc.disciplineStandings = make(map[string][]data.Standing)
+	for _, def := range fc.disciplines.Defs() {
+		fc.disciplineStandings[def.Name] = sortByMode(data.Standings(def, byDiscipline[def.Name]), mode, def)
+	}
+
+	// Every category ranks its own members from first place in the
+	// individual disciplines
+	fc.categoryStandings = make(map[string]map[string][]data.Standing)
+	for _, c := range fc.categories {
+		fc.categoryStandings[c.Name] = make(map[string][]data.Standing)
+		for _, def := range fc.disciplines.Defs() {
+			if def.Team {
+				continue
+			}
+			results := data.CategoryResults(byDiscipline[def.Name], fc.participants, c.Name)
+			fc.categoryStandings[c.Name][def.Name] = sortByMode(data.Standings(def, results), mode, def)
+		}
+	}
+
+	// Relay legs are ranked on their own, whatever the scoreboard mode
+	fc.fastestLegs = data.FastestLegs(fc.allResults, fc.disciplines.Relays()...)
 
 	// Refresh all lists
-	fc.bottleList.Refresh()
-	fc.halfTankardList.Refresh()
-	fc.fullTankardList.Refresh()
-	fc.bierStaphetteList.Refresh()
-	fc.megaMedleyList.Refresh()
-	fc.teamClashList.Refresh()
+	fc.refreshDisciplineLists()
+	fc.fastestLegsList.Refresh()
+
+	// Championship points come from every result, whatever the scoreboard
+	// mode
+	fc.championship = data.NewChampionship(fc.disciplines, fc.participants, fc.teams, fc.allResults)
+	fc.overallList.Refresh()
+	fc.factionList.Refresh()
+}
+
+// updateCategories lists the configured categories and those the
+// participants are in as choices of the category select, keeping the
+// selected one if it is still there.
+func (fc *FinishContest) updateCategories() {
+	fc.categories = data.ContestCategories(fc.configuredCategories, fc.participants)
+	options := []string{allCategories}
+	selected := allCategories
+	for _, c := range fc.categories {
+		options = append(options, c.Name)
+		if c.Name == fc.categorySelect.Selected {
+			selected = c.Name
+		}
+	}
+	fc.categorySelect.SetOptions(options)
+	fc.categorySelect.SetSelected(selected)
+}
+
+// shownStandings returns the standings of def in the selected category.
+// Team disciplines are not split by category and always show every team.
+func (fc *FinishContest) shownStandings(def models.DisciplineDef) []data.Standing {
+	category := fc.categorySelect.Selected
+	if category == allCategories || category == "" || def.Team {
+		return fc.disciplineStandings[def.Name]
+	}
+	return fc.categoryStandings[category][def.Name]
+}
+
+// refreshDisciplineLists redraws the standings of every discipline tab.
+func (fc *FinishContest) refreshDisciplineLists() {
+	for _, list := range fc.disciplineLists {
+		list.Refresh()
+	}
+}
+
+// updateRecords adds the results of this contest to the records of the
+// other contests. An unofficial contest is left out of them.
+func (fc *FinishContest) updateRecords() {
+	fc.records = fc.otherRecords.Clone()
+	if !fc.rules.Counted {
+		return
+	}
+	fc.records.AddContest(data.SeriesContest{
+		Folder:       fc.contestFolder(),
+		Disciplines:  fc.disciplines,
+		Participants: fc.participants,
+		Teams:        fc.teams,
+		Results:      fc.allResults,
+	})
+}
+
+// contestFolder returns the folder name of the contest, as the records name
+// it.
+func (fc *FinishContest) contestFolder() string {
+	return filepath.Base(config.Settings.FolderPathContestNameAndDate)
+}
+
+// recordSummary flags the records a result broke, e.g. "NEW RECORD:
+// all-time record"; empty if it broke none.
+func (fc *FinishContest) recordSummary(r models.Result) string {
+	return data.RecordSummary(fc.records.BrokenBy(fc.contestFolder(), r.ID))
 }
 
-func sortByMode(results []models.Result, mode string) []models.Result {
-	sorted := make([]models.Result, len(results))
-	copy(sorted, results)
+// sortByMode orders the standings of the discipline def for a scoreboard
+// mode by the attempts that count. Fastest First keeps the ranked order;
+// slowest first goes by the discipline's ranking time too.
+func sortByMode(standings []data.Standing, mode string, def models.DisciplineDef) []data.Standing {
+	sorted := make([]data.Standing, len(standings))
+	copy(sorted, standings)
 
 	sort.SliceStable(sorted, func(i, j int) bool {
-		ri, rj := sorted[i], sorted[j]
+		ri, rj := sorted[i].Result, sorted[j].Result
 
 		switch mode {
 		case "🐢 Slowest First":
@@ -535,7 +684,7 @@ func sortByMode(results []models.Result, mode string) []models.Result {
 			if ri.Status != models.StatusPass && rj.Status == models.StatusPass {
 				return false
 			}
-			return utils.ParseTimeForComparison(ri.Time) > utils.ParseTimeForComparison(rj.Time)
+			return data.RankingTime(def, ri) > data.RankingTime(def, rj)
 
 		case "⏳ Most Penalty Time":
 			// Pass first, then most additional time first
@@ -576,19 +725,38 @@ func sortByMode(results []models.Result, mode string) []models.Result {
 			return ri.Name < rj.Name
 
 		default: // "🏆 Fastest First"
-			// Pass first, then fastest time first
-			if ri.Status == models.StatusPass && rj.Status != models.StatusPass {
-				return true
-			}
-			if ri.Status != models.StatusPass && rj.Status == models.StatusPass {
-				return false
-			}
-			return utils.ParseTimeForComparison(ri.Time) < utils.ParseTimeForComparison(rj.Time)
+			// data.Standings already ranks fastest first
+			return false
 		}
 	})
 	return sorted
 }
 
+// teamOf returns the team of the participant a result belongs to, or "" if
+// that participant is no longer in the list.
+func (fc *FinishContest) teamOf(r models.Result) string {
+	for _, p := range fc.participants {
+		if r.BelongsTo(p) {
+			return p.Team
+		}
+	}
+	return ""
+}
+
+// rosterOf returns the roster of the team a team result belongs to, in
+// running order. Results recorded for a participant fall back to teamOf.
+func (fc *FinishContest) rosterOf(r models.Result) string {
+	if !r.IsTeamResult() {
+		return fc.teamOf(r)
+	}
+	for _, t := range fc.teams {
+		if t.ID == r.TeamID {
+			return strings.Join(data.RosterNames(t, fc.participants), ", ")
+		}
+	}
+	return ""
+}
+
 func (fc *FinishContest) updateSummary() {
 	totalParticipants := len(fc.participants)
 	totalResults := len(fc.allResults)
@@ -655,7 +823,12 @@ func (fc *FinishContest) createTextReport() string {
 	report.WriteString("=" + strings.Repeat("=", 50) + "\n\n")
 
 	report.WriteString(fmt.Sprintf("Generated: %s\n", time.Now().Format("2006-01-02 15:04:05")))
-	report.WriteString(fmt.Sprintf("Contest Date: %s\n\n", fc.contestDateLabel.Text))
+	report.WriteString(fmt.Sprintf("Contest Date: %s\n", fc.contestDateLabel.Text))
+	if fc.rules.Official {
+		report.WriteString("Mode: Official\n\n")
+	} else {
+		report.WriteString("Mode: Unofficial (not counted towards records or season standings)\n\n")
+	}
 
 	report.WriteString("SUMMARY:\n")
 	report.WriteString(fmt.Sprintf("Total Participants: %s\n", fc.totalParticipantsLabel.Text))
@@ -666,31 +839,64 @@ func (fc *FinishContest) createTextReport() string {
 	// Leaderboard section
 	report.WriteString("LEADERBOARD:\n" + strings.Repeat("-", 60) + "\n")
 
-	disciplines := []struct {
-		Label   string
-		Results []models.Result
-	}{
-		{"Bottle", fc.bottleResults},
-		{"Half-Tankard", fc.halfTankardResults},
-		{"Full-Tankard", fc.fullTankardResults},
-		{"Bier Staphette", fc.bierStaphetteResults},
-		{"Mega Medley", fc.megaMedleyResults},
-		{"Team Clash", fc.teamClashResults},
+	for _, discipline := range fc.disciplines.Names() {
+		fc.writeLeaderboard(&report, discipline, fc.disciplineStandings[discipline])
 	}
 
-	for _, d := range disciplines {
-		if len(d.Results) == 0 {
-			continue
+	// A leaderboard per category in the individual disciplines
+	for _, c := range fc.categories {
+		report.WriteString(fmt.Sprintf("\n%s LEADERBOARD:\n", strings.ToUpper(c.Name)))
+		for _, discipline := range fc.disciplines.Names() {
+			fc.writeLeaderboard(&report, discipline, fc.categoryStandings[c.Name][discipline])
 		}
+	}
 
-		report.WriteString(fmt.Sprintf("\n%s:\n", d.Label))
-		rank := 1
-		for _, result := range d.Results {
-			if result.Status != models.StatusPass {
-				continue
+	// Overall standings
+	if len(fc.championship.Individuals) > 0 {
+		report.WriteString("\nOVERALL STANDINGS:\n")
+		for _, ps := range fc.championship.Individuals {
+			report.WriteString(fmt.Sprintf("%s. %s - %d points (%s)\n", ps.Place, ps.Name, ps.Points, fc.pointsBreakdown(ps)))
+		}
+	}
+	if len(fc.championship.Factions) > 0 {
+		report.WriteString("\nFACTION STANDINGS:\n")
+		for _, ps := range fc.championship.Factions {
+			report.WriteString(fmt.Sprintf("%s. %s - %d points (%s)\n", ps.Place, ps.Name, ps.Points, fc.pointsBreakdown(ps)))
+		}
+	}
+
+	// Records broken in this contest
+	var broken []data.Record
+	for _, r := range fc.allResults {
+		broken = append(broken, fc.records.BrokenBy(fc.contestFolder(), r.ID)...)
+	}
+	if len(broken) > 0 {
+		report.WriteString("\nNEW RECORDS:\n")
+		for _, r := range broken {
+			report.WriteString(fmt.Sprintf("%s %s: %s - %s (was %s)\n", r.Discipline, r.Label(), r.Name, r.Time, r.Previous))
+		}
+	}
+
+	// Knockout brackets
+	name := bracketTeamName(fc.teams)
+	for _, b := range fc.brackets {
+		report.WriteString(fmt.Sprintf("\n%s BRACKET (%s elimination):\n", strings.ToUpper(b.Discipline), b.Format))
+		for _, round := range data.BracketRounds(b) {
+			report.WriteString(fmt.Sprintf("%s:\n", round.Name))
+			for _, m := range round.Matches {
+				report.WriteString(fmt.Sprintf("  %s\n", bracketMatchLine(m, name)))
 			}
-			report.WriteString(fmt.Sprintf("%d. %s - %s\n", rank, result.Name, result.Time))
-			rank++
+		}
+		if champion := data.Champion(b); champion != "" {
+			report.WriteString(fmt.Sprintf("Champion: %s\n", name(champion)))
+		}
+	}
+
+	// Fastest relay legs
+	if len(fc.fastestLegs) > 0 {
+		report.WriteString("\nFASTEST RELAY LEGS:\n")
+		for i, l := range fc.fastestLegs {
+			report.WriteString(fmt.Sprintf("%d. %s (%s, leg %d) - %s\n", i+1, l.Name, l.Team, l.Leg, l.Time))
 		}
 	}
 
@@ -734,7 +940,90 @@ func (fc *FinishContest) createTextReport() string {
 	return report.String()
 }
 
+// writeLeaderboard writes the ranked standings of a discipline to the
+// report, with the attempts that do not count; nothing if no one is ranked.
+func (fc *FinishContest) writeLeaderboard(report *strings.Builder, discipline string, standings []data.Standing) {
+	if len(standings) == 0 {
+		return
+	}
+
+	report.WriteString(fmt.Sprintf("\n%s:\n", discipline))
+	for _, st := range standings {
+		if st.Rank == 0 {
+			continue
+		}
+		result := st.Result
+		report.WriteString(fmt.Sprintf("%s. %s - %s\n", st.Place(), result.Name, result.Time))
+		if roster := fc.rosterOf(result); result.IsTeamResult() && roster != "" {
+			report.WriteString(fmt.Sprintf("   %s\n", roster))
+		}
+		if len(result.Penalties) > 0 {
+			report.WriteString(fmt.Sprintf("   penalties: %s\n", data.PenaltySummary(result.Penalties)))
+		}
+		if rec := fc.recordSummary(result); rec != "" {
+			report.WriteString(fmt.Sprintf("   %s\n", rec))
+		}
+		if others := otherAttempts(st); others != "" {
+			report.WriteString(fmt.Sprintf("   other attempts: %s\n", others))
+		}
+	}
+}
+
+// exportResults asks for the export format: a spreadsheet with one sheet per
+// discipline, or the JSON dump of all results
 func (fc *FinishContest) exportResults() {
+	if config.Settings.FolderPathContestNameAndDate == "" {
+		dialog.ShowError(fmt.Errorf("no contest directory configured"), fc.window)
+		return
+	}
+
+	formats := []string{"Excel (.xlsx)", "OpenDocument (.ods)", "JSON"}
+	formatGroup := widget.NewRadioGroup(formats, nil)
+	formatGroup.SetSelected(formats[0])
+	dialog.ShowCustomConfirm("Export Results", "Export", "Cancel", formatGroup, func(ok bool) {
+		if !ok {
+			return
+		}
+		switch formatGroup.Selected {
+		case formats[0]:
+			fc.exportSpreadsheet(export.FormatXLSX)
+		case formats[1]:
+			fc.exportSpreadsheet(export.FormatODS)
+		default:
+			fc.exportJSON()
+		}
+	}, fc.window)
+}
+
+// exportSpreadsheet writes the results to the results directory as an XLSX
+// or ODS file with a summary sheet and one sheet per discipline
+func (fc *FinishContest) exportSpreadsheet(format string) {
+	name, date := export.ContestFromFolder(config.Settings.FolderPathContestNameAndDate)
+	if date == "" {
+		date = fc.contestDateLabel.Text
+	}
+	wb := export.ContestWorkbook(export.Contest{
+		Name:         name,
+		Date:         date,
+		Participants: fc.participants,
+		Teams:        fc.teams,
+		Results:      fc.allResults,
+		Disciplines:  fc.disciplines,
+	})
+
+	timestamp := time.Now().Format("20060102_150405")
+	fullPath := filepath.Join(config.GetResultsPath(), fmt.Sprintf("contest_results_%s.%s", timestamp, format))
+	if err := wb.WriteFile(fullPath); err != nil {
+		dialog.ShowError(fmt.Errorf("error exporting results: %w", err), fc.window)
+		return
+	}
+	dialog.ShowInformation("Export Complete",
+		fmt.Sprintf("Results exported to: %s", fullPath), fc.window)
+}
+
+// exportJSON writes the summary and all results to the results directory as
+// a JSON list
+func (fc *FinishContest) exportJSON() {
 	// Save to results directory
 	if config.Settings.FolderPathContestNameAndDate != "" {
 		resultsPath := config.GetResultsPath()
@@ -784,27 +1073,15 @@ func (fc *FinishContest) generateDiplomas() {
 	// Generate diploma data for winners
 	diplomaData := make([]map[string]string, 0)
 
-	disciplines := []struct {
-		Label   string
-		Results []models.Result
-	}{
-		{"Bottle", fc.bottleResults},
-		{"Half-Tankard", fc.halfTankardResults},
-		{"Full-Tankard", fc.fullTankardResults},
-		{"Bier Staphette", fc.bierStaphetteResults},
-		{"Mega Medley", fc.megaMedleyResults},
-		{"Team Clash", fc.teamClashResults},
-	}
-
-	for _, d := range disciplines {
-		rank := 1
-		for _, result := range d.Results {
-			if result.Status != models.StatusPass {
+	// One diploma per competitor: their best attempt decides the place. The
+	// overall top three of every discipline get one, and so do the top three
+	// of every category eligible for diplomas.
+	addDiplomas := func(category, discipline string, standings []data.Standing) {
+		for _, st := range standings {
+			rank, result := st.Rank, st.Result
+			if rank == 0 || rank > 3 {
 				continue
 			}
-			if rank > 3 {
-				break
-			}
 
 			place := ""
 			switch rank {
@@ -815,6 +1092,9 @@ func (fc *FinishContest) generateDiplomas() {
 			case 3:
 				place = "3rd Place"
 			}
+			if st.Tied {
+				place = "Shared " + place
+			}
 
 			// We need Team info but Result struct doesn't have it directly?
 			// Checking models.Result struct in types.go, it only had Name, Discipline, Time, BaseTime, Status, Comment.
@@ -848,25 +1128,36 @@ func (fc *FinishContest) generateDiplomas() {
 			// So I need to fetch Team for the result.
 			// I have fc.participants map or slice?
 			// fc.participants is []models.Participant.
-			// I can lookup team by name.
+			// I can lookup team by participant.
 
-			team := ""
-			for _, p := range fc.participants {
-				if p.Name == result.Name {
-					team = p.Team
-					break
-				}
+			team := fc.teamOf(result)
+			if result.IsTeamResult() {
+				team = result.Name
 			}
 
 			diplomaData = append(diplomaData, map[string]string{
 				"name":       result.Name,
 				"team":       team,
-				"discipline": d.Label,
+				"roster":     fc.rosterOf(result),
+				"discipline": discipline,
+				"category":   category,
 				"place":      place,
+				"rank":       st.Place(),
 				"time":       result.Time,
 				"date":       fc.contestDateLabel.Text,
+				"record":     fc.recordSummary(result),
 			})
-			rank++
+		}
+	}
+	for _, discipline := range fc.disciplines.Names() {
+		addDiplomas("", discipline, fc.disciplineStandings[discipline])
+	}
+	for _, c := range fc.categories {
+		if !c.Diplomas {
+			continue
+		}
+		for _, discipline := range fc.disciplines.Names() {
+			addDiplomas(c.Name, discipline, fc.categoryStandings[c.Name][discipline])
 		}
 	}
 
@@ -888,6 +1179,309 @@ func (fc *FinishContest) generateDiplomas() {
 	}
 }
 
+// showAuditTrail lists every journal entry and reconstructs the results as they
+// stood after the selected entry, e.g. when a protest is raised.
+func (fc *FinishContest) showAuditTrail() {
+	entries, err := fc.resultMgr.JournalEntries()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error reading result journal: %w", err), fc.window)
+		return
+	}
+	if len(entries) == 0 {
+		dialog.ShowInformation("Audit Trail", "The result journal is empty", fc.window)
+		return
+	}
+
+	stateView := widget.NewMultiLineEntry()
+	stateView.Wrapping = fyne.TextWrapOff
+	stateView.SetPlaceHolder("Select a journal entry to see the results as they stood after it")
+
+	entryList := widget.NewList(
+		func() int { return len(entries) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Journal entry")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= 0 && id < len(entries) {
+				e := entries[id]
+				line := fmt.Sprintf("#%d  %s  %s/%s  %s  %s %s",
+					e.Seq, e.Timestamp.Format("15:04:05"), e.Station, e.Operator,
+					e.Action, e.Name, e.Discipline)
+				if e.Reason != "" {
+					line += "  (" + e.Reason + ")"
+				}
+				item.(*widget.Label).SetText(line)
+			}
+		},
+	)
+	entryList.OnSelected = func(id widget.ListItemID) {
+		results, err := data.ReplayResults(entries[:id+1])
+		if err != nil {
+			stateView.SetText(err.Error())
+			return
+		}
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("Results after entry #%d (%s):\n\n",
+			entries[id].Seq, entries[id].Timestamp.Format("2006-01-02 15:04:05")))
+		for _, r := range results {
+			line := fmt.Sprintf("%s - %s - %s - %s", r.Discipline, r.Name, r.Time, r.Status)
+			if r.Voided {
+				line += " [VOID]"
+			}
+			b.WriteString(line + "\n")
+		}
+		stateView.SetText(b.String())
+	}
+
+	auditWindow := fc.app.NewWindow("Audit Trail - Result Journal")
+	split := container.NewHSplit(entryList, container.NewScroll(stateView))
+	split.SetOffset(0.55)
+	auditWindow.SetContent(split)
+	auditWindow.Resize(fyne.NewSize(1100, 600))
+	auditWindow.Show()
+}
+
+// showResultEditor lets the operator amend or void any individual result.
+// Every change needs a reason; the original values stay on the result and the
+// change is written to the result journal.
+func (fc *FinishContest) showResultEditor() {
+	results := fc.resultMgr.GetResults()
+	if len(results) == 0 {
+		dialog.ShowInformation("Edit Results", "There are no results to edit", fc.window)
+		return
+	}
+
+	editorWindow := fc.app.NewWindow("Edit Results")
+	var selected *models.Result
+
+	selectedLabel := widget.NewLabel("Select a result to edit")
+	selectedLabel.Wrapping = fyne.TextWrapWord
+	originalLabel := widget.NewLabel("")
+	originalLabel.Wrapping = fyne.TextWrapWord
+	baseTimeEntry := widget.NewEntry()
+	baseTimeEntry.SetPlaceHolder("HH:MM:SS.mmmm or seconds")
+	additionalTimeEntry := widget.NewEntry()
+	additionalTimeEntry.SetPlaceHolder("Penalty time besides the penalties below (optional)")
+
+	// The penalties of the selected result, given from its discipline's
+	// catalog; their times are added to the extra time on amending
+	var penalties []models.Penalty
+	penaltyLabel := widget.NewLabel("")
+	penaltyLabel.Wrapping = fyne.TextWrapWord
+	penaltyButtons := container.NewGridWithColumns(3)
+	showPenalties := func() {
+		if selected == nil {
+			penaltyLabel.SetText("")
+			return
+		}
+		text := data.PenaltySummary(penalties)
+		if text == "" {
+			text = "No penalties"
+		}
+		def, _ := fc.disciplines.Get(selected.Discipline)
+		if item, ok := data.EscalatedPenalty(def, penalties); ok {
+			text += fmt.Sprintf("  –  disqualifies (%s)", item.Name)
+		}
+		penaltyLabel.SetText(text)
+	}
+	// setPenaltyButtons offers the catalog of the selected result's discipline
+	setPenaltyButtons := func() {
+		penaltyButtons.Objects = nil
+		def, _ := fc.disciplines.Get(selected.Discipline)
+		for _, item := range def.Penalty.Catalog {
+			item := item
+			penaltyButtons.Add(widget.NewButton(penaltyButtonLabel(item), func() {
+				penalties, _ = data.GivePenalty(item, penalties, "")
+				showPenalties()
+			}))
+		}
+		penaltyButtons.Add(widget.NewButton("Remove Last", func() {
+			if len(penalties) > 0 {
+				penalties = penalties[:len(penalties)-1]
+				showPenalties()
+			}
+		}))
+		penaltyButtons.Add(widget.NewButton("Clear Penalties", func() {
+			penalties = nil
+			showPenalties()
+		}))
+		penaltyButtons.Refresh()
+	}
+
+	statusSelect := widget.NewSelect([]string{models.StatusPass, models.StatusDisqualified, models.StatusFail}, nil)
+	commentEntry := widget.NewEntry()
+	reasonEntry := widget.NewEntry()
+	reasonEntry.SetPlaceHolder("Required: why is this result being changed?")
+
+	var resultList *widget.List
+	resultList = widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Result")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= 0 && id < len(results) {
+				r := results[id]
+				line := fmt.Sprintf("%s - %s - %s - %s", r.Discipline, r.Name, r.Time, r.Status)
+				if r.Voided {
+					line += "  [VOID]"
+				} else if r.IsCorrected() {
+					line += "  [amended]"
+				}
+				item.(*widget.Label).SetText(line)
+			}
+		},
+	)
+
+	// reload refreshes the editor and the main window after a change
+	reload := func() {
+		results = fc.resultMgr.GetResults()
+		selected = nil
+		selectedLabel.SetText("Select a result to edit")
+		originalLabel.SetText("")
+		reasonEntry.SetText("")
+		penalties = nil
+		penaltyButtons.Objects = nil
+		penaltyButtons.Refresh()
+		showPenalties()
+		resultList.UnselectAll()
+		resultList.Refresh()
+		fc.allResults = fc.resultMgr.GetActiveResults()
+		fc.updateSummary()
+		fc.applyFilters()
+	}
+
+	resultList.OnSelected = func(id widget.ListItemID) {
+		r := results[id]
+		selected = &r
+		selectedLabel.SetText(fmt.Sprintf("%s – %s (ID %s)", r.Name, r.Discipline, r.ID))
+		baseTimeEntry.SetText(r.BaseTime)
+		additionalTimeEntry.SetText(data.ExtraTime(r))
+		penalties = append([]models.Penalty(nil), r.Penalties...)
+		setPenaltyButtons()
+		showPenalties()
+		statusSelect.SetSelected(r.Status)
+		commentEntry.SetText(r.Comment)
+		reasonEntry.SetText("")
+		if r.IsCorrected() {
+			state := "Amended"
+			if r.Voided {
+				state = "Voided"
+			}
+			text := fmt.Sprintf("%s: %s\nOriginally recorded: %s (base %s, additional %s) – %s %s",
+				state, r.EditReason, r.OriginalTime, r.OriginalBaseTime, r.OriginalAdditionalTime,
+				r.OriginalStatus, r.OriginalComment)
+			if len(r.OriginalPenalties) > 0 {
+				text += "\nPenalties: " + data.PenaltySummary(r.OriginalPenalties)
+			}
+			originalLabel.SetText(text)
+		} else {
+			originalLabel.SetText("")
+		}
+	}
+
+	amendBtn := widget.NewButton("Amend Result", func() {
+		if selected == nil {
+			dialog.ShowError(fmt.Errorf("select a result first"), editorWindow)
+			return
+		}
+		baseTime := strings.TrimSpace(baseTimeEntry.Text)
+		additionalTime := strings.TrimSpace(additionalTimeEntry.Text)
+		if statusSelect.Selected != models.StatusDisqualified {
+			if utils.ParseAndPadTimeString(baseTime) == config.NoKey {
+				dialog.ShowError(fmt.Errorf("invalid base time format: %s", baseTime), editorWindow)
+				return
+			}
+			if additionalTime != "" && utils.ParseAndPadTimeString(additionalTime) == config.NoKey {
+				dialog.ShowError(fmt.Errorf("invalid additional time format: %s", additionalTime), editorWindow)
+				return
+			}
+		}
+		updated := models.Result{
+			BaseTime:       baseTime,
+			AdditionalTime: additionalTime,
+			Status:         statusSelect.Selected,
+			Comment:        strings.TrimSpace(commentEntry.Text),
+			Penalties:      penalties,
+		}
+		// The amended result is held to the discipline's limits as when it
+		// was recorded
+		id := selected.ID
+		def, _ := fc.disciplines.Get(selected.Discipline)
+		if err := fc.resultMgr.AmendResult(id, updated, reasonEntry.Text, def); err != nil {
+			dialog.ShowError(err, editorWindow)
+			return
+		}
+		if err := fc.resultMgr.SaveResults(); err != nil {
+			dialog.ShowError(fmt.Errorf("error saving results: %w", err), editorWindow)
+		}
+		reload()
+		if amended, ok := fc.resultMgr.GetResultByID(id); ok && amended.Status != updated.Status {
+			dialog.ShowInformation("Result Amended",
+				fmt.Sprintf("The discipline rules made the result %s: %s", amended.Status, amended.Comment), editorWindow)
+		}
+	})
+	amendBtn.Importance = widget.HighImportance
+
+	voidBtn := widget.NewButton("Void Result", func() {
+		if selected == nil {
+			dialog.ShowError(fmt.Errorf("select a result first"), editorWindow)
+			return
+		}
+		target := *selected
+		reason := reasonEntry.Text
+		if utils.IsNullString(reason) {
+			dialog.ShowError(fmt.Errorf("a reason is required to void a result"), editorWindow)
+			return
+		}
+		message := fmt.Sprintf("Void %s's %s attempt (%s)?\nThe try will be given back.", target.Name, target.Discipline, target.Time)
+		if target.IsTeamResult() {
+			message = fmt.Sprintf("Void %s's %s attempt (%s)?", target.Name, target.Discipline, target.Time)
+		}
+		dialog.ShowConfirm("Void Result", message,
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				if err := fc.resultMgr.VoidResult(target.ID, reason); err != nil {
+					dialog.ShowError(err, editorWindow)
+					return
+				}
+				if err := fc.resultMgr.SaveResults(); err != nil {
+					dialog.ShowError(fmt.Errorf("error saving results: %w", err), editorWindow)
+				}
+				// The voided attempt no longer counts against the participant's
+				// tries, which are derived from the results
+				reload()
+			}, editorWindow)
+	})
+	voidBtn.Importance = widget.DangerImportance
+
+	form := container.NewVBox(
+		selectedLabel,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Base Time", baseTimeEntry),
+			widget.NewFormItem("Extra Time", additionalTimeEntry),
+			widget.NewFormItem("Status", statusSelect),
+			widget.NewFormItem("Comment", commentEntry),
+			widget.NewFormItem("Reason", reasonEntry),
+		),
+		widget.NewLabel("Penalties:"),
+		penaltyButtons,
+		penaltyLabel,
+		container.NewHBox(amendBtn, voidBtn),
+		widget.NewSeparator(),
+		originalLabel,
+	)
+
+	split := container.NewHSplit(resultList, container.NewScroll(form))
+	split.SetOffset(0.5)
+	editorWindow.SetContent(split)
+	editorWindow.Resize(fyne.NewSize(1000, 560))
+	editorWindow.Show()
+}
+
 // saveFinalResults saves the final results to file
 func (fc *FinishContest) saveFinalResults() {
 	if err := fc.resultMgr.SaveResults(); err != nil {
@@ -895,7 +1489,19 @@ func (fc *FinishContest) saveFinalResults() {
 		return
 	}
 
-	dialog.ShowInformation("Results Saved", "Final contest results have been saved", fc.window)
+	// The overall standings go to the Leaderboard and Faction files
+	leaderBoardPath, factionPath := config.GetLeaderBoardPath(), config.GetFactionPath()
+	if leaderBoardPath == "" || factionPath == "" {
+		dialog.ShowInformation("Results Saved", "Final contest results have been saved", fc.window)
+		return
+	}
+	if err := data.SaveChampionship(leaderBoardPath, factionPath, fc.championship); err != nil {
+		dialog.ShowError(err, fc.window)
+		return
+	}
+	dialog.ShowInformation("Results Saved",
+		fmt.Sprintf("Final contest results have been saved\n\nOverall standings: %s\nFaction standings: %s", leaderBoardPath, factionPath),
+		fc.window)
 }
 
 // Show displays the finish contest window
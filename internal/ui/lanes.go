@@ -0,0 +1,377 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/data"
+	"chugware/internal/models"
+)
+
+// laneStopKeys stop lanes 1 to data.MaxLanes.
+var laneStopKeys = []fyne.KeyName{fyne.Key1, fyne.Key2, fyne.Key3, fyne.Key4}
+
+// LaneHeat runs a heat of two to four lanes side by side in the discipline
+// selected in the Chug Manager it was opened from. All lanes start together;
+// each lane has its own stop key and result form, and the results of every
+// lane are saved together as one step of the Chug Manager's undo history.
+type LaneHeat struct {
+	cm     *ChugManager
+	window fyne.Window
+
+	discipline string
+	heat       *data.Heat
+	stopTicker chan struct{} // stops the display updates
+
+	// UI Components
+	lanesSelect *widget.Select
+	startBtn    *widget.Button
+	resetBtn    *widget.Button
+	saveBtn     *widget.Button
+	lanesBox    *fyne.Container
+	forms       []*laneForm
+
+	// Competitors that can be put in a lane, from the Chug Manager's list
+	participants []models.Participant
+	teams        []models.Team
+}
+
+// laneForm is the timer and result form of one lane.
+type laneForm struct {
+	competitorSelect    *widget.Select
+	timer               *canvas.Text
+	stopBtn             *widget.Button
+	baseTimeEntry       *widget.Entry
+	additionalTimeEntry *widget.Entry
+	commentEntry        *widget.Entry
+	statusSelect        *widget.Select
+}
+
+// newLaneHeat creates the heat window for the discipline selected in cm.
+func newLaneHeat(cm *ChugManager) *LaneHeat {
+	lh := &LaneHeat{
+		cm:         cm,
+		discipline: cm.disciplineSelect.Selected,
+	}
+	lh.window = cm.app.NewWindow(fmt.Sprintf("Multi-Lane Heat - %s", lh.discipline))
+	lh.window.SetOnClosed(lh.stopTimers)
+
+	lh.setupUI()
+	lh.setLanes(2)
+	return lh
+}
+
+// setupUI initializes the heat UI
+func (lh *LaneHeat) setupUI() {
+	lh.window.Resize(fyne.NewSize(1200, 600))
+	lh.window.CenterOnScreen()
+
+	var counts []string
+	for n := 2; n <= data.MaxLanes; n++ {
+		counts = append(counts, strconv.Itoa(n))
+	}
+	lh.lanesSelect = widget.NewSelect(counts, func(s string) {
+		n, _ := strconv.Atoi(s)
+		if lh.heat != nil && n != len(lh.heat.Lanes) {
+			lh.setLanes(n)
+		}
+	})
+	lh.startBtn = widget.NewButton("Start All (S)", lh.startAll)
+	lh.resetBtn = widget.NewButton("Reset", lh.reset)
+	lh.saveBtn = widget.NewButton("Save All Lanes", lh.saveAll)
+	lh.lanesBox = container.NewGridWithColumns(2)
+
+	top := container.NewHBox(
+		widget.NewLabelWithStyle(lh.discipline, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Lanes:"),
+		lh.lanesSelect,
+		widget.NewSeparator(),
+		lh.startBtn,
+		lh.resetBtn,
+		lh.saveBtn,
+	)
+	lh.window.SetContent(container.NewBorder(top, nil, nil, nil, container.NewScroll(lh.lanesBox)))
+
+	// Keyboard shortcuts: S starts every lane, 1-4 stop a lane. Ignored when
+	// a text entry field has focus so normal typing is unaffected.
+	lh.window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
+		if _, focused := lh.window.Canvas().Focused().(*widget.Entry); focused {
+			return
+		}
+		if key.Name == fyne.KeyS && !lh.startBtn.Disabled() {
+			lh.startAll()
+			return
+		}
+		for i, k := range laneStopKeys {
+			if key.Name == k && i < len(lh.forms) && !lh.forms[i].stopBtn.Disabled() {
+				lh.stopLane(i)
+			}
+		}
+	})
+}
+
+// setLanes makes a new heat of n empty lanes.
+func (lh *LaneHeat) setLanes(n int) {
+	heat, err := data.NewHeat(n)
+	if err != nil {
+		dialog.ShowError(err, lh.window)
+		return
+	}
+	lh.stopTimers()
+	lh.heat = heat
+	lh.loadCompetitors()
+
+	lh.forms = nil
+	lh.lanesBox.Objects = nil
+	for i := 0; i < n; i++ {
+		form := lh.newLaneForm(i)
+		lh.forms = append(lh.forms, form)
+		lh.lanesBox.Add(widget.NewCard(fmt.Sprintf("Lane %d", i+1), "",
+			container.NewVBox(
+				form.competitorSelect,
+				form.timer,
+				form.stopBtn,
+				widget.NewFormItem("Base Time", form.baseTimeEntry).Widget,
+				widget.NewFormItem("Additional Time", form.additionalTimeEntry).Widget,
+				widget.NewFormItem("Comment", form.commentEntry).Widget,
+				widget.NewFormItem("Status", form.statusSelect).Widget,
+			),
+		))
+	}
+	lh.lanesBox.Refresh()
+	lh.lanesSelect.SetSelected(strconv.Itoa(n))
+	lh.updateButtons()
+}
+
+// newLaneForm creates the form of lane i.
+func (lh *LaneHeat) newLaneForm(i int) *laneForm {
+	form := &laneForm{
+		timer:               canvas.NewText("00:00:00.0000", theme.ForegroundColor()),
+		baseTimeEntry:       widget.NewEntry(),
+		additionalTimeEntry: widget.NewEntry(),
+		commentEntry:        widget.NewEntry(),
+		statusSelect:        widget.NewSelect([]string{models.StatusPass, models.StatusDisqualified}, nil),
+	}
+	form.timer.TextSize = theme.TextSize() * 3
+	form.stopBtn = widget.NewButton(fmt.Sprintf("Stop (%d)", i+1), func() { lh.stopLane(i) })
+	form.stopBtn.Disable()
+	form.baseTimeEntry.SetPlaceHolder("Filled in on Stop")
+	form.additionalTimeEntry.SetPlaceHolder("Penalty time")
+	form.statusSelect.SetSelected(models.StatusPass)
+	form.competitorSelect = widget.NewSelect(lh.competitorNames(), func(string) { lh.onCompetitorSelected(i) })
+	form.competitorSelect.PlaceHolder = "Empty lane"
+	return form
+}
+
+// loadCompetitors takes the participants or teams that still have a try in
+// the discipline from the Chug Manager.
+func (lh *LaneHeat) loadCompetitors() {
+	lh.participants, lh.teams = nil, nil
+	if lh.cm.disciplines.IsTeam(lh.discipline) {
+		lh.teams = append(lh.teams, lh.cm.availableTeams...)
+	} else {
+		lh.participants = append(lh.participants, lh.cm.availableParticipants...)
+	}
+}
+
+// competitorNames returns the names offered for a lane.
+func (lh *LaneHeat) competitorNames() []string {
+	var names []string
+	for _, t := range lh.teams {
+		names = append(names, t.Name)
+	}
+	for _, p := range lh.participants {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// onCompetitorSelected puts the chosen participant or team in lane i. No one
+// runs in two lanes at once.
+func (lh *LaneHeat) onCompetitorSelected(i int) {
+	lane := data.Lane{}
+	if j := lh.forms[i].competitorSelect.SelectedIndex(); j >= 0 {
+		if lh.teams != nil {
+			t := lh.teams[j]
+			lane.Team = &t
+		} else {
+			p := lh.participants[j]
+			lane.Participant = &p
+		}
+	}
+	for k, other := range lh.heat.Lanes {
+		if k != i && lane.Occupied() && other.Occupied() && lanesShareCompetitor(lane, other) {
+			dialog.ShowError(fmt.Errorf("%s is already in lane %d", lane.Name(), k+1), lh.window)
+			lh.forms[i].competitorSelect.ClearSelected()
+			return
+		}
+	}
+	lh.heat.Lanes[i].Participant, lh.heat.Lanes[i].Team = lane.Participant, lane.Team
+	lh.updateButtons()
+}
+
+// lanesShareCompetitor reports whether a and b hold the same participant or
+// team.
+func lanesShareCompetitor(a, b data.Lane) bool {
+	if a.Team != nil && b.Team != nil {
+		return a.Team.ID == b.Team.ID
+	}
+	if a.Participant != nil && b.Participant != nil {
+		return a.Participant.ID == b.Participant.ID
+	}
+	return false
+}
+
+// startAll starts every occupied lane at the same moment.
+func (lh *LaneHeat) startAll() {
+	if err := lh.heat.Start(time.Now()); err != nil {
+		dialog.ShowError(err, lh.window)
+		return
+	}
+	for i, form := range lh.forms {
+		if lh.heat.Lanes[i].Timer.Running {
+			form.stopBtn.Enable()
+			form.baseTimeEntry.SetText("")
+		}
+	}
+	lh.stopTicker = make(chan struct{})
+	go lh.runTimers(time.NewTicker(10*time.Millisecond), lh.stopTicker)
+	lh.updateButtons()
+}
+
+// runTimers updates the displays of the running lanes until stopped.
+func (lh *LaneHeat) runTimers(ticker *time.Ticker, stop chan struct{}) {
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			lh.updateTimerDisplays()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// updateTimerDisplays shows the time of every lane.
+func (lh *LaneHeat) updateTimerDisplays() {
+	now := time.Now()
+	for i, form := range lh.forms {
+		form.timer.Text = data.FormatElapsed(lh.heat.Lanes[i].Elapsed(now))
+		form.timer.Refresh()
+	}
+}
+
+// stopLane stops lane i and fills in its base time. The display stops
+// updating once every lane has stopped.
+func (lh *LaneHeat) stopLane(i int) {
+	d, err := lh.heat.Stop(i, time.Now())
+	if err != nil {
+		dialog.ShowError(err, lh.window)
+		return
+	}
+	form := lh.forms[i]
+	form.stopBtn.Disable()
+	form.baseTimeEntry.SetText(data.FormatElapsed(d))
+	if !lh.heat.Running() {
+		lh.stopTimers()
+		lh.updateTimerDisplays()
+	}
+	lh.updateButtons()
+}
+
+// stopTimers stops updating the displays.
+func (lh *LaneHeat) stopTimers() {
+	if lh.stopTicker != nil {
+		close(lh.stopTicker)
+		lh.stopTicker = nil
+	}
+}
+
+// reset stops every lane and clears the times, keeping who is in the lanes.
+func (lh *LaneHeat) reset() {
+	lh.stopTimers()
+	lh.heat.Reset()
+	for _, form := range lh.forms {
+		form.stopBtn.Disable()
+		form.baseTimeEntry.SetText("")
+		form.additionalTimeEntry.SetText("")
+		form.commentEntry.SetText("")
+		form.statusSelect.SetSelected(models.StatusPass)
+	}
+	lh.updateTimerDisplays()
+	lh.updateButtons()
+}
+
+// updateButtons allows changes to the heat only while no lane is running.
+func (lh *LaneHeat) updateButtons() {
+	running := lh.heat.Running()
+	occupied := false
+	for _, l := range lh.heat.Lanes {
+		occupied = occupied || l.Occupied()
+	}
+	for _, w := range []fyne.Disableable{lh.lanesSelect, lh.saveBtn, lh.resetBtn} {
+		if running {
+			w.Disable()
+		} else {
+			w.Enable()
+		}
+	}
+	if running || !occupied {
+		lh.startBtn.Disable()
+	} else {
+		lh.startBtn.Enable()
+	}
+	for _, form := range lh.forms {
+		if running {
+			form.competitorSelect.Disable()
+		} else {
+			form.competitorSelect.Enable()
+		}
+	}
+}
+
+// saveAll saves the results of every occupied lane in one step, then empties
+// the lanes for the next heat.
+func (lh *LaneHeat) saveAll() {
+	if lh.heat.Running() {
+		dialog.ShowError(fmt.Errorf("stop every lane before saving"), lh.window)
+		return
+	}
+	var results []models.Result
+	for i, lane := range lh.heat.Lanes {
+		if !lane.Occupied() {
+			continue
+		}
+		form := lh.forms[i]
+		r, err := lane.Result(lh.discipline, form.baseTimeEntry.Text, form.additionalTimeEntry.Text,
+			form.statusSelect.Selected, form.commentEntry.Text)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("lane %d: %w", i+1, err), lh.window)
+			return
+		}
+		results = append(results, r)
+	}
+	if len(results) == 0 {
+		dialog.ShowError(fmt.Errorf("no one is in the lanes"), lh.window)
+		return
+	}
+
+	if err := lh.cm.recordHeat(results); err != nil {
+		dialog.ShowError(err, lh.window)
+		return
+	}
+	lh.setLanes(len(lh.heat.Lanes))
+}
+
+// Show displays the heat window
+func (lh *LaneHeat) Show() {
+	lh.window.Show()
+}
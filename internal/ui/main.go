@@ -7,6 +7,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"chugware/internal/config"
+	"chugware/internal/data"
 	"chugware/internal/resources"
 )
 
@@ -18,9 +19,12 @@ type MainWindow struct {
 	// UI components
 	contestWizardBtn   *widget.Button
 	addParticipantsBtn *widget.Button
+	manageTeamsBtn     *widget.Button
 	chugManagerBtn     *widget.Button
 	configurationBtn   *widget.Button
 	finishContestBtn   *widget.Button
+	seasonSeriesBtn    *widget.Button
+	recordsBtn         *widget.Button
 	exitBtn            *widget.Button
 }
 
@@ -61,9 +65,12 @@ func (mw *MainWindow) setupUI() {
 func (mw *MainWindow) createMenuButtons() {
 	mw.contestWizardBtn = widget.NewButton("Contest Wizard", mw.openContestWizard)
 	mw.addParticipantsBtn = widget.NewButton("Add Participants", mw.openAddParticipants)
+	mw.manageTeamsBtn = widget.NewButton("Manage Teams", mw.openManageTeams)
 	mw.chugManagerBtn = widget.NewButton("Chug Manager", mw.openChugManager)
 	mw.configurationBtn = widget.NewButton("Configuration", mw.openConfiguration)
 	mw.finishContestBtn = widget.NewButton("Finish Contest", mw.openFinishContest)
+	mw.seasonSeriesBtn = widget.NewButton("Season Series", mw.openSeasonSeries)
+	mw.recordsBtn = widget.NewButton("Records", mw.openRecords)
 	mw.exitBtn = widget.NewButton("Exit", mw.exitApplication)
 }
 
@@ -82,9 +89,12 @@ func (mw *MainWindow) createMainMenu() *fyne.Container {
 	menuButtons := container.NewVBox(
 		mw.contestWizardBtn,
 		mw.addParticipantsBtn,
+		mw.manageTeamsBtn,
 		mw.chugManagerBtn,
 		mw.configurationBtn,
 		mw.finishContestBtn,
+		mw.seasonSeriesBtn,
+		mw.recordsBtn,
 		widget.NewSeparator(),
 		mw.exitBtn,
 	)
@@ -109,17 +119,23 @@ func (mw *MainWindow) createApplicationMenu() *fyne.MainMenu {
 	// File menu
 	contestWizardItem := fyne.NewMenuItem("Contest Wizard", mw.openContestWizard)
 	addParticipantsItem := fyne.NewMenuItem("Add Participants", mw.openAddParticipants)
+	manageTeamsItem := fyne.NewMenuItem("Manage Teams", mw.openManageTeams)
 	chugManagerItem := fyne.NewMenuItem("Chug Manager", mw.openChugManager)
 	configurationItem := fyne.NewMenuItem("Configuration", mw.openConfiguration)
 	finishContestItem := fyne.NewMenuItem("Finish Contest", mw.openFinishContest)
+	seasonSeriesItem := fyne.NewMenuItem("Season Series", mw.openSeasonSeries)
+	recordsItem := fyne.NewMenuItem("Records", mw.openRecords)
 	exitItem := fyne.NewMenuItem("Exit", mw.exitApplication)
 
 	fileMenu := fyne.NewMenu("Menu",
 		contestWizardItem,
 		addParticipantsItem,
+		manageTeamsItem,
 		chugManagerItem,
 		configurationItem,
 		finishContestItem,
+		seasonSeriesItem,
+		recordsItem,
 		fyne.NewMenuItemSeparator(),
 		exitItem,
 	)
@@ -149,6 +165,11 @@ func (mw *MainWindow) openAddParticipants() {
 	participantMgr.Show()
 }
 
+func (mw *MainWindow) openManageTeams() {
+	teamMgr := NewTeamManager(mw.app)
+	teamMgr.Show()
+}
+
 func (mw *MainWindow) openChugManager() {
 	chugMgr := NewChugManager(mw.app)
 	chugMgr.Show()
@@ -164,6 +185,16 @@ func (mw *MainWindow) openFinishContest() {
 	finishWindow.Show()
 }
 
+func (mw *MainWindow) openSeasonSeries() {
+	seriesWindow := NewSeasonSeries(mw.app)
+	seriesWindow.Show()
+}
+
+func (mw *MainWindow) openRecords() {
+	recordsWindow := NewRecordsHistory(mw.app)
+	recordsWindow.Show()
+}
+
 func (mw *MainWindow) exitApplication() {
 	mw.app.Quit()
 }
@@ -218,9 +249,12 @@ func (mw *MainWindow) showHelp() {
 ## Getting Started
 1. **Contest Wizard** - Set up a new contest
 2. **Add Participants** - Register contestants  
-3. **Chug Manager** - Run live contests
-4. **Configuration** - Adjust settings
-5. **Finish Contest** - Generate final results
+3. **Manage Teams** - Build team rosters for the team disciplines
+4. **Chug Manager** - Run live contests and the knockout brackets
+5. **Configuration** - Adjust settings
+6. **Finish Contest** - Generate final results
+7. **Season Series** - Add up standings across contests
+8. **Records** - Current records and how they were set
 
 ## Contest Workflow
 1. Use Contest Wizard to create contest files and structure
@@ -276,4 +310,6 @@ func (mw *MainWindow) Window() fyne.Window {
 // ShowAndRun displays the main window and starts the application
 func (mw *MainWindow) ShowAndRun() {
 	mw.window.ShowAndRun()
+	// Remove the contest lock even if windows were not closed one by one
+	data.CloseSessions()
 }
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/data"
+)
+
+// notMapped is the column choice for a field that is not in the file
+const notMapped = "(not mapped)"
+
+// importFieldLabels are the labels of the mappable fields in the import window
+var importFieldLabels = map[string]string{
+	data.ImportName:        "Name",
+	data.ImportProgram:     "Program",
+	data.ImportTeam:        "Team",
+	data.ImportCategories:  "Categories",
+	data.ImportCode:        "Code (322)",
+	data.ImportBottle:      "Bottle tries",
+	data.ImportHalfTankard: "Half tankard tries",
+	data.ImportFullTankard: "Full tankard tries",
+}
+
+// importFromSpreadsheet shows a file picker for a CSV/TSV registration list
+// and opens the import window for it
+func (pm *ParticipantManagerUI) importFromSpreadsheet() {
+	if pm.participantMgr == nil {
+		dialog.ShowError(fmt.Errorf("no participant file is loaded"), pm.window)
+		return
+	}
+
+	fileDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("error selecting file: %w", err), pm.window)
+			return
+		}
+		if reader == nil {
+			return // User cancelled
+		}
+		reader.Close()
+
+		filePath := reader.URI().Path()
+		table, err := data.ReadImportFile(filePath)
+		if err != nil {
+			dialog.ShowError(err, pm.window)
+			return
+		}
+		pm.showImportWindow(filepath.Base(filePath), table)
+	}, pm.window)
+	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".tsv", ".txt"}))
+	fileDialog.Show()
+}
+
+// showImportWindow lets the operator map the columns of table to participant
+// fields, shows the validation report for every row and merges the valid rows
+// into the participant list
+func (pm *ParticipantManagerUI) showImportWindow(fileName string, table data.ImportTable) {
+	importWindow := pm.app.NewWindow("Import Participants – " + fileName)
+
+	delimiter := map[rune]string{'\t': "tab", ';': "semicolon", ',': "comma"}[table.Delimiter]
+	infoLabel := widget.NewLabel(fmt.Sprintf("%d rows, %s separated, %s", len(table.Rows), delimiter, table.Encoding))
+
+	columns := append([]string{notMapped}, table.Header...)
+	for i, h := range table.Header {
+		if h == "" {
+			columns[i+1] = fmt.Sprintf("Column %d", i+1)
+		}
+	}
+
+	var report data.ImportReport
+	summaryLabel := widget.NewLabel("")
+	reportList := widget.NewList(
+		func() int { return len(report.Rows) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("Row")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= 0 && id < len(report.Rows) {
+				row := report.Rows[id]
+				p := row.Participant
+				line := fmt.Sprintf("Line %d: %s - %s - %s - %s%s%s",
+					row.Line, p.Name, p.Program, p.Team, p.Bottle, p.HalfTankard, p.FullTankard)
+				if !row.OK() {
+					line = fmt.Sprintf("Line %d: %s  [%s]", row.Line, p.Name, strings.Join(row.Problems, "; "))
+				}
+				item.(*widget.Label).SetText(line)
+			}
+		},
+	)
+
+	importBtn := widget.NewButton("Import", nil)
+	importBtn.Importance = widget.HighImportance
+
+	// Rebuild the report whenever the mapping changes
+	mapping := data.GuessMapping(table.Header)
+	replan := func() {
+		var err error
+		report, err = data.PlanImport(table, mapping, pm.participantMgr.GetParticipants())
+		if err != nil {
+			summaryLabel.SetText(err.Error())
+			importBtn.Disable()
+		} else {
+			summaryLabel.SetText(fmt.Sprintf("%d rows will be imported, %d have problems and are skipped",
+				len(report.Accepted()), report.Rejected()))
+			if len(report.Accepted()) > 0 {
+				importBtn.Enable()
+			} else {
+				importBtn.Disable()
+			}
+		}
+		reportList.Refresh()
+	}
+
+	mappingForm := widget.NewForm()
+	for _, field := range data.ImportFields {
+		field := field
+		columnSelect := widget.NewSelect(columns, nil)
+		if col, ok := mapping[field]; ok {
+			columnSelect.SetSelected(columns[col+1])
+		} else {
+			columnSelect.SetSelected(notMapped)
+		}
+		columnSelect.OnChanged = func(string) {
+			if i := columnSelect.SelectedIndex(); i > 0 {
+				mapping[field] = i - 1
+			} else {
+				delete(mapping, field)
+			}
+			replan()
+		}
+		mappingForm.Append(importFieldLabels[field], columnSelect)
+	}
+	hint := widget.NewLabel("Map either the code column or the per-discipline tries columns. " +
+		"Without them the tries from the configuration are used.")
+	hint.Wrapping = fyne.TextWrapWord
+
+	importBtn.OnTapped = func() {
+		added, err := pm.participantMgr.ImportParticipants(report)
+		if err != nil {
+			dialog.ShowError(err, importWindow)
+			return
+		}
+		if err := pm.participantMgr.SaveParticipants(); err != nil {
+			dialog.ShowError(fmt.Errorf("participants imported but failed to save: %w", err), importWindow)
+			return
+		}
+		pm.refreshParticipantList()
+		importWindow.Close()
+		dialog.ShowInformation("Import Complete",
+			fmt.Sprintf("Imported %d participants, skipped %d rows", added, report.Rejected()),
+			pm.window)
+	}
+	cancelBtn := widget.NewButton("Cancel", importWindow.Close)
+
+	left := container.NewVBox(
+		infoLabel,
+		widget.NewSeparator(),
+		mappingForm,
+		hint,
+		widget.NewSeparator(),
+		container.NewHBox(importBtn, cancelBtn),
+	)
+	right := container.NewBorder(summaryLabel, nil, nil, nil, reportList)
+
+	replan()
+	split := container.NewHSplit(container.NewScroll(left), right)
+	split.SetOffset(0.35)
+	importWindow.SetContent(split)
+	importWindow.Resize(fyne.NewSize(1000, 560))
+	importWindow.Show()
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -22,14 +21,17 @@ type ParticipantManagerUI struct {
 	app    fyne.App
 	window fyne.Window
 
-	// Data managers
+	// Data managers, shared with the other windows through the session
+	session        *contestSession
 	participantMgr *data.ParticipantManager
+	teamMgr        *data.TeamManager
 	resultMgr      *data.ResultManager
 
 	// UI Components - Participant Form
 	nameEntry        *widget.Entry
 	programEntry     *widget.Entry
 	teamEntry        *widget.Entry
+	categoriesEntry  *widget.Entry
 	disciplinesEntry *widget.Entry // Single entry for "322" format
 
 	// UI Components - Lists
@@ -44,6 +46,7 @@ type ParticipantManagerUI struct {
 	refreshBtn *widget.Button
 	saveBtn    *widget.Button
 	loadBtn    *widget.Button
+	importBtn  *widget.Button
 
 	// Data
 	participants []models.Participant
@@ -51,9 +54,6 @@ type ParticipantManagerUI struct {
 
 	// Selection tracking
 	selectedParticipantID int
-
-	// Auto-refresh
-	refreshTimer *time.Timer
 }
 
 // NewParticipantManager creates a new participant manager window
@@ -73,7 +73,9 @@ func NewParticipantManager(app fyne.App) *ParticipantManagerUI {
 // initializeManagers sets up data managers
 func (pm *ParticipantManagerUI) initializeManagers() {
 	pm.participantMgr = data.NewParticipantManager()
+	pm.teamMgr = data.NewTeamManager()
 	pm.resultMgr = data.NewResultManager()
+	pm.session = newContestSession(pm.window, pm.onDataChanged)
 }
 
 // setupUI initializes the participant manager UI
@@ -90,9 +92,6 @@ func (pm *ParticipantManagerUI) setupUI() {
 	// Create layout
 	content := pm.createLayout()
 	pm.window.SetContent(content)
-
-	// Start auto-refresh
-	pm.startAutoRefresh()
 }
 
 // createFormComponents creates the participant form components
@@ -106,6 +105,9 @@ func (pm *ParticipantManagerUI) createFormComponents() {
 	pm.teamEntry = widget.NewEntry()
 	pm.teamEntry.SetPlaceHolder("Team name")
 
+	pm.categoriesEntry = widget.NewEntry()
+	pm.categoriesEntry.SetPlaceHolder("e.g. Women, Alumni")
+
 	// Single discipline entry like reference project
 	pm.disciplinesEntry = widget.NewEntry()
 	pm.disciplinesEntry.SetPlaceHolder("322")
@@ -174,17 +176,8 @@ func (pm *ParticipantManagerUI) createListComponents() {
 		},
 	)
 
-	// Discipline filter
-	disciplines := []string{
-		models.DisciplineBottle,
-		models.DisciplineHalfTankard,
-		models.DisciplineFullTankard,
-		models.DisciplineBierStaphette,
-		models.DisciplineMegaMedley,
-		models.DisciplineTeamClash,
-	}
-
-	pm.disciplineFilter = widget.NewCheckGroup(disciplines, pm.onDisciplineFilterChanged)
+	// Discipline filter; the options follow the contest's disciplines
+	pm.disciplineFilter = widget.NewCheckGroup(data.DefaultDisciplineRegistry().Names(), pm.onDisciplineFilterChanged)
 }
 
 // createButtonComponents creates the action buttons
@@ -195,6 +188,7 @@ func (pm *ParticipantManagerUI) createButtonComponents() {
 	pm.refreshBtn = widget.NewButton("Refresh", pm.refreshData)
 	pm.saveBtn = widget.NewButton("Save All", pm.saveData)
 	pm.loadBtn = widget.NewButton("Load from File", pm.loadFromFile)
+	pm.importBtn = widget.NewButton("Import CSV/TSV", pm.importFromSpreadsheet)
 
 	// Initially disable update/delete buttons
 	pm.updateBtn.Disable()
@@ -209,6 +203,7 @@ func (pm *ParticipantManagerUI) createLayout() fyne.CanvasObject {
 			widget.NewFormItem("Name", pm.nameEntry).Widget,
 			widget.NewFormItem("Program", pm.programEntry).Widget,
 			widget.NewFormItem("Team", pm.teamEntry).Widget,
+			widget.NewFormItem("Categories", pm.categoriesEntry).Widget,
 
 			widget.NewSeparator(),
 			widget.NewLabel("Disciplines (3 digits):"),
@@ -240,7 +235,7 @@ func (pm *ParticipantManagerUI) createLayout() fyne.CanvasObject {
 	// Participants tab
 	participantsTab := container.NewBorder(
 		nil,
-		container.NewHBox(pm.refreshBtn, pm.saveBtn, pm.loadBtn),
+		container.NewHBox(pm.refreshBtn, pm.saveBtn, pm.loadBtn, pm.importBtn),
 		nil, nil,
 		pm.participantList,
 	)
@@ -279,6 +274,7 @@ func (pm *ParticipantManagerUI) onParticipantSelected(id widget.ListItemID) {
 		pm.nameEntry.SetText(participant.Name)
 		pm.programEntry.SetText(participant.Program)
 		pm.teamEntry.SetText(participant.Team)
+		pm.categoriesEntry.SetText(strings.Join(participant.Categories, ", "))
 		// Combine discipline attempts into single string
 		disciplineString := participant.Bottle + participant.HalfTankard + participant.FullTankard
 		pm.disciplinesEntry.SetText(disciplineString)
@@ -288,7 +284,7 @@ func (pm *ParticipantManagerUI) onParticipantSelected(id widget.ListItemID) {
 		pm.deleteBtn.Enable()
 
 		// Load participant results
-		pm.loadParticipantResults(participant.Name)
+		pm.loadParticipantResults(participant.ID)
 	}
 }
 
@@ -331,16 +327,26 @@ func (pm *ParticipantManagerUI) updateParticipant() {
 		return
 	}
 
-	// Remove old and add updated
+	// Update in place so the participant keeps its ID and results, and the
+	// tries of the disciplines the form does not show
 	oldParticipant := pm.participants[pm.selectedParticipantID]
-	if err := pm.participantMgr.RemoveParticipant(oldParticipant.Name); err != nil {
+	participant.ID = oldParticipant.ID
+	participant.Tries = oldParticipant.Tries
+	if err := pm.participantMgr.UpdateParticipant(*participant); err != nil {
 		dialog.ShowError(err, pm.window)
 		return
 	}
 
-	if err := pm.participantMgr.AddParticipant(*participant); err != nil {
-		dialog.ShowError(err, pm.window)
-		return
+	// A rename carries over to the participant's recorded results
+	if participant.Name != oldParticipant.Name {
+		if err := pm.resultMgr.RenameParticipant(participant.ID, participant.Name); err != nil {
+			dialog.ShowError(fmt.Errorf("error renaming results: %w", err), pm.window)
+			return
+		}
+		if err := pm.resultMgr.SaveResults(); err != nil {
+			dialog.ShowError(fmt.Errorf("error saving results: %w", err), pm.window)
+			return
+		}
 	}
 
 	// Auto-save after updating
@@ -367,7 +373,7 @@ func (pm *ParticipantManagerUI) deleteParticipant() {
 		fmt.Sprintf("Are you sure you want to delete participant '%s'?", participant.Name),
 		func(confirmed bool) {
 			if confirmed {
-				if err := pm.participantMgr.RemoveParticipant(participant.Name); err != nil {
+				if err := pm.participantMgr.RemoveParticipant(participant.ID); err != nil {
 					dialog.ShowError(err, pm.window)
 					return
 				}
@@ -378,6 +384,14 @@ func (pm *ParticipantManagerUI) deleteParticipant() {
 					return
 				}
 
+				// A deleted participant no longer runs for their team
+				if pm.teamMgr.DropMember(participant.ID) {
+					if err := pm.teamMgr.SaveTeams(); err != nil {
+						dialog.ShowError(fmt.Errorf("participant deleted but failed to update team rosters: %w", err), pm.window)
+						return
+					}
+				}
+
 				pm.clearForm()
 				pm.refreshParticipantList()
 				dialog.ShowInformation("Success", "Participant deleted and saved successfully", pm.window)
@@ -403,29 +417,33 @@ func (pm *ParticipantManagerUI) loadFromFile() {
 
 		filePath := reader.URI().Path()
 
-		// Load participants from selected file
-		if err := pm.participantMgr.LoadParticipants(filePath); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading participants from %s: %w", filePath, err), pm.window)
+		// An official contest with results keeps its participant file
+		if err := data.CheckContestFiles(config.Settings.ParticipantFile, config.Settings.ResultFile,
+			filePath, config.Settings.ResultFile); err != nil {
+			dialog.ShowError(err, pm.window)
 			return
 		}
 
-		// Update configuration to remember this file
-		config.Settings.ParticipantFile = filePath
-		if err := config.SaveConfig(); err != nil {
-			dialog.ShowError(fmt.Errorf("loaded file but failed to save configuration: %w", err), pm.window)
-		}
+		// Update configuration to remember this file, then join the
+		// session for it
+		useFile := func() {
+			config.Settings.ParticipantFile = filePath
+			if err := config.SaveConfig(); err != nil {
+				dialog.ShowError(fmt.Errorf("loaded file but failed to save configuration: %w", err), pm.window)
+			}
 
-		pm.refreshParticipantList()
-		dialog.ShowInformation("Success",
-			fmt.Sprintf("Loaded %d participants from: %s", len(pm.participants), filePath),
-			pm.window)
+			pm.loadData()
+			dialog.ShowInformation("Success",
+				fmt.Sprintf("Loaded %d participants from: %s", len(pm.participants), filePath),
+				pm.window)
+		}
 
-		// Also try to load results if result file is configured
-		if config.Settings.ResultFile != "" && utils.DoesFileExist(config.Settings.ResultFile) {
-			if err := pm.resultMgr.LoadResults(config.Settings.ResultFile); err == nil {
-				pm.refreshResultsList()
-			}
+		// Check the selected file before switching to it
+		if err := data.NewParticipantManager().LoadParticipants(filePath); err != nil {
+			showLoadError(fmt.Errorf("error loading participants from %s: %w", filePath, err), pm.window, useFile)
+			return
 		}
+		useFile()
 	}, pm.window)
 }
 
@@ -450,16 +468,35 @@ func (pm *ParticipantManagerUI) loadData() {
 		return
 	}
 
+	pm.session.attach(func(s *data.Session) {
+		pm.participantMgr = s.Participants()
+		pm.teamMgr = s.Teams()
+		pm.resultMgr = s.Results()
+		pm.loadFiles()
+	})
+}
+
+// loadFiles (re)loads the shared managers from the configured files,
+// creating missing files.
+func (pm *ParticipantManagerUI) loadFiles() {
+	// Offer the contest's disciplines in the results filter
+	if disciplines, err := data.LoadDisciplines(data.DisciplinePathFor(config.Settings.ParticipantFile)); err != nil {
+		dialog.ShowError(fmt.Errorf("error loading disciplines: %w", err), pm.window)
+	} else {
+		pm.disciplineFilter.Options = disciplines.Names()
+		pm.disciplineFilter.Refresh()
+	}
+
 	// Load participants
 	if utils.DoesFileExist(config.Settings.ParticipantFile) {
 		if err := pm.participantMgr.LoadParticipants(config.Settings.ParticipantFile); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading participants from %s: %w", config.Settings.ParticipantFile, err), pm.window)
+			showLoadError(fmt.Errorf("error loading participants from %s: %w", config.Settings.ParticipantFile, err), pm.window, pm.loadData)
 		} else {
 			pm.refreshParticipantList()
 		}
 	} else {
 		// File doesn't exist - create empty file and inform user
-		if err := utils.SaveListToJSONFile(config.Settings.ParticipantFile, []map[string]string{}); err != nil {
+		if err := pm.participantMgr.SaveParticipants(); err != nil {
 			dialog.ShowError(fmt.Errorf("error creating participant file %s: %w", config.Settings.ParticipantFile, err), pm.window)
 		} else {
 			dialog.ShowInformation("Created New File",
@@ -471,22 +508,24 @@ func (pm *ParticipantManagerUI) loadData() {
 		pm.refreshParticipantList()
 	}
 
+	// Load teams so a deleted participant can be taken off their rosters
+	if teamFile := data.TeamPathFor(config.Settings.ParticipantFile); utils.DoesFileExist(teamFile) {
+		if err := pm.teamMgr.LoadTeams(teamFile); err != nil {
+			showLoadError(fmt.Errorf("error loading teams from %s: %w", teamFile, err), pm.window, pm.loadData)
+		}
+	}
+
 	// Load results
 	if config.Settings.ResultFile != "" && utils.DoesFileExist(config.Settings.ResultFile) {
 		if err := pm.resultMgr.LoadResults(config.Settings.ResultFile); err != nil {
-			dialog.ShowError(fmt.Errorf("error loading results: %w", err), pm.window)
+			showLoadError(fmt.Errorf("error loading results: %w", err), pm.window, pm.loadData)
 		} else {
 			pm.refreshResultsList()
 		}
 	} else if config.Settings.ResultFile != "" {
 		// Create empty results file if it doesn't exist
-		if err := utils.SaveListToJSONFile(config.Settings.ResultFile, []map[string]string{}); err != nil {
+		if err := pm.resultMgr.SaveResults(); err != nil {
 			dialog.ShowError(fmt.Errorf("error creating result file %s: %w", config.Settings.ResultFile, err), pm.window)
-		} else {
-			// Load the newly-created file so filePath is set on resultMgr
-			if err := pm.resultMgr.LoadResults(config.Settings.ResultFile); err != nil {
-				dialog.ShowError(fmt.Errorf("error initialising result manager: %w", err), pm.window)
-			}
 		}
 		pm.results = nil
 		pm.refreshResultsList()
@@ -524,6 +563,7 @@ func (pm *ParticipantManagerUI) getParticipantFromForm() *models.Participant {
 		Bottle:      string(disciplineStr[0]),
 		HalfTankard: string(disciplineStr[1]),
 		FullTankard: string(disciplineStr[2]),
+		Categories:  data.ParseCategories(pm.categoriesEntry.Text),
 	}
 
 	return participant
@@ -533,6 +573,7 @@ func (pm *ParticipantManagerUI) clearForm() {
 	pm.nameEntry.SetText("")
 	pm.programEntry.SetText("")
 	pm.teamEntry.SetText("")
+	pm.categoriesEntry.SetText("")
 	pm.disciplinesEntry.SetText("322") // Reset to default
 }
 
@@ -548,12 +589,12 @@ func (pm *ParticipantManagerUI) refreshParticipantList() {
 }
 
 func (pm *ParticipantManagerUI) refreshResultsList() {
-	pm.results = pm.resultMgr.GetResults()
+	pm.results = pm.resultMgr.GetActiveResults()
 	pm.participantResults.Refresh()
 }
 
-func (pm *ParticipantManagerUI) loadParticipantResults(participantName string) {
-	filteredResults := pm.resultMgr.GetResultsByParticipant(participantName)
+func (pm *ParticipantManagerUI) loadParticipantResults(participantID string) {
+	filteredResults := pm.resultMgr.GetResultsByParticipant(participantID)
 
 	// Apply discipline filter if any
 	if len(pm.disciplineFilter.Selected) > 0 {
@@ -576,10 +617,10 @@ func (pm *ParticipantManagerUI) loadParticipantResults(participantName string) {
 
 func (pm *ParticipantManagerUI) filterResults(disciplines []string) {
 	if len(disciplines) == 0 {
-		pm.results = pm.resultMgr.GetResults()
+		pm.results = pm.resultMgr.GetActiveResults()
 	} else {
 		var filteredResults []models.Result
-		allResults := pm.resultMgr.GetResults()
+		allResults := pm.resultMgr.GetActiveResults()
 
 		for _, result := range allResults {
 			for _, discipline := range disciplines {
@@ -596,12 +637,15 @@ func (pm *ParticipantManagerUI) filterResults(disciplines []string) {
 	pm.participantResults.Refresh()
 }
 
-func (pm *ParticipantManagerUI) startAutoRefresh() {
-	// Refresh every 10 seconds
-	pm.refreshTimer = time.AfterFunc(10*time.Second, func() {
-		pm.refreshData()
-		pm.startAutoRefresh() // Restart timer
-	})
+// onDataChanged refreshes the lists after another window or program changed
+// the contest data.
+func (pm *ParticipantManagerUI) onDataChanged(c data.Change) {
+	if c.Participants {
+		pm.refreshParticipantList()
+	}
+	if c.Results {
+		pm.refreshResultsList()
+	}
 }
 
 // getFileStatusParts returns a short status word and the file path as separate strings.
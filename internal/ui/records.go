@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/export"
+)
+
+// RecordsHistory shows the records of every contest under the ChugWare
+// folder: the current all-time, program and team records and the history
+// of how they were set.
+type RecordsHistory struct {
+	app    fyne.App
+	window fyne.Window
+
+	// UI Components
+	infoLabel   *widget.Label
+	currentList *widget.List
+	historyList *widget.List
+	refreshBtn  *widget.Button
+
+	// Data
+	current []data.Record
+	history []data.Record // newest first
+}
+
+// NewRecordsHistory creates a new records window
+func NewRecordsHistory(app fyne.App) *RecordsHistory {
+	rh := &RecordsHistory{
+		app:    app,
+		window: app.NewWindow("Records"),
+	}
+
+	rh.setupUI()
+	rh.loadRecords()
+	return rh
+}
+
+// setupUI initializes the records UI
+func (rh *RecordsHistory) setupUI() {
+	rh.window.Resize(fyne.NewSize(1000, 650))
+	rh.window.CenterOnScreen()
+
+	rh.infoLabel = widget.NewLabel("")
+	rh.refreshBtn = widget.NewButton("Refresh", rh.loadRecords)
+	rh.currentList = rh.newRecordList(func() []data.Record { return rh.current }, false)
+	rh.historyList = rh.newRecordList(func() []data.Record { return rh.history }, true)
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Current Records", rh.currentList),
+		container.NewTabItem("History", rh.historyList),
+	)
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, nil, rh.refreshBtn, rh.infoLabel),
+		widget.NewSeparator(),
+	)
+	rh.window.SetContent(container.NewBorder(top, nil, nil, nil, tabs))
+}
+
+// loadRecords reads every contest under the ChugWare folder, oldest first.
+func (rh *RecordsHistory) loadRecords() {
+	if config.Settings.FolderPath == "" {
+		dialog.ShowError(fmt.Errorf("no ChugWare folder configured"), rh.window)
+		return
+	}
+	book, err := data.LoadRecordBook(config.Settings.FolderPath, "")
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("some contests are left out of the records: %w", err), rh.window)
+	}
+
+	rh.current = nil
+	for _, scope := range []string{data.RecordAllTime, data.RecordProgram, data.RecordTeam} {
+		rh.current = append(rh.current, book.Records(scope)...)
+	}
+	rh.history = make([]data.Record, len(book.History))
+	for i, r := range book.History {
+		rh.history[len(book.History)-1-i] = r
+	}
+	rh.infoLabel.SetText(fmt.Sprintf("%d current record(s), %d set in all.", len(rh.current), len(rh.history)))
+	rh.currentList.Refresh()
+	rh.historyList.Refresh()
+}
+
+// newRecordList creates a list of records. The history also shows the time
+// each record broke.
+func (rh *RecordsHistory) newRecordList(records func() []data.Record, history bool) *widget.List {
+	return widget.NewList(
+		func() int { return len(records()) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(
+				widget.NewLabelWithStyle("Discipline", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Record", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Time", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Contest", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabel("Previous"),
+			)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			list := records()
+			if id >= 0 && id < len(list) {
+				r := list[id]
+				containers := item.(*fyne.Container)
+
+				name, date := export.ContestFromFolder(r.Contest)
+				containers.Objects[0].(*widget.Label).SetText(r.Discipline)
+				containers.Objects[1].(*widget.Label).SetText(r.Label())
+				containers.Objects[2].(*widget.Label).SetText(r.Name)
+				containers.Objects[3].(*widget.Label).SetText(r.Time)
+				containers.Objects[4].(*widget.Label).SetText(fmt.Sprintf("%s %s", name, date))
+				previous := ""
+				if history {
+					previous = "first record"
+					if r.Previous != "" {
+						previous = "broke " + r.Previous
+					}
+				}
+				containers.Objects[5].(*widget.Label).SetText(previous)
+			}
+		},
+	)
+}
+
+// Show displays the records window
+func (rh *RecordsHistory) Show() {
+	rh.window.Show()
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"chugware/internal/utils"
+)
+
+// showLoadError reports a failed load. If the file turned out to be corrupt and
+// a valid backup exists, the operator is offered to restore it; reload is
+// called after a successful restore.
+func showLoadError(err error, window fyne.Window, reload func()) {
+	corrupt, ok := utils.IsCorruptFile(err)
+	if !ok || corrupt.Backup == "" {
+		dialog.ShowError(err, window)
+		return
+	}
+
+	message := fmt.Sprintf("%s could not be read – it was probably cut off while being saved.\n\n"+
+		"Restore it from the newest valid backup (%s)?\n\n"+
+		"The damaged file is kept in the %s folder.",
+		filepath.Base(corrupt.Path), filepath.Base(corrupt.Backup), filepath.Base(utils.BackupDir(corrupt.Path)))
+
+	dialog.ShowConfirm("Corrupt Data File", message, func(restore bool) {
+		if !restore {
+			dialog.ShowError(err, window)
+			return
+		}
+		backup, rerr := utils.RecoverFromBackup(corrupt.Path)
+		if rerr != nil {
+			dialog.ShowError(fmt.Errorf("recovery failed: %w", rerr), window)
+			return
+		}
+		dialog.ShowInformation("File Restored",
+			fmt.Sprintf("%s was restored from %s", filepath.Base(corrupt.Path), filepath.Base(backup)),
+			window)
+		reload()
+	}, window)
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/models"
+)
+
+// RunningOrderEditor draws the running order of a discipline from a seed,
+// lets it be rearranged by hand and exports it as a start list for the MC.
+// It works on the running orders of the Chug Manager it was opened from,
+// which saves them to the contest folder.
+type RunningOrderEditor struct {
+	cm     *ChugManager
+	window fyne.Window
+
+	discipline string
+	order      models.RunningOrder
+	field      []string // IDs in running order
+	selected   int
+
+	// UI Components
+	seedEntry *widget.Entry
+	drawBtn   *widget.Button
+	upBtn     *widget.Button
+	downBtn   *widget.Button
+	exportBtn *widget.Button
+	infoLabel *widget.Label
+	list      *widget.List
+}
+
+// newRunningOrderEditor creates the running order window of discipline.
+func newRunningOrderEditor(cm *ChugManager, discipline string) *RunningOrderEditor {
+	re := &RunningOrderEditor{
+		cm:         cm,
+		discipline: discipline,
+		selected:   -1,
+	}
+	re.window = cm.app.NewWindow(fmt.Sprintf("Running Order - %s", discipline))
+
+	re.setupUI()
+	re.loadOrder()
+	return re
+}
+
+// setupUI initializes the running order UI
+func (re *RunningOrderEditor) setupUI() {
+	re.window.Resize(fyne.NewSize(700, 650))
+	re.window.CenterOnScreen()
+
+	re.seedEntry = widget.NewEntry()
+	re.seedEntry.SetPlaceHolder("Seed")
+	re.drawBtn = widget.NewButton("Draw", re.draw)
+	re.upBtn = widget.NewButton("Move Up", func() { re.move(-1) })
+	re.downBtn = widget.NewButton("Move Down", func() { re.move(1) })
+	re.exportBtn = widget.NewButton("Export Start List", re.exportStartList)
+	re.infoLabel = widget.NewLabel("")
+	re.upBtn.Disable()
+	re.downBtn.Disable()
+
+	re.list = widget.NewList(
+		func() int { return len(re.field) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(
+				widget.NewLabel("000."),
+				widget.NewLabel("Name"),
+				widget.NewLabel("Program"),
+				widget.NewLabel("Status"),
+			)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id < 0 || id >= len(re.field) {
+				return
+			}
+			name, program := re.competitor(re.field[id])
+			status := ""
+			if data.IsSkipped(re.order, re.field[id]) {
+				status = "(skipped)"
+			}
+			row := item.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%d.", id+1))
+			row.Objects[1].(*widget.Label).SetText(name)
+			row.Objects[2].(*widget.Label).SetText(program)
+			row.Objects[3].(*widget.Label).SetText(status)
+		},
+	)
+	re.list.OnSelected = func(id widget.ListItemID) {
+		re.selected = id
+		re.upBtn.Enable()
+		re.downBtn.Enable()
+	}
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabel("Seed:"), re.drawBtn, re.seedEntry),
+		re.infoLabel,
+		widget.NewSeparator(),
+	)
+	bottom := container.NewHBox(re.upBtn, re.downBtn, widget.NewSeparator(), re.exportBtn)
+	re.window.SetContent(container.NewBorder(top, bottom, nil, nil, re.list))
+}
+
+// loadOrder shows the discipline's running order as the Chug Manager holds
+// it, offering a fresh seed if it has not been drawn yet.
+func (re *RunningOrderEditor) loadOrder() {
+	re.order = re.cm.runningOrder(re.discipline)
+	re.field = data.ArrangeByRunningOrder(re.order, re.cm.runningField(re.discipline))
+
+	if len(re.order.Order) > 0 {
+		re.seedEntry.SetText(strconv.FormatInt(re.order.Seed, 10))
+		re.infoLabel.SetText(fmt.Sprintf("%d in the running order, drawn with seed %d", len(re.field), re.order.Seed))
+	} else {
+		re.seedEntry.SetText(strconv.FormatInt(rand.Int63n(1000000), 10))
+		re.infoLabel.SetText(fmt.Sprintf("%d to run; no running order drawn yet, they run by name", len(re.field)))
+	}
+
+	// An official contest keeps its draw once the discipline has a result;
+	// late entries can still be moved into place
+	if re.cm.rules.Locked(re.cm.resultMgr.GetResultsByDiscipline(re.discipline)) {
+		re.seedEntry.Disable()
+		re.drawBtn.Disable()
+		re.infoLabel.SetText(re.infoLabel.Text + "; the draw is locked now that results have been recorded")
+	} else {
+		re.seedEntry.Enable()
+		re.drawBtn.Enable()
+	}
+	re.list.Refresh()
+}
+
+// competitor returns the name and program of the participant or team id.
+func (re *RunningOrderEditor) competitor(id string) (name, program string) {
+	for _, p := range re.cm.allParticipants {
+		if p.ID == id {
+			return p.Name, p.Program
+		}
+	}
+	if t, ok := re.cm.teamMgr.GetTeam(id); ok {
+		return t.Name, t.Program
+	}
+	return id, ""
+}
+
+// draw draws the running order from the seed, replacing the current order
+// and its manual moves after confirmation. The skipped list is kept.
+func (re *RunningOrderEditor) draw() {
+	seed, err := strconv.ParseInt(strings.TrimSpace(re.seedEntry.Text), 10, 64)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("the seed must be a whole number"), re.window)
+		return
+	}
+	if len(re.cm.runningField(re.discipline)) == 0 {
+		dialog.ShowError(fmt.Errorf("no one runs %s", re.discipline), re.window)
+		return
+	}
+	apply := func() {
+		o := data.DrawRunningOrder(re.discipline, re.cm.runningField(re.discipline), seed)
+		o.Skipped = re.order.Skipped
+		re.save(o)
+	}
+	if len(re.order.Order) == 0 {
+		apply()
+		return
+	}
+	dialog.ShowConfirm("Draw Again",
+		fmt.Sprintf("Replace the running order of %s, drawn with seed %d, and any changes made to it?", re.discipline, re.order.Seed),
+		func(ok bool) {
+			if ok {
+				apply()
+			}
+		}, re.window)
+}
+
+// move moves the selected competitor delta places up (-1) or down (1).
+func (re *RunningOrderEditor) move(delta int) {
+	to := re.selected + delta
+	if re.selected < 0 || to < 0 || to >= len(re.field) {
+		return
+	}
+	o := re.order
+	if err := data.MoveInRunningOrder(&o, re.field, re.selected, to); err != nil {
+		dialog.ShowError(err, re.window)
+		return
+	}
+	if re.save(o) {
+		re.list.Select(to)
+	}
+}
+
+// save stores o in the Chug Manager, which saves it and reorders its lists.
+func (re *RunningOrderEditor) save(o models.RunningOrder) bool {
+	if err := re.cm.setRunningOrder(o); err != nil {
+		dialog.ShowError(err, re.window)
+		return false
+	}
+	re.loadOrder()
+	return true
+}
+
+// exportStartList writes the start list to the results directory and offers
+// to copy it for the MC.
+func (re *RunningOrderEditor) exportStartList() {
+	if config.GetResultsPath() == "" {
+		dialog.ShowError(fmt.Errorf("no contest directory configured"), re.window)
+		return
+	}
+	o := re.order
+	o.Order = re.field
+	list := data.StartList(o, re.cm.allParticipants, re.cm.sortedTeams())
+
+	name := strings.ToLower(strings.ReplaceAll(re.discipline, " ", "_"))
+	fullPath := filepath.Join(config.GetResultsPath(),
+		fmt.Sprintf("start_list_%s_%s.txt", name, time.Now().Format("20060102_150405")))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		dialog.ShowError(fmt.Errorf("error exporting start list: %w", err), re.window)
+		return
+	}
+	if err := os.WriteFile(fullPath, []byte(list), 0644); err != nil {
+		dialog.ShowError(fmt.Errorf("error exporting start list: %w", err), re.window)
+		return
+	}
+	dialog.ShowCustomConfirm("Start List Exported", "Copy to Clipboard", "Close",
+		widget.NewLabel(fmt.Sprintf("Start list exported to: %s", fullPath)),
+		func(copyIt bool) {
+			if copyIt {
+				re.window.Clipboard().SetContent(list)
+			}
+		}, re.window)
+}
+
+// Show displays the running order window
+func (re *RunningOrderEditor) Show() {
+	re.window.Show()
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/export"
+)
+
+// SeasonSeries shows the season standings of the series defined in the
+// ChugWare root folder: overall and per discipline, with the points of every
+// contest.
+type SeasonSeries struct {
+	app    fyne.App
+	window fyne.Window
+
+	// UI Components
+	seriesSelect *widget.Select
+	infoLabel    *widget.Label
+	tabs         *container.AppTabs
+	refreshBtn   *widget.Button
+
+	// Data
+	series    []data.SeriesDef
+	standings data.SeriesStandings
+}
+
+// NewSeasonSeries creates a new season series window
+func NewSeasonSeries(app fyne.App) *SeasonSeries {
+	ss := &SeasonSeries{
+		app:    app,
+		window: app.NewWindow("Season Series"),
+	}
+
+	ss.setupUI()
+	ss.loadSeries()
+	return ss
+}
+
+// setupUI initializes the season series UI
+func (ss *SeasonSeries) setupUI() {
+	ss.window.Resize(fyne.NewSize(1000, 650))
+	ss.window.CenterOnScreen()
+
+	ss.seriesSelect = widget.NewSelect(nil, func(string) { ss.showStandings() })
+	ss.seriesSelect.PlaceHolder = "Select a series"
+	ss.infoLabel = widget.NewLabel("")
+	ss.tabs = container.NewAppTabs()
+	ss.refreshBtn = widget.NewButton("Refresh", ss.loadSeries)
+
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, widget.NewLabel("Series:"), ss.refreshBtn, ss.seriesSelect),
+		ss.infoLabel,
+		widget.NewSeparator(),
+	)
+	ss.window.SetContent(container.NewBorder(top, nil, nil, nil, ss.tabs))
+}
+
+// loadSeries reads the series file of the ChugWare root folder.
+func (ss *SeasonSeries) loadSeries() {
+	path := data.SeriesPathFor(config.Settings.FolderPath)
+	series, err := data.LoadSeries(path)
+	if err != nil {
+		dialog.ShowError(err, ss.window)
+		return
+	}
+	ss.series = series
+
+	var names []string
+	for _, s := range series {
+		names = append(names, s.Name)
+	}
+	ss.seriesSelect.Options = names
+	if len(names) == 0 {
+		ss.infoLabel.SetText(fmt.Sprintf("No series defined. Add one to %s.", path))
+		ss.seriesSelect.ClearSelected()
+		ss.tabs.SetItems(nil)
+		return
+	}
+	for _, name := range names {
+		if name == ss.seriesSelect.Selected {
+			ss.showStandings()
+			return
+		}
+	}
+	ss.seriesSelect.SetSelected(names[0]) // calls showStandings
+}
+
+// showStandings loads the contests of the selected series and shows its
+// tables.
+func (ss *SeasonSeries) showStandings() {
+	var def data.SeriesDef
+	for _, s := range ss.series {
+		if s.Name == ss.seriesSelect.Selected {
+			def = s
+		}
+	}
+	if def.Name == "" {
+		return
+	}
+
+	standings, err := data.LoadSeriesStandings(config.Settings.FolderPath, def)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error loading series %q: %w", def.Name, err), ss.window)
+		return
+	}
+	ss.standings = standings
+
+	counting := "all contests count"
+	if def.BestOf > 0 {
+		counting = fmt.Sprintf("best %d of %d contests count", def.BestOf, len(standings.Contests))
+	}
+	ss.infoLabel.SetText(fmt.Sprintf("%d contest(s), %s. Points that do not count are in brackets.", len(standings.Contests), counting))
+
+	tabs := []*container.TabItem{container.NewTabItem("Overall", ss.newTableList(standings.Overall))}
+	for _, table := range standings.Disciplines {
+		tabs = append(tabs, container.NewTabItem(table.Name, ss.newTableList(table)))
+	}
+	ss.tabs.SetItems(tabs)
+}
+
+// newTableList creates the list of a season table.
+func (ss *SeasonSeries) newTableList(table data.SeriesTable) *widget.List {
+	return widget.NewList(
+		func() int { return len(table.Entries) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(
+				widget.NewLabelWithStyle("Rank", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Name", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("Points", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				widget.NewLabelWithStyle("By Contest", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= 0 && id < len(table.Entries) {
+				e := table.Entries[id]
+				containers := item.(*fyne.Container)
+
+				containers.Objects[0].(*widget.Label).SetText(e.Place)
+				containers.Objects[1].(*widget.Label).SetText(e.Name)
+				containers.Objects[2].(*widget.Label).SetText(fmt.Sprintf("%d", e.Points))
+				containers.Objects[3].(*widget.Label).SetText(ss.contestBreakdown(e))
+			}
+		},
+	)
+}
+
+// contestBreakdown lists the points of an entry per contest, e.g.
+// "Spring Chug 10, Summer Chug (6)"; points that do not count are in
+// brackets.
+func (ss *SeasonSeries) contestBreakdown(e data.SeriesEntry) string {
+	var parts []string
+	for i, points := range e.PerContest {
+		if points == 0 {
+			continue
+		}
+		name, _ := export.ContestFromFolder(ss.standings.Contests[i])
+		if e.Counted[i] {
+			parts = append(parts, fmt.Sprintf("%s %d", name, points))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s (%d)", name, points))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Show displays the season series window
+func (ss *SeasonSeries) Show() {
+	ss.window.Show()
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+)
+
+// contestSession connects a window to the shared data.Session of the
+// configured contest, so that every window works on the same managers and
+// hears about changes made by the others or by another program.
+type contestSession struct {
+	window      fyne.Window
+	onChange    func(data.Change)
+	session     *data.Session
+	unsubscribe func()
+}
+
+// newContestSession creates the connection for window; onChange is called
+// after every change to the contest data. The session is released when the
+// window closes.
+func newContestSession(window fyne.Window, onChange func(data.Change)) *contestSession {
+	cs := &contestSession{window: window, onChange: onChange}
+	window.SetOnClosed(cs.release)
+	return cs
+}
+
+// attach joins the session for the configured participant and result files,
+// switching sessions if the configuration changed since the last call, and
+// then calls ready. Nothing happens while no contest files are configured.
+// If another ChugWare instance has the contest open the operator is asked
+// before taking it over.
+func (cs *contestSession) attach(ready func(*data.Session)) {
+	participantFile, resultFile := config.Settings.ParticipantFile, config.Settings.ResultFile
+	if participantFile == "" && resultFile == "" {
+		return
+	}
+	if cs.session != nil && cs.session.Matches(participantFile, resultFile) {
+		ready(cs.session)
+		return
+	}
+	cs.release()
+
+	s, err := data.OpenSession(participantFile, resultFile, false)
+	var locked *data.LockedError
+	if errors.As(err, &locked) {
+		message := fmt.Sprintf("The %s.\n\n"+
+			"Working on it from two places at once can overwrite the other station's changes.\n"+
+			"Only continue if that ChugWare has been closed or crashed.\n\n"+
+			"Open the contest here anyway?", err)
+		dialog.ShowConfirm("Contest Already Open", message, func(takeOver bool) {
+			if !takeOver {
+				return
+			}
+			s, err := data.OpenSession(participantFile, resultFile, true)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("error opening contest: %w", err), cs.window)
+				return
+			}
+			cs.join(s)
+			ready(s)
+		}, cs.window)
+		return
+	}
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("error opening contest: %w", err), cs.window)
+		return
+	}
+	cs.join(s)
+	ready(s)
+}
+
+// join subscribes to s.
+func (cs *contestSession) join(s *data.Session) {
+	cs.session = s
+	cs.unsubscribe = s.Subscribe(cs.onChange)
+}
+
+// release leaves the current session, if any.
+func (cs *contestSession) release() {
+	if cs.session == nil {
+		return
+	}
+	cs.unsubscribe()
+	cs.session.Release()
+	cs.session = nil
+	cs.unsubscribe = nil
+}
+
+// showIgnoredSetupEdits tells the operator when the discipline or category
+// file of an official contest was edited by hand after its first result. The
+// definitions sealed with that result stay in force.
+func showIgnoredSetupEdits(window fyne.Window) {
+	if config.Settings.ParticipantFile == "" {
+		return
+	}
+	edited := data.IgnoredSetupEdits(config.Settings.ParticipantFile)
+	if len(edited) == 0 {
+		return
+	}
+	dialog.ShowInformation("Setup Locked",
+		fmt.Sprintf("Edits to %s made after the first result of this official contest are ignored:\n"+
+			"the definitions in force at the first result are used.",
+			strings.Join(edited, " and ")), window)
+}
@@ -0,0 +1,537 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"chugware/internal/config"
+	"chugware/internal/data"
+	"chugware/internal/models"
+	"chugware/internal/utils"
+)
+
+// TeamManagerUI handles the team editor: teams for the team disciplines and
+// the running order of their rosters
+type TeamManagerUI struct {
+	app    fyne.App
+	window fyne.Window
+
+	// Data managers, shared with the other windows through the session
+	session        *contestSession
+	participantMgr *data.ParticipantManager
+	teamMgr        *data.TeamManager
+	resultMgr      *data.ResultManager
+
+	// UI Components - Team Form
+	nameEntry    *widget.Entry
+	programEntry *widget.Entry
+	memberSelect *widget.Select
+
+	// UI Components - Lists
+	teamList   *widget.List
+	rosterList *widget.List
+
+	// UI Components - Buttons
+	addBtn          *widget.Button
+	updateBtn       *widget.Button
+	deleteBtn       *widget.Button
+	fromTeamsBtn    *widget.Button
+	addMemberBtn    *widget.Button
+	removeMemberBtn *widget.Button
+	moveUpBtn       *widget.Button
+	moveDownBtn     *widget.Button
+	refreshBtn      *widget.Button
+
+	// Data
+	teams            []models.Team
+	participants     []models.Participant
+	participantsByID map[string]models.Participant
+	memberOptions    map[string]string // select label -> participant ID
+	roster           []string          // participant IDs of the team being edited
+
+	// Selection tracking
+	selectedTeam   int
+	selectedMember int
+}
+
+// NewTeamManager creates a new team manager window
+func NewTeamManager(app fyne.App) *TeamManagerUI {
+	tm := &TeamManagerUI{
+		app:            app,
+		window:         app.NewWindow("Team Management"),
+		selectedTeam:   -1,
+		selectedMember: -1,
+	}
+
+	tm.initializeManagers()
+	tm.setupUI()
+	tm.loadData()
+	return tm
+}
+
+// initializeManagers sets up data managers
+func (tm *TeamManagerUI) initializeManagers() {
+	tm.participantMgr = data.NewParticipantManager()
+	tm.teamMgr = data.NewTeamManager()
+	tm.resultMgr = data.NewResultManager()
+	tm.session = newContestSession(tm.window, tm.onDataChanged)
+}
+
+// setupUI initializes the team manager UI
+func (tm *TeamManagerUI) setupUI() {
+	tm.window.Resize(fyne.NewSize(960, 680))
+	tm.window.CenterOnScreen()
+	tm.window.SetFixedSize(false)
+
+	tm.createFormComponents()
+	tm.createListComponents()
+	tm.createButtonComponents()
+
+	tm.window.SetContent(tm.createLayout())
+}
+
+// createFormComponents creates the team form components
+func (tm *TeamManagerUI) createFormComponents() {
+	tm.nameEntry = widget.NewEntry()
+	tm.nameEntry.SetPlaceHolder("Team name")
+
+	tm.programEntry = widget.NewEntry()
+	tm.programEntry.SetPlaceHolder("Program/Course")
+
+	tm.memberSelect = widget.NewSelect(nil, nil)
+	tm.memberSelect.PlaceHolder = "Select participant..."
+}
+
+// createListComponents creates the team and roster lists
+func (tm *TeamManagerUI) createListComponents() {
+	tm.teamList = widget.NewList(
+		func() int {
+			return len(tm.teams)
+		},
+		func() fyne.CanvasObject {
+			return container.NewHBox(
+				widget.NewLabel("Team"),
+				widget.NewLabel("Program"),
+				widget.NewLabel("Roster"),
+			)
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= 0 && id < len(tm.teams) {
+				t := tm.teams[id]
+				containers := item.(*fyne.Container)
+
+				containers.Objects[0].(*widget.Label).SetText(t.Name)
+				containers.Objects[1].(*widget.Label).SetText(t.Program)
+				containers.Objects[2].(*widget.Label).SetText(strings.Join(data.RosterNames(t, tm.participants), ", "))
+			}
+		},
+	)
+	tm.teamList.OnSelected = tm.onTeamSelected
+
+	tm.rosterList = widget.NewList(
+		func() int {
+			return len(tm.roster)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("1. Member")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			if id >= 0 && id < len(tm.roster) {
+				item.(*widget.Label).SetText(fmt.Sprintf("%d. %s", id+1, tm.memberName(tm.roster[id])))
+			}
+		},
+	)
+	tm.rosterList.OnSelected = func(id widget.ListItemID) {
+		tm.selectedMember = int(id)
+		tm.removeMemberBtn.Enable()
+		tm.moveUpBtn.Enable()
+		tm.moveDownBtn.Enable()
+	}
+}
+
+// createButtonComponents creates the action buttons
+func (tm *TeamManagerUI) createButtonComponents() {
+	tm.addBtn = widget.NewButton("Add Team", tm.addTeam)
+	tm.updateBtn = widget.NewButton("Update", tm.updateTeam)
+	tm.deleteBtn = widget.NewButton("Delete", tm.deleteTeam)
+	tm.fromTeamsBtn = widget.NewButton("Create from Participant Teams", tm.createFromParticipants)
+	tm.refreshBtn = widget.NewButton("Refresh", tm.loadData)
+
+	tm.addMemberBtn = widget.NewButton("Add Member", tm.addMember)
+	tm.removeMemberBtn = widget.NewButton("Remove", tm.removeMember)
+	tm.moveUpBtn = widget.NewButton("Up", func() { tm.moveMember(-1) })
+	tm.moveDownBtn = widget.NewButton("Down", func() { tm.moveMember(1) })
+
+	// Initially disable buttons that need a selection
+	tm.updateBtn.Disable()
+	tm.deleteBtn.Disable()
+	tm.removeMemberBtn.Disable()
+	tm.moveUpBtn.Disable()
+	tm.moveDownBtn.Disable()
+}
+
+// createLayout creates the main layout
+func (tm *TeamManagerUI) createLayout() fyne.CanvasObject {
+	form := widget.NewCard("Add/Edit Team", "",
+		container.NewVBox(
+			widget.NewFormItem("Name", tm.nameEntry).Widget,
+			widget.NewFormItem("Program", tm.programEntry).Widget,
+
+			widget.NewSeparator(),
+			widget.NewLabel("Roster (running order):"),
+			container.NewGridWrap(fyne.NewSize(360, 200), tm.rosterList),
+			container.NewHBox(tm.moveUpBtn, tm.moveDownBtn, tm.removeMemberBtn),
+			container.NewBorder(nil, nil, nil, tm.addMemberBtn, tm.memberSelect),
+
+			widget.NewSeparator(),
+			container.NewHBox(
+				tm.addBtn,
+				tm.updateBtn,
+				tm.deleteBtn,
+			),
+		),
+	)
+
+	tFileStatus, tFilePath := getFileStatusParts(data.TeamPathFor(config.Settings.ParticipantFile))
+	statusCard := widget.NewCard("File Status", "",
+		container.NewVBox(
+			widget.NewLabel("Team file: "+tFileStatus),
+			widget.NewLabel(tFilePath),
+		),
+	)
+
+	teamsPanel := container.NewBorder(
+		nil,
+		container.NewHBox(tm.refreshBtn, tm.fromTeamsBtn),
+		nil, nil,
+		tm.teamList,
+	)
+
+	leftPanel := container.NewVBox(form, statusCard)
+	return container.NewHSplit(container.NewScroll(leftPanel), teamsPanel)
+}
+
+// Event handlers
+func (tm *TeamManagerUI) onTeamSelected(id widget.ListItemID) {
+	tm.selectedTeam = int(id)
+
+	if id >= 0 && id < len(tm.teams) {
+		team := tm.teams[id]
+
+		tm.nameEntry.SetText(team.Name)
+		tm.programEntry.SetText(team.Program)
+		tm.setRoster(team.Members)
+
+		tm.updateBtn.Enable()
+		tm.deleteBtn.Enable()
+	}
+}
+
+// Data operations
+func (tm *TeamManagerUI) addTeam() {
+	team := tm.getTeamFromForm()
+	if team == nil {
+		return
+	}
+
+	if err := tm.teamMgr.AddTeam(*team); err != nil {
+		dialog.ShowError(err, tm.window)
+		return
+	}
+	if err := tm.teamMgr.SaveTeams(); err != nil {
+		dialog.ShowError(fmt.Errorf("team added but failed to save: %w", err), tm.window)
+		return
+	}
+
+	tm.clearForm()
+	tm.refreshTeamList()
+	dialog.ShowInformation("Success", "Team added and saved successfully", tm.window)
+}
+
+func (tm *TeamManagerUI) updateTeam() {
+	if tm.selectedTeam < 0 || tm.selectedTeam >= len(tm.teams) {
+		dialog.ShowError(fmt.Errorf("no team selected"), tm.window)
+		return
+	}
+
+	team := tm.getTeamFromForm()
+	if team == nil {
+		return
+	}
+
+	oldTeam := tm.teams[tm.selectedTeam]
+	team.ID = oldTeam.ID
+	if err := tm.teamMgr.UpdateTeam(*team); err != nil {
+		dialog.ShowError(err, tm.window)
+		return
+	}
+
+	// A rename carries over to the team's recorded results
+	if team.Name != oldTeam.Name {
+		if err := tm.resultMgr.RenameTeam(team.ID, team.Name); err != nil {
+			dialog.ShowError(fmt.Errorf("error renaming results: %w", err), tm.window)
+			return
+		}
+		if err := tm.resultMgr.SaveResults(); err != nil {
+			dialog.ShowError(fmt.Errorf("error saving results: %w", err), tm.window)
+			return
+		}
+	}
+
+	if err := tm.teamMgr.SaveTeams(); err != nil {
+		dialog.ShowError(fmt.Errorf("team updated but failed to save: %w", err), tm.window)
+		return
+	}
+
+	tm.clearForm()
+	tm.refreshTeamList()
+	dialog.ShowInformation("Success", "Team updated and saved successfully", tm.window)
+}
+
+func (tm *TeamManagerUI) deleteTeam() {
+	if tm.selectedTeam < 0 || tm.selectedTeam >= len(tm.teams) {
+		dialog.ShowError(fmt.Errorf("no team selected"), tm.window)
+		return
+	}
+
+	team := tm.teams[tm.selectedTeam]
+
+	dialog.ShowConfirm("Confirm Delete",
+		fmt.Sprintf("Are you sure you want to delete team '%s'?\n\nResults already recorded for the team are kept.", team.Name),
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if err := tm.teamMgr.RemoveTeam(team.ID); err != nil {
+				dialog.ShowError(err, tm.window)
+				return
+			}
+			if err := tm.teamMgr.SaveTeams(); err != nil {
+				dialog.ShowError(fmt.Errorf("team deleted but failed to save: %w", err), tm.window)
+				return
+			}
+
+			tm.clearForm()
+			tm.refreshTeamList()
+			dialog.ShowInformation("Success", "Team deleted and saved successfully", tm.window)
+		}, tm.window)
+}
+
+// createFromParticipants adds a team for every team name typed in the
+// participant list that has no team yet.
+func (tm *TeamManagerUI) createFromParticipants() {
+	teams := data.TeamsFromParticipants(tm.participantMgr.GetParticipants(), tm.teamMgr.GetTeams())
+	if len(teams) == 0 {
+		dialog.ShowInformation("No New Teams", "Every team named in the participant list already exists.", tm.window)
+		return
+	}
+
+	for _, team := range teams {
+		if err := tm.teamMgr.AddTeam(team); err != nil {
+			dialog.ShowError(err, tm.window)
+			return
+		}
+	}
+	if err := tm.teamMgr.SaveTeams(); err != nil {
+		dialog.ShowError(fmt.Errorf("teams created but failed to save: %w", err), tm.window)
+		return
+	}
+
+	tm.refreshTeamList()
+	dialog.ShowInformation("Success", fmt.Sprintf("Created %d team(s) from the participant list", len(teams)), tm.window)
+}
+
+// Roster editing
+func (tm *TeamManagerUI) addMember() {
+	id, ok := tm.memberOptions[tm.memberSelect.Selected]
+	if !ok {
+		dialog.ShowError(fmt.Errorf("no participant selected"), tm.window)
+		return
+	}
+	for _, m := range tm.roster {
+		if m == id {
+			dialog.ShowError(fmt.Errorf("%s is already on the roster", tm.memberName(id)), tm.window)
+			return
+		}
+	}
+
+	tm.roster = append(tm.roster, id)
+	tm.memberSelect.ClearSelected()
+	tm.rosterList.Refresh()
+}
+
+func (tm *TeamManagerUI) removeMember() {
+	if tm.selectedMember < 0 || tm.selectedMember >= len(tm.roster) {
+		return
+	}
+	tm.roster = append(tm.roster[:tm.selectedMember], tm.roster[tm.selectedMember+1:]...)
+	tm.clearMemberSelection()
+}
+
+// moveMember moves the selected roster entry up (-1) or down (+1) in the
+// running order.
+func (tm *TeamManagerUI) moveMember(delta int) {
+	i := tm.selectedMember
+	j := i + delta
+	if i < 0 || i >= len(tm.roster) || j < 0 || j >= len(tm.roster) {
+		return
+	}
+	tm.roster[i], tm.roster[j] = tm.roster[j], tm.roster[i]
+	tm.rosterList.Select(j)
+	tm.rosterList.Refresh()
+}
+
+func (tm *TeamManagerUI) loadData() {
+	if config.Settings.ParticipantFile == "" {
+		dialog.ShowInformation("No Participant File",
+			"No participant file is configured. Please use Contest Wizard to create a contest or set the file path in Configuration.",
+			tm.window)
+		tm.teams = nil
+		tm.teamList.Refresh()
+		return
+	}
+
+	tm.session.attach(func(s *data.Session) {
+		tm.participantMgr = s.Participants()
+		tm.teamMgr = s.Teams()
+		tm.resultMgr = s.Results()
+		tm.loadFiles()
+	})
+}
+
+// loadFiles (re)loads the shared managers from the configured files,
+// creating a missing team file.
+func (tm *TeamManagerUI) loadFiles() {
+	if utils.DoesFileExist(config.Settings.ParticipantFile) {
+		if err := tm.participantMgr.LoadParticipants(config.Settings.ParticipantFile); err != nil {
+			showLoadError(fmt.Errorf("error loading participants from %s: %w", config.Settings.ParticipantFile, err), tm.window, tm.loadData)
+		}
+	}
+	tm.refreshParticipants()
+
+	teamFile := data.TeamPathFor(config.Settings.ParticipantFile)
+	if utils.DoesFileExist(teamFile) {
+		if err := tm.teamMgr.LoadTeams(teamFile); err != nil {
+			showLoadError(fmt.Errorf("error loading teams from %s: %w", teamFile, err), tm.window, tm.loadData)
+		}
+	} else if err := tm.teamMgr.SaveTeams(); err != nil {
+		dialog.ShowError(fmt.Errorf("error creating team file %s: %w", teamFile, err), tm.window)
+	}
+	tm.refreshTeamList()
+
+	// Results are needed so a team rename reaches them
+	if config.Settings.ResultFile != "" && utils.DoesFileExist(config.Settings.ResultFile) {
+		if err := tm.resultMgr.LoadResults(config.Settings.ResultFile); err != nil {
+			showLoadError(fmt.Errorf("error loading results: %w", err), tm.window, tm.loadData)
+		}
+	}
+}
+
+// Helper methods
+func (tm *TeamManagerUI) getTeamFromForm() *models.Team {
+	name := strings.TrimSpace(tm.nameEntry.Text)
+	if name == "" {
+		dialog.ShowError(fmt.Errorf("team name is required"), tm.window)
+		return nil
+	}
+
+	return &models.Team{
+		Name:    name,
+		Program: strings.TrimSpace(tm.programEntry.Text),
+		Members: append([]string(nil), tm.roster...),
+	}
+}
+
+func (tm *TeamManagerUI) clearForm() {
+	tm.nameEntry.SetText("")
+	tm.programEntry.SetText("")
+	tm.setRoster(nil)
+
+	tm.selectedTeam = -1
+	tm.teamList.UnselectAll()
+	tm.updateBtn.Disable()
+	tm.deleteBtn.Disable()
+}
+
+// setRoster replaces the roster being edited.
+func (tm *TeamManagerUI) setRoster(members []string) {
+	tm.roster = append([]string(nil), members...)
+	tm.clearMemberSelection()
+}
+
+func (tm *TeamManagerUI) clearMemberSelection() {
+	tm.selectedMember = -1
+	tm.rosterList.UnselectAll()
+	tm.rosterList.Refresh()
+	tm.removeMemberBtn.Disable()
+	tm.moveUpBtn.Disable()
+	tm.moveDownBtn.Disable()
+}
+
+// memberName returns the name of a roster member, or a placeholder for a
+// participant that has since been deleted.
+func (tm *TeamManagerUI) memberName(id string) string {
+	if p, ok := tm.participantsByID[id]; ok {
+		return p.Name
+	}
+	return "(removed participant)"
+}
+
+func (tm *TeamManagerUI) refreshTeamList() {
+	tm.teams = tm.teamMgr.GetTeams()
+
+	sort.Slice(tm.teams, func(i, j int) bool {
+		return tm.teams[i].Name < tm.teams[j].Name
+	})
+
+	tm.teamList.Refresh()
+}
+
+// refreshParticipants rebuilds the participant choices for the roster.
+func (tm *TeamManagerUI) refreshParticipants() {
+	tm.participants = tm.participantMgr.GetParticipants()
+	sort.Slice(tm.participants, func(i, j int) bool {
+		return tm.participants[i].Name < tm.participants[j].Name
+	})
+
+	tm.participantsByID = make(map[string]models.Participant, len(tm.participants))
+	tm.memberOptions = make(map[string]string, len(tm.participants))
+	options := make([]string, 0, len(tm.participants))
+	for _, p := range tm.participants {
+		tm.participantsByID[p.ID] = p
+		label := fmt.Sprintf("%s (%s, %s)", p.Name, p.Program, p.Team)
+		// Participants may share a name; keep every option distinct
+		for n := 2; tm.memberOptions[label] != ""; n++ {
+			label = fmt.Sprintf("%s (%s, %s) #%d", p.Name, p.Program, p.Team, n)
+		}
+		tm.memberOptions[label] = p.ID
+		options = append(options, label)
+	}
+
+	tm.memberSelect.Options = options
+	tm.memberSelect.Refresh()
+	tm.rosterList.Refresh()
+}
+
+// onDataChanged refreshes the lists after another window or program changed
+// the contest data.
+func (tm *TeamManagerUI) onDataChanged(c data.Change) {
+	if c.Participants {
+		tm.refreshParticipants()
+		tm.teamList.Refresh()
+	}
+	if c.Teams {
+		tm.refreshTeamList()
+	}
+}
+
+// Show displays the team manager window
+func (tm *TeamManagerUI) Show() {
+	tm.window.Show()
+}
@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"chugware/internal/config"
+)
+
+// backupTimeFormat sorts lexically in chronological order.
+const backupTimeFormat = "20060102-150405.000"
+
+// CorruptFileError is returned by FillListFromJSONFile (and the data store
+// loaders) when a data file exists but cannot be parsed, e.g. after a crash in
+// the middle of a write.
+// Backup is the newest backup that still parses, or "" if there is none.
+type CorruptFileError struct {
+	Path   string
+	Backup string
+	Err    error
+}
+
+func (e *CorruptFileError) Error() string {
+	msg := fmt.Sprintf("file %s is corrupt: %v", e.Path, e.Err)
+	if e.Backup != "" {
+		msg += fmt.Sprintf(" (backup available: %s)", filepath.Base(e.Backup))
+	}
+	return msg
+}
+
+func (e *CorruptFileError) Unwrap() error {
+	return e.Err
+}
+
+// WriteFileAtomic writes data to filename without ever leaving a partially
+// written file behind: the data goes to a temp file in the same directory,
+// is synced to disk and then renamed over filename.
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(filename)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temp file for %s: %w", filename, err)
+	}
+	tmpName := tmp.Name()
+	// Clean up the temp file on any failure; after a successful rename it no
+	// longer exists and Remove is a no-op
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing file %s: %w", filename, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error syncing file %s: %w", filename, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error closing file %s: %w", filename, err)
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return fmt.Errorf("error setting permissions on %s: %w", filename, err)
+	}
+	if err := os.Rename(tmpName, filename); err != nil {
+		return fmt.Errorf("error replacing file %s: %w", filename, err)
+	}
+
+	// Persist the rename itself. Directories cannot be synced on Windows, so
+	// this is best effort.
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// BackupDir returns the backup folder for a data file.
+func BackupDir(filename string) string {
+	return filepath.Join(filepath.Dir(filename), config.BackupsDirectory)
+}
+
+// backupStem is the prefix shared by every backup of filename.
+func backupStem(filename string) (stem, ext string) {
+	base := filepath.Base(filename)
+	ext = filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "_", ext
+}
+
+// WriteBackup stores data as a new timestamped backup of filename and prunes
+// the oldest backups so that at most config.MaxBackups remain.
+func WriteBackup(filename string, data []byte) error {
+	stem, ext := backupStem(filename)
+	name := stem + time.Now().Format(backupTimeFormat) + ext
+	if err := WriteFileAtomic(filepath.Join(BackupDir(filename), name), data, 0644); err != nil {
+		return fmt.Errorf("error writing backup of %s: %w", filename, err)
+	}
+
+	backups, err := ListBackups(filename)
+	if err != nil {
+		return err
+	}
+	for len(backups) > config.MaxBackups {
+		if err := os.Remove(backups[len(backups)-1]); err != nil {
+			return fmt.Errorf("error pruning backup: %w", err)
+		}
+		backups = backups[:len(backups)-1]
+	}
+	return nil
+}
+
+// ListBackups returns the backups of filename, newest first.
+func ListBackups(filename string) ([]string, error) {
+	entries, err := os.ReadDir(BackupDir(filename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing backups for %s: %w", filename, err)
+	}
+
+	stem, ext := backupStem(filename)
+	var backups []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, stem) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		// Only accept a timestamp between stem and extension, so that
+		// "results_journal" backups are never mistaken for "results" ones
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, stem), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(BackupDir(filename), name))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// NewestValidBackup returns the newest backup of filename that is valid JSON,
+// or "" if there is none.
+func NewestValidBackup(filename string) string {
+	backups, err := ListBackups(filename)
+	if err != nil {
+		return ""
+	}
+	for _, b := range backups {
+		data, err := os.ReadFile(b)
+		if err != nil {
+			continue
+		}
+		if json.Valid(data) {
+			return b
+		}
+	}
+	return ""
+}
+
+// RecoverFromBackup replaces a corrupt filename with its newest valid backup.
+// The corrupt file is kept next to the backups for inspection. It returns the
+// backup that was restored.
+func RecoverFromBackup(filename string) (string, error) {
+	backup := NewestValidBackup(filename)
+	if backup == "" {
+		return "", fmt.Errorf("no valid backup found for %s", filename)
+	}
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		return "", fmt.Errorf("error reading backup %s: %w", backup, err)
+	}
+
+	if DoesFileExist(filename) {
+		stem, ext := backupStem(filename)
+		kept := filepath.Join(BackupDir(filename), stem+"corrupt-"+time.Now().Format(backupTimeFormat)+ext)
+		if err := os.Rename(filename, kept); err != nil {
+			return "", fmt.Errorf("error setting aside corrupt file %s: %w", filename, err)
+		}
+	}
+
+	if err := WriteFileAtomic(filename, data, 0644); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// IsCorruptFile reports whether err (or anything it wraps) is a CorruptFileError.
+func IsCorruptFile(err error) (*CorruptFileError, bool) {
+	var corrupt *CorruptFileError
+	if errors.As(err, &corrupt) {
+		return corrupt, true
+	}
+	return nil, false
+}
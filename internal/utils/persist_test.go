@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"chugware/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// WriteFileAtomic
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestWriteFileAtomic_ReplacesContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+	require.NoError(t, WriteFileAtomic(path, []byte("new"), 0644))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+}
+
+func TestWriteFileAtomic_LeavesNoTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, WriteFileAtomic(filepath.Join(dir, "results.json"), []byte("[]"), 0644))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "results.json", entries[0].Name())
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Backups
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestSaveListToJSONFile_WritesBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, SaveListToJSONFile(path, []map[string]string{{"name": "Alice"}}))
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, BackupDir(path), filepath.Dir(backups[0]))
+}
+
+func TestWriteBackup_KeepsOnlyNewest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	for i := 0; i < config.MaxBackups+3; i++ {
+		require.NoError(t, WriteBackup(path, []byte("[]")))
+		time.Sleep(2 * time.Millisecond) // distinct timestamps
+	}
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	assert.Len(t, backups, config.MaxBackups)
+}
+
+func TestListBackups_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.json")
+	require.NoError(t, WriteBackup(path, []byte("[]")))
+	require.NoError(t, WriteBackup(filepath.Join(dir, "results_journal.json"), []byte("[]")))
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestListBackups_NoBackupDir(t *testing.T) {
+	backups, err := ListBackups(filepath.Join(t.TempDir(), "results.json"))
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Corruption detection and recovery
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestFillListFromJSONFile_CorruptFileNamesBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, SaveListToJSONFile(path, []map[string]string{{"name": "Alice"}}))
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "Al`), 0644)) // truncated write
+
+	_, err := FillListFromJSONFile(path)
+	corrupt, ok := IsCorruptFile(err)
+	require.True(t, ok, "expected a CorruptFileError, got %v", err)
+	assert.Equal(t, path, corrupt.Path)
+	assert.NotEmpty(t, corrupt.Backup)
+}
+
+func TestNewestValidBackup_SkipsCorruptBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, WriteBackup(path, []byte(`[{"name":"Alice"}]`)))
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, WriteBackup(path, []byte(`[{"na`)))
+
+	backups, err := ListBackups(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, backups[1], NewestValidBackup(path))
+}
+
+func TestRecoverFromBackup_RestoresNewestValid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, SaveListToJSONFile(path, []map[string]string{{"name": "Alice"}}))
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0644))
+
+	_, err := RecoverFromBackup(path)
+	require.NoError(t, err)
+
+	loaded, err := FillListFromJSONFile(path)
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	assert.Equal(t, "Alice", loaded[0]["name"])
+
+	// The corrupt file is kept aside, not deleted
+	entries, err := os.ReadDir(BackupDir(path))
+	require.NoError(t, err)
+	found := false
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "results_corrupt-") {
+			found = true
+		}
+	}
+	assert.True(t, found, "corrupt file should be preserved in the backups folder")
+}
+
+func TestRecoverFromBackup_NoBackup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0644))
+
+	_, err := RecoverFromBackup(path)
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,11 +13,14 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"chugware/internal/config"
 )
 
-// FillListFromJSONFile reads and parses a JSON file into a slice of maps
+// FillListFromJSONFile reads and parses a JSON file into a slice of maps.
+// A file that exists but does not parse yields a *CorruptFileError naming the
+// newest valid backup, which RecoverFromBackup can restore.
 func FillListFromJSONFile(filename string) ([]map[string]string, error) {
 	var list []map[string]string
 
@@ -30,31 +35,35 @@ func FillListFromJSONFile(filename string) ([]map[string]string, error) {
 
 	err = json.Unmarshal(data, &list)
 	if err != nil {
-		return list, fmt.Errorf("error parsing JSON file %s: %w", filename, err)
+		return list, &CorruptFileError{
+			Path:   filename,
+			Backup: NewestValidBackup(filename),
+			Err:    fmt.Errorf("error parsing JSON file %s: %w", filename, err),
+		}
 	}
 
 	return list, nil
 }
 
-// SaveListToJSONFile saves a slice of maps to a JSON file
+// SaveListToJSONFile saves a slice of maps to a JSON file.
+// The write is atomic, and a timestamped copy is kept in the backups folder
+// next to the file.
 func SaveListToJSONFile(filename string, list []map[string]string) error {
-	data, err := json.MarshalIndent(list, "", "  ")
+	return SaveJSONFile(filename, list)
+}
+
+// SaveJSONFile saves any JSON-encodable value the same way as SaveListToJSONFile.
+func SaveJSONFile(filename string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshalling JSON: %w", err)
 	}
 
-	// Ensure directory exists
-	dir := filepath.Dir(filename)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("error creating directory %s: %w", dir, err)
+	if err := WriteFileAtomic(filename, data, 0644); err != nil {
+		return err
 	}
 
-	err = os.WriteFile(filename, data, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing file %s: %w", filename, err)
-	}
-
-	return nil
+	return WriteBackup(filename, data)
 }
 
 // RemoveDuplicateDictionaries removes duplicate entries based on a specific key
@@ -97,9 +106,11 @@ func DoesFileExist(filename string) bool {
 	return !os.IsNotExist(err)
 }
 
-// IsStringValid checks if string length is within limits
+// IsStringValid checks if string length is within limits. The length is
+// counted in characters, so å, ä and ö count as one each.
 func IsStringValid(str string) bool {
-	return len(str) <= config.MaxStringLength && len(str) > 0
+	n := utf8.RuneCountInString(str)
+	return n <= config.MaxStringLength && n > 0
 }
 
 // IsNullString checks if string is empty or whitespace
@@ -265,6 +276,19 @@ func ParseTimeForComparison(timeStr string) int64 {
 	return 0
 }
 
+// FormatComparisonTime is the inverse of ParseTimeForComparison: it formats
+// tenths-of-milliseconds as HH:MM:SS.mmmm. Negative values format as "NaN".
+func FormatComparisonTime(tms int64) string {
+	if tms < 0 {
+		return "NaN"
+	}
+	hours := tms / (60 * 60 * 10000)
+	minutes := (tms / (60 * 10000)) % 60
+	seconds := (tms / 10000) % 60
+	subms := tms % 10000
+	return fmt.Sprintf("%02d:%02d:%02d.%04d", hours, minutes, seconds, subms)
+}
+
 // CreateContestFiles creates the necessary contest files and directories
 func CreateContestFiles(disciplines []string, contestPath string, contestName string, contestDate string) ([]string, error) {
 	var createdFiles []string
@@ -344,3 +368,14 @@ func CopyFile(src, dst string) error {
 	}
 	return nil
 }
+
+// NewID returns a random 12-character hex identifier for data rows.
+func NewID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand only fails if the OS entropy source is broken; fall back
+		// to the clock so callers still get a usable, unique-enough value
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
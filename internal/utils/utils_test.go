@@ -39,6 +39,8 @@ func TestIsStringValid(t *testing.T) {
 	assert.False(t, IsStringValid(""), "empty string should be invalid")
 	assert.False(t, IsStringValid(strings.Repeat("x", 256)), "string > MaxStringLength should be invalid")
 	assert.True(t, IsStringValid(strings.Repeat("x", 255)), "string == MaxStringLength should be valid")
+	assert.True(t, IsStringValid(strings.Repeat("å", 255)), "length is counted in characters, not bytes")
+	assert.False(t, IsStringValid(strings.Repeat("å", 256)))
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -132,6 +134,16 @@ func TestParseTimeForComparison(t *testing.T) {
 	}
 }
 
+func TestFormatComparisonTime(t *testing.T) {
+	assert.Equal(t, "00:00:00.0000", FormatComparisonTime(0))
+	assert.Equal(t, "00:00:05.5000", FormatComparisonTime(55000))
+	assert.Equal(t, "01:23:45.1000", FormatComparisonTime(36000000+23*600000+45*10000+1000))
+	assert.Equal(t, "NaN", FormatComparisonTime(-1))
+
+	// Round trip
+	assert.Equal(t, int64(123456), ParseTimeForComparison(FormatComparisonTime(123456)))
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // RemoveDuplicateDictionaries
 // ─────────────────────────────────────────────────────────────────────────────
@@ -408,3 +420,17 @@ func TestFilterFiles_MissingDirectory(t *testing.T) {
 	result := FilterFiles("/no/such/dir", "pattern")
 	assert.Equal(t, "No", result)
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// NewID
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestNewID_UniqueHex(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := NewID()
+		assert.Regexp(t, `^[0-9a-f]{12}$`, id)
+		assert.False(t, seen[id], "duplicate id %s", id)
+		seen[id] = true
+	}
+}